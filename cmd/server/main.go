@@ -1,17 +1,40 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	// Export time zones must load on hosts without a zoneinfo database
+	_ "time/tzdata"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/application/service"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/health"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/controller"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/middleware"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/routes"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/jobs"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/metrics"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/ratelimit"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/storage"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/tracing"
 	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func init() {
@@ -23,27 +46,351 @@ func init() {
 		CustomLinks:      map[string]string{},
 		PaginationLinks:  []string{"self", "collection"},
 	})
+	hateoas.GlobalInstance.RegisterResource("category", hateoas.ResourceConfig{
+		ResourceName:     "categories",
+		DefaultLinkTypes: []string{"self", "collection", "create", "show", "update", "delete"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self", "collection"},
+	})
+	hateoas.GlobalInstance.RegisterResource("import-profile", hateoas.ResourceConfig{
+		ResourceName:     "import-profiles",
+		DefaultLinkTypes: []string{"self", "collection", "create", "show", "update", "delete"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self", "collection"},
+	})
+	hateoas.GlobalInstance.RegisterResource("trash", hateoas.ResourceConfig{
+		ResourceName:     "trash",
+		DefaultLinkTypes: []string{"self", "collection"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self", "collection"},
+	})
+	hateoas.GlobalInstance.RegisterResource("recurring-transaction", hateoas.ResourceConfig{
+		ResourceName:     "recurring-transactions",
+		DefaultLinkTypes: []string{"self", "collection", "create", "show", "delete"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self", "collection"},
+	})
+	hateoas.GlobalInstance.RegisterResource("installment-purchase", hateoas.ResourceConfig{
+		ResourceName:     "installment-purchases",
+		DefaultLinkTypes: []string{"self", "collection", "create", "show"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self", "collection"},
+	})
+	hateoas.GlobalInstance.RegisterResource("job", hateoas.ResourceConfig{
+		ResourceName:     "jobs",
+		DefaultLinkTypes: []string{"self", "show"},
+		CustomLinks:      map[string]string{},
+		PaginationLinks:  []string{"self"},
+	})
 }
 
 func main() {
-	config, err := config.LoadConfig()
+	if err := run(); err != nil {
+		slog.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	configPath := flag.String("config", "", "path to the YAML config file (defaults to ./config.yaml)")
+	flag.Parse()
+
+	config, err := config.LoadConfig(*configPath)
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// db := db.NewGormDB(config)
-	db, err := db.NewGormDBWithAutoMigrate(config)
+	log, err := logger.New(os.Stdout, logger.Options{
+		Level:  config.Logging.Level,
+		Format: config.Logging.Format,
+		Redact: config.Logging.Redact,
+	})
 	if err != nil {
-		log.Fatalf("failed to initialize database: %v", err)
+		return fmt.Errorf("failed to configure logger: %w", err)
 	}
+	slog.SetDefault(log)
 
-	transactionRepository := repository.NewTransactionRepository(db)
-	transactionService := service.NewTransactionService(transactionRepository)
-	transactionController := controller.NewTransactionController(transactionService)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	router := gin.Default()
+	if config.Tracing.Enabled {
+		shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+			ServiceName: config.Tracing.ServiceName,
+			Exporter:    config.Tracing.Exporter,
+			Endpoint:    config.Tracing.Endpoint,
+			Insecure:    config.Tracing.Insecure,
+			SampleRatio: config.Tracing.SampleRatio,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			flushCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				slog.Error("failed to flush traces", "error", err)
+			}
+		}()
+	}
+
+	openDB := db.NewGormDB
+	if config.DB.AutoMigrate {
+		openDB = db.NewGormDBWithAutoMigrate
+	}
+	gormDB, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer closeDB(gormDB)
+
+	if config.Tracing.Enabled {
+		if err := db.RegisterQueryTracing(gormDB); err != nil {
+			return fmt.Errorf("failed to set up database tracing: %w", err)
+		}
+	}
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(config.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	servers := []*http.Server{newServer(config.Server, config.Server.Port, router)}
+
+	var businessMetrics interfaces.BusinessMetricsInterface = metrics.Noop{}
+	if config.Metrics.Enabled {
+		appMetrics, adminServer, err := setupMetrics(config.Metrics, config.Server, gormDB, router)
+		if err != nil {
+			return fmt.Errorf("failed to set up metrics: %w", err)
+		}
+		businessMetrics = appMetrics
+		if adminServer != nil {
+			servers = append(servers, adminServer)
+		}
+	}
+
+	systemClock := clock.System()
+
+	jobStorage, err := storage.NewLocalStorage(config.Jobs.StorageDir)
+	if err != nil {
+		return fmt.Errorf("failed to set up job storage: %w", err)
+	}
+	jobRepository := repository.NewJobRepository(gormDB)
+	jobService := service.NewJobService(jobRepository, jobStorage, systemClock, identifier.NewV7(), config.Jobs.MaxAttempts, config.Jobs.Retention)
+
+	unitOfWork := db.NewUnitOfWork(gormDB)
+	transactionRepository := repository.NewTransactionRepository(gormDB)
+	categoryRepository := repository.NewCategoryRepository(gormDB)
+	idempotencyRepository := repository.NewIdempotencyRepository(gormDB)
+	auditTrail := service.NewAuditTrailService(repository.NewAuditRepository(gormDB), systemClock, identifier.NewV7())
+	transactionService := service.NewTransactionService(unitOfWork, transactionRepository, categoryRepository, auditTrail, businessMetrics, systemClock, identifier.NewV7())
+	exportLocation, err := time.LoadLocation(config.Export.TimeZone)
+	if err != nil {
+		return fmt.Errorf("invalid export time zone: %w", err)
+	}
+	transactionController := controller.NewTransactionController(transactionService, jobService, config.Concurrency.RequireIfMatch, config.Batch.MaxOperations, config.Export.WriteTimeout, exportLocation, systemClock)
+	categoryService := service.NewCategoryService(unitOfWork, categoryRepository, transactionRepository, auditTrail, systemClock, identifier.NewV7())
+	categoryController := controller.NewCategoryController(categoryService, config.Concurrency.RequireIfMatch)
+	importProfileRepository := repository.NewImportProfileRepository(gormDB)
+	importProfileService := service.NewImportProfileService(importProfileRepository, categoryRepository, systemClock, identifier.NewV7())
+	importService := service.NewImportService(importProfileRepository, categoryRepository, transactionRepository, transactionService, config.Import.MaxRows)
+	recurringTransactionService := service.NewRecurringTransactionService(unitOfWork, repository.NewRecurringTransactionRepository(gormDB), categoryRepository, transactionService, systemClock, identifier.NewV7())
+	installmentPurchaseService := service.NewInstallmentPurchaseService(unitOfWork, repository.NewInstallmentPurchaseRepository(gormDB), categoryRepository, transactionService, systemClock, identifier.NewV7())
+	trashService := service.NewTrashService(unitOfWork, repository.NewTrashRepository(gormDB), transactionRepository, categoryRepository, systemClock, config.Trash.Retention)
+
+	checker := health.NewChecker(config.Health.CheckTimeout, db.PingCheck(gormDB), db.MigrationCheck(gormDB))
+	healthController := controller.NewHealthController(checker)
+
+	var rateLimiter ratelimit.Store
+	if config.RateLimit.Enabled {
+		rateLimiter = ratelimit.NewMemoryStore(systemClock, config.RateLimit.IdleTTL)
+	}
+
+	routes.SetupRoutes(router, routes.Dependencies{
+		Config:                         config,
+		Logger:                         log,
+		Clock:                          systemClock,
+		RateLimiter:                    rateLimiter,
+		IdempotencyRepository:          idempotencyRepository,
+		HealthController:               healthController,
+		OpenAPIController:              controller.NewOpenAPIController(),
+		TransactionController:          transactionController,
+		CategoryController:             categoryController,
+		TrashController:                controller.NewTrashController(trashService),
+		ImportController:               controller.NewImportController(importService, importProfileService, jobService, config.Import.MaxFileBytes),
+		JobController:                  controller.NewJobController(jobService, config.Export.WriteTimeout),
+		RecurringTransactionController: controller.NewRecurringTransactionController(recurringTransactionService, config.Concurrency.RequireIfMatch),
+		InstallmentPurchaseController:  controller.NewInstallmentPurchaseController(installmentPurchaseService, config.Concurrency.RequireIfMatch),
+	})
+
+	var background sync.WaitGroup
+	inBackground := func(run func()) {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			run()
+		}()
+	}
+
+	inBackground(func() {
+		purgePeriodically(ctx, config.Idempotency.PurgeInterval, "idempotency keys", func(ctx context.Context) (int64, error) {
+			return idempotencyRepository.DeleteExpired(ctx, systemClock.Now())
+		})
+	})
+	inBackground(func() { purgePeriodically(ctx, config.Trash.PurgeInterval, "trash", trashService.Purge) })
+	inBackground(func() { purgePeriodically(ctx, config.Jobs.PurgeInterval, "jobs", jobService.Purge) })
+	inBackground(func() {
+		every(ctx, config.Recurring.MaterializeInterval, func(ctx context.Context) {
+			recorded, err := recurringTransactionService.MaterializeDue(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "recurring transactions materialization failed", "error", err)
+			}
+			if recorded > 0 {
+				slog.InfoContext(ctx, "recurring transactions materialized", "recorded", recorded)
+			}
+		})
+	})
+
+	pool := jobs.NewPool(jobRepository, jobStorage, systemClock, jobs.Handlers(transactionService, importService), jobs.Options{
+		Workers:           config.Jobs.Workers,
+		PollInterval:      config.Jobs.PollInterval,
+		HeartbeatInterval: config.Jobs.HeartbeatInterval,
+		Lease:             config.Jobs.Lease,
+		RetryBackoff:      config.Jobs.RetryBackoff,
+	})
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		pool.Run(ctx)
+	}()
+
+	serveErr := serve(ctx, config.Server.DrainDelay, config.Server.ShutdownTimeout, checker, servers...)
+
+	// running jobs are put back in the queue, and the purges and materialization under way
+	// finish, before the database is closed
+	stop()
+	<-workersDone
+	slog.Info("job workers stopped")
+	background.Wait()
+	slog.Info("background tasks stopped")
+
+	return serveErr
+}
+
+func newServer(server config.ServerConfig, port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           handler,
+		ReadTimeout:       server.ReadTimeout,
+		ReadHeaderTimeout: server.ReadHeaderTimeout,
+		WriteTimeout:      server.WriteTimeout,
+		IdleTimeout:       server.IdleTimeout,
+	}
+}
+
+// serve runs every server until one fails or ctx is cancelled by a signal. On shutdown
+// readiness fails for drainDelay first, so load balancers notice and stop routing traffic here
+// while the listeners still accept it, then in-flight requests get shutdownTimeout to finish.
+func serve(ctx context.Context, drainDelay, shutdownTimeout time.Duration, checker *health.Checker, servers ...*http.Server) error {
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			slog.Info("server listening", "addr", server.Addr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("server on %s: %w", server.Addr, err)
+			}
+		}()
+	}
+
+	var serveErr error
+	select {
+	case serveErr = <-errs:
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining connections", "delay", drainDelay, "timeout", shutdownTimeout)
+	}
+
+	checker.Drain()
+	if serveErr == nil {
+		time.Sleep(drainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			serveErr = errors.Join(serveErr, fmt.Errorf("shutdown of %s: %w", server.Addr, err))
+		}
+	}
+
+	slog.Info("server stopped")
+	return serveErr
+}
+
+// purgePeriodically runs purge every interval until ctx is cancelled
+func purgePeriodically(ctx context.Context, interval time.Duration, what string, purge func(ctx context.Context) (int64, error)) {
+	every(ctx, interval, func(ctx context.Context) {
+		deleted, err := purge(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "purge failed", "what", what, "error", err)
+			return
+		}
+		if deleted > 0 {
+			slog.InfoContext(ctx, "purged expired records", "what", what, "deleted", deleted)
+		}
+	})
+}
+
+// every runs fn every interval until ctx is cancelled
+func every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
+
+func closeDB(gormDB *gorm.DB) {
+	sqlDB, err := gormDB.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		slog.Error("failed to close database", "error", err)
+	}
+}
+
+// setupMetrics instruments the router and the database, and serves the metrics either on
+// the main router or, when metrics.admin_port is set, on a separate admin server
+func setupMetrics(metricsConfig config.MetricsConfig, server config.ServerConfig, gormDB *gorm.DB, router *gin.Engine) (*metrics.Metrics, *http.Server, error) {
+	appMetrics := metrics.New()
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := appMetrics.RegisterDBStats(sqlDB, "primary"); err != nil {
+		return nil, nil, err
+	}
+	if err := db.RegisterQueryMetrics(gormDB, appMetrics); err != nil {
+		return nil, nil, err
+	}
+
+	router.Use(middleware.Metrics(appMetrics))
+
+	path := metricsConfig.Path
+	adminPort := metricsConfig.AdminPort
+	if adminPort == 0 {
+		router.GET(path, gin.WrapH(appMetrics.Handler()))
+		return appMetrics, nil, nil
+	}
 
-	routes.SetupRoutes(router, transactionController)
+	adminMux := http.NewServeMux()
+	adminMux.Handle(path, appMetrics.Handler())
 
-	router.Run(fmt.Sprintf(":%d", config.GetInt("server.port")))
+	return appMetrics, newServer(server, adminPort, adminMux), nil
 }
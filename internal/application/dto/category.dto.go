@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+type CreateCategoryDTO struct {
+	UserID uuid.UUID
+	Name   string
+	Type   enum.CategoryType
+	Icon   string
+}
+
+type UpdateCategoryDTO struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Version int64 // Version the change is based on, or 0 to overwrite whatever is stored
+	Name    string
+	Type    enum.CategoryType
+	Icon    string
+}
+
+// PatchCategoryDTO changes only the fields that are set, keeping the others as stored
+type PatchCategoryDTO struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Version int64 // Version the change is based on, or 0 for the version stored when it is applied
+	Name    *string
+	Type    *enum.CategoryType
+	Icon    *string
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+type CreateImportProfileDTO struct {
+	UserID   uuid.UUID
+	Name     string
+	Settings entity.ImportSettings
+}
+
+type UpdateImportProfileDTO struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Name     string
+	Settings entity.ImportSettings
+}
+
+// ImportCSVDTO is a CSV statement to read with the settings of a saved profile (ProfileID) or
+// with the given Settings. Unless Commit is set the rows are only previewed.
+type ImportCSVDTO struct {
+	UserID    uuid.UUID
+	ProfileID uuid.UUID
+	Settings  *entity.ImportSettings
+	Content   []byte
+	Commit    bool
+}
+
+// ImportOFXDTO is an OFX statement whose entries go to the given categories by the sign of their
+// amount, or to the default categories of the user when not given. Unless Commit is set the
+// entries are only previewed.
+type ImportOFXDTO struct {
+	UserID            uuid.UUID
+	ExpenseCategoryID uuid.UUID
+	IncomeCategoryID  uuid.UUID
+	Content           []byte
+	Commit            bool
+}
+
+// ImportRowDTO is a data row of a statement. Transaction holds the values read from a ready,
+// duplicate or imported row, Imported the transaction created from it, and Err why an invalid
+// row cannot be imported.
+type ImportRowDTO struct {
+	Line        int
+	Raw         []string
+	Status      enum.ImportRowStatus
+	Transaction *CreateTransactionDTO
+	Imported    *entity.Transaction
+	Err         error
+}
+
+type ImportResultDTO struct {
+	Committed bool
+	Rows      []ImportRowDTO
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+type CreateInstallmentPurchaseDTO struct {
+	UserID       uuid.UUID
+	CategoryID   uuid.UUID
+	Description  string
+	TotalAmount  float64
+	Installments int
+	FirstDueAt   time.Time
+	Remainder    enum.InstallmentRemainder
+}
+
+// EditRemainingInstallmentsDTO changes the installments of a purchase that are not due yet.
+// TotalAmount is the new total of the purchase, installments already due included.
+type EditRemainingInstallmentsDTO struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Version     int64 // Version the change is based on, or 0 to overwrite whatever is stored
+	CategoryID  uuid.UUID
+	Description string
+	TotalAmount float64
+}
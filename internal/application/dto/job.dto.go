@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+// EnqueueJobDTO is a job to run in the background. Payload holds the parameters of its kind and
+// is stored as JSON; Input is the file the job reads, if any.
+type EnqueueJobDTO struct {
+	UserID  uuid.UUID
+	Kind    enum.JobKind
+	Payload any
+	Input   []byte
+}
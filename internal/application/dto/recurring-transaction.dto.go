@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type CreateRecurringTransactionDTO struct {
+	UserID      uuid.UUID
+	CategoryID  uuid.UUID
+	Amount      float64
+	Description string
+	StartAt     time.Time
+	Rule        entity.RecurrenceRule
+}
+
+// SkipOccurrenceDTO keeps the occurrence of a series falling on Date from being recorded
+type SkipOccurrenceDTO struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Version int64 // Version the change is based on, or 0 to overwrite whatever is stored
+	Date    time.Time
+}
+
+// ResumeRecurringTransactionDTO records the occurrences of a paused series again
+type ResumeRecurringTransactionDTO struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Version int64 // Version the change is based on, or 0 to overwrite whatever is stored
+}
+
+// EditFollowingOccurrencesDTO changes the occurrence of a series falling on Date and every one
+// after it
+type EditFollowingOccurrencesDTO struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Version     int64 // Version the change is based on, or 0 to overwrite whatever is stored
+	Date        time.Time
+	CategoryID  uuid.UUID
+	Amount      float64
+	Description string
+	Rule        entity.RecurrenceRule
+}
@@ -3,13 +3,76 @@ package dto
 import (
 	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
 	"github.com/google/uuid"
 )
 
+// CreateTransactionDTO is a transaction to create. ExternalID is only set for transactions
+// imported from a bank statement, as the id the bank gave the entry.
 type CreateTransactionDTO struct {
 	UserID      uuid.UUID
 	CategoryID  uuid.UUID
 	Amount      float64
 	Datetime    time.Time
 	Description string
+	ExternalID  string
+}
+
+type UpdateTransactionDTO struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Version     int64 // Version the change is based on, or 0 to overwrite whatever is stored
+	CategoryID  uuid.UUID
+	Amount      float64
+	Datetime    time.Time
+	Description string
+}
+
+// PatchTransactionDTO changes only the fields that are set, keeping the others as stored
+type PatchTransactionDTO struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Version     int64 // Version the change is based on, or 0 for the version stored when it is applied
+	CategoryID  *uuid.UUID
+	Amount      *float64
+	Datetime    *time.Time
+	Description *string
+}
+
+type DeleteTransactionDTO struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Version int64 // Version the deletion is based on, or 0 to delete whatever is stored
+}
+
+// BatchTransactionDTO is a list of operations applied in order, either all or nothing (Atomic)
+// or each on its own
+type BatchTransactionDTO struct {
+	UserID     uuid.UUID
+	Atomic     bool
+	Operations []BatchOperationDTO
+}
+
+// BatchOperationDTO holds exactly one of Create, Update and Delete, or Err when the operation
+// could not be read from the request and fails without being attempted
+type BatchOperationDTO struct {
+	Create *CreateTransactionDTO
+	Update *UpdateTransactionDTO
+	Delete *DeleteTransactionDTO
+	Err    error
+}
+
+// BatchResultDTO is the outcome of the operation at the same position: the created or updated
+// transaction, nothing for a deletion, or the error that made it fail
+type BatchResultDTO struct {
+	Transaction *entity.Transaction
+	Err         error
+}
+
+// ExportedTransactionDTO is a transaction as exported, with the name and type of its category
+type ExportedTransactionDTO struct {
+	Transaction  *entity.Transaction
+	CategoryName string
+	CategoryType enum.CategoryType
 }
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+// AuditTrailInterface keeps the history of changes to financial records
+type AuditTrailInterface interface {
+	// Record appends the change userID made to a record, from before to after, tagged with the
+	// request ID carried by ctx. Call it inside the unit of work that makes the change, so the
+	// entry is stored if and only if the change is.
+	Record(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, action enum.AuditAction, before, after entity.AuditFields) error
+	// FindAllPaginated lists the changes the user made to a record, oldest first
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, page, pageSize int) ([]entity.AuditEntry, *pagination.Pagination, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+
+// BusinessMetricsInterface records domain events for monitoring
+type BusinessMetricsInterface interface {
+	TransactionCreated(categoryType enum.CategoryType)
+}
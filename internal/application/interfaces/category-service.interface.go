@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type CategoryServiceInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Category, *pagination.Pagination, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Category, error)
+	Create(ctx context.Context, createCategoryDTO *dto.CreateCategoryDTO) (*entity.Category, error)
+	Update(ctx context.Context, updateCategoryDTO *dto.UpdateCategoryDTO) (*entity.Category, error)
+	Patch(ctx context.Context, patchCategoryDTO *dto.PatchCategoryDTO) (*entity.Category, error)
+	// Delete moves the category along with its transactions to the trash, provided it is still at version, or at any
+	// version when version is 0
+	Delete(ctx context.Context, userID, id uuid.UUID, version int64) error
+	// Restore takes the category out of the trash, with the same version check as Delete
+	Restore(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.Category, error)
+}
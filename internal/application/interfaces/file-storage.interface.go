@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorageInterface keeps files by key, such as the statements uploaded for background imports
+// and the files jobs produce
+type FileStorageInterface interface {
+	// Create starts the file at key, which replaces any file stored there once the writer is closed
+	Create(ctx context.Context, key string) (io.WriteCloser, error)
+	// Open reads the file at key, failing with a not found error when there is none
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file at key, if there is one
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type ImportProfileServiceInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.ImportProfile, *pagination.Pagination, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.ImportProfile, error)
+	Create(ctx context.Context, createImportProfileDTO *dto.CreateImportProfileDTO) (*entity.ImportProfile, error)
+	Update(ctx context.Context, updateImportProfileDTO *dto.UpdateImportProfileDTO) (*entity.ImportProfile, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+)
+
+type ImportServiceInterface interface {
+	// ImportCSV reads the rows of a CSV statement and tells which are ready to import, invalid or
+	// already recorded. When committing, the ready rows are created as transactions, all or none.
+	ImportCSV(ctx context.Context, importCSVDTO *dto.ImportCSVDTO) (*dto.ImportResultDTO, error)
+	// ImportOFX does the same for the entries of an OFX statement, recognizing those imported
+	// before by the id the bank gave them
+	ImportOFX(ctx context.Context, importOFXDTO *dto.ImportOFXDTO) (*dto.ImportResultDTO, error)
+}
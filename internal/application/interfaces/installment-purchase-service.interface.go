@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type InstallmentPurchaseServiceInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.InstallmentPurchase, *pagination.Pagination, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.InstallmentPurchase, error)
+	// Create records the purchase along with a transaction for each of its installments
+	Create(ctx context.Context, createInstallmentPurchaseDTO *dto.CreateInstallmentPurchaseDTO) (*entity.InstallmentPurchase, error)
+	// EditRemaining changes the installments not due yet and their transactions
+	EditRemaining(ctx context.Context, editRemainingDTO *dto.EditRemainingInstallmentsDTO) (*entity.InstallmentPurchase, error)
+	// CancelRemaining cancels the installments not due yet and moves their transactions to the
+	// trash, provided the purchase is still at version, or at any version when version is 0
+	CancelRemaining(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.InstallmentPurchase, error)
+}
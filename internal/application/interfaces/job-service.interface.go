@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type JobServiceInterface interface {
+	// Enqueue queues a job to run in the background, storing the file it reads first
+	Enqueue(ctx context.Context, enqueueJobDTO *dto.EnqueueJobDTO) (*entity.Job, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Job, error)
+	// Cancel stops a job that has not finished; a running job stops at its next heartbeat
+	Cancel(ctx context.Context, userID, id uuid.UUID) (*entity.Job, error)
+	// OpenResult reads the file produced by a job that succeeded
+	OpenResult(ctx context.Context, userID, id uuid.UUID) (*entity.Job, io.ReadCloser, error)
+	// Purge removes the jobs, and their files, that finished longer ago than the retention period
+	Purge(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionServiceInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.RecurringTransaction, *pagination.Pagination, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.RecurringTransaction, error)
+	Create(ctx context.Context, createRecurringTransactionDTO *dto.CreateRecurringTransactionDTO) (*entity.RecurringTransaction, error)
+	// Delete removes the series, provided it is still at version, or at any version when version
+	// is 0. Transactions already recorded from it are kept.
+	Delete(ctx context.Context, userID, id uuid.UUID, version int64) error
+	Skip(ctx context.Context, skipOccurrenceDTO *dto.SkipOccurrenceDTO) (*entity.RecurringTransaction, error)
+	// Resume records the occurrences of a paused series again, passing over those that fell due
+	// while it was paused
+	Resume(ctx context.Context, resumeDTO *dto.ResumeRecurringTransactionDTO) (*entity.RecurringTransaction, error)
+	// EditFollowing changes an occurrence and the ones after it. Editing from the first occurrence
+	// changes the series itself; editing from a later one ends the series before it and starts a
+	// new one, which is returned.
+	EditFollowing(ctx context.Context, editFollowingDTO *dto.EditFollowingOccurrencesDTO) (*entity.RecurringTransaction, error)
+	// MaterializeDue records the occurrences that are due as transactions, returning how many
+	// were recorded. Series whose occurrences are refused are paused.
+	MaterializeDue(ctx context.Context) (int64, error)
+}
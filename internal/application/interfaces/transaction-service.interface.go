@@ -1,12 +1,31 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
 )
 
 type TransactionServiceInterface interface {
-	FindAllPaginated(page, pageSize int) ([]entity.Transaction, *pagination.Pagination, error)
-	Create(createTransactionDTO *dto.CreateTransactionDTO) (*entity.Transaction, error)
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Transaction, *pagination.Pagination, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Transaction, error)
+	// Export calls fn with every transaction of the user, oldest first, streaming them from the
+	// repository. Nothing is passed to fn when the export fails to start.
+	Export(ctx context.Context, userID uuid.UUID, fn func(exported dto.ExportedTransactionDTO) error) error
+	Create(ctx context.Context, createTransactionDTO *dto.CreateTransactionDTO) (*entity.Transaction, error)
+	Update(ctx context.Context, updateTransactionDTO *dto.UpdateTransactionDTO) (*entity.Transaction, error)
+	Patch(ctx context.Context, patchTransactionDTO *dto.PatchTransactionDTO) (*entity.Transaction, error)
+	// Delete moves the transaction to the trash, provided it is still at version, or at any
+	// version when version is 0
+	Delete(ctx context.Context, userID, id uuid.UUID, version int64) error
+	// Restore takes the transaction out of the trash, with the same version check as Delete
+	Restore(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.Transaction, error)
+	// Batch applies a list of creations, updates and deletions, all or nothing when the batch is
+	// atomic, returning one result per operation
+	Batch(ctx context.Context, batchDTO *dto.BatchTransactionDTO) ([]dto.BatchResultDTO, error)
+	// History lists the changes made to the transaction, oldest first, even while it is in the trash
+	History(ctx context.Context, userID, id uuid.UUID, page, pageSize int) ([]entity.AuditEntry, *pagination.Pagination, error)
 }
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type TrashServiceInterface interface {
+	// FindAllPaginated lists the trashed transactions and categories of the user, most recently
+	// trashed first
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.TrashItem, *pagination.Pagination, error)
+	// PurgeAt tells when a trashed item is permanently removed
+	PurgeAt(item *entity.TrashItem) time.Time
+	// Purge permanently removes everything that stayed in the trash longer than the retention period
+	Purge(ctx context.Context) (int64, error)
+}
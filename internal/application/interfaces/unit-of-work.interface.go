@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// UnitOfWorkInterface groups repository calls into a single database transaction
+type UnitOfWorkInterface interface {
+	// Do runs fn inside a transaction, committing when fn returns nil and rolling back otherwise.
+	// Repositories called with the context handed to fn take part in the transaction, and calling
+	// Do again with that context opens a savepoint nested in the current transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/requestid"
+	"github.com/google/uuid"
+)
+
+type AuditTrailService struct {
+	auditRepository repository.AuditRepositoryInterface
+	clock           clock.Clock
+	ids             identifier.Generator
+}
+
+func NewAuditTrailService(
+	auditRepository repository.AuditRepositoryInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.AuditTrailInterface {
+	return &AuditTrailService{
+		auditRepository: auditRepository,
+		clock:           clock,
+		ids:             ids,
+	}
+}
+
+func (s *AuditTrailService) Record(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, action enum.AuditAction, before, after entity.AuditFields) error {
+	entry := entity.NewAuditEntry(s.clock, s.ids, userID, resource, resourceID, action, requestid.FromContext(ctx), before, after)
+	return s.auditRepository.Append(ctx, entry)
+}
+
+func (s *AuditTrailService) FindAllPaginated(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, page, pageSize int) ([]entity.AuditEntry, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "AuditTrailService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	entries, err := s.auditRepository.FindAllPaginated(ctx, userID, resource, resourceID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return entries, paginate, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// checkCategory makes sure the category transactions are recorded in belongs to the user,
+// reporting a missing one as a validation error rather than a missing resource
+func checkCategory(ctx context.Context, categories repository.CategoryRepositoryInterface, userID, categoryID uuid.UUID) error {
+	category, err := categories.FindByID(ctx, categoryID)
+	if domainerror.IsKind(err, domainerror.KindNotFound) || (err == nil && category.UserID() != userID) {
+		return domainerror.NewValidation("categoryId", "category_not_found", "category does not exist")
+	}
+	return err
+}
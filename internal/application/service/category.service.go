@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type CategoryService struct {
+	unitOfWork            interfaces.UnitOfWorkInterface
+	categoryRepository    repository.CategoryRepositoryInterface
+	transactionRepository repository.TransactionRepositoryInterface
+	auditTrail            interfaces.AuditTrailInterface
+	clock                 clock.Clock
+	ids                   identifier.Generator
+}
+
+func NewCategoryService(
+	unitOfWork interfaces.UnitOfWorkInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	transactionRepository repository.TransactionRepositoryInterface,
+	auditTrail interfaces.AuditTrailInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.CategoryServiceInterface {
+	return &CategoryService{
+		unitOfWork:            unitOfWork,
+		categoryRepository:    categoryRepository,
+		transactionRepository: transactionRepository,
+		auditTrail:            auditTrail,
+		clock:                 clock,
+		ids:                   ids,
+	}
+}
+
+func (s *CategoryService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Category, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	categories, err := s.categoryRepository.FindAllPaginated(ctx, userID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return categories, paginate, nil
+}
+
+func (s *CategoryService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.FindByID")
+	defer span.End()
+
+	category, err := s.findCategory(ctx, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return category, nil
+}
+
+func (s *CategoryService) Create(ctx context.Context, createCategoryDTO *dto.CreateCategoryDTO) (*entity.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.Create")
+	defer span.End()
+
+	category, err := entity.NewCategory(
+		s.clock,
+		s.ids,
+		createCategoryDTO.UserID,
+		createCategoryDTO.Name,
+		createCategoryDTO.Type,
+		false,
+		createCategoryDTO.Icon,
+	)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	var createdCategory *entity.Category
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		createdCategory, err = s.categoryRepository.Create(ctx, category)
+		if err != nil {
+			return err
+		}
+
+		return s.auditTrail.Record(ctx, createdCategory.UserID(), enum.AuditResourceCategory, createdCategory.ID(), enum.AuditActionCreate, nil, createdCategory.AuditFields())
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "category created",
+		"category_id", createdCategory.ID(),
+		"user_id", createdCategory.UserID(),
+	)
+
+	return createdCategory, nil
+}
+
+func (s *CategoryService) Update(ctx context.Context, updateCategoryDTO *dto.UpdateCategoryDTO) (*entity.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.Update")
+	defer span.End()
+
+	var updatedCategory *entity.Category
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) (err error) {
+		updatedCategory, err = s.update(ctx, updateCategoryDTO)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "category updated",
+		"category_id", updatedCategory.ID(),
+		"user_id", updatedCategory.UserID(),
+		"version", updatedCategory.Version(),
+	)
+
+	return updatedCategory, nil
+}
+
+// Patch merges the fields of patchCategoryDTO into the category as stored. The category is read
+// in the unit of work that writes it, so the merge is never based on a stale replica.
+func (s *CategoryService) Patch(ctx context.Context, patchCategoryDTO *dto.PatchCategoryDTO) (*entity.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.Patch")
+	defer span.End()
+
+	var updatedCategory *entity.Category
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := s.findCategory(ctx, patchCategoryDTO.UserID, patchCategoryDTO.ID)
+		if err != nil {
+			return err
+		}
+
+		updatedCategory, err = s.update(ctx, mergeCategory(current, patchCategoryDTO))
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "category updated",
+		"category_id", updatedCategory.ID(),
+		"user_id", updatedCategory.UserID(),
+		"version", updatedCategory.Version(),
+	)
+
+	return updatedCategory, nil
+}
+
+// update changes a category of the user, in the unit of work bound to ctx
+func (s *CategoryService) update(ctx context.Context, updateCategoryDTO *dto.UpdateCategoryDTO) (*entity.Category, error) {
+	category, err := s.findCategory(ctx, updateCategoryDTO.UserID, updateCategoryDTO.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion("category", category.ID(), updateCategoryDTO.Version, category.Version()); err != nil {
+		return nil, err
+	}
+
+	before := category.AuditFields()
+	if err := category.Update(s.clock, updateCategoryDTO.Name, updateCategoryDTO.Type, updateCategoryDTO.Icon); err != nil {
+		return nil, err
+	}
+
+	updatedCategory, err := s.categoryRepository.Update(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auditTrail.Record(ctx, updateCategoryDTO.UserID, enum.AuditResourceCategory, category.ID(), enum.AuditActionUpdate, before, updatedCategory.AuditFields())
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedCategory, nil
+}
+
+// mergeCategory fills the fields missing from patch with those of current. Without a version the
+// change is based on that of current, the one the merge was built from.
+func mergeCategory(current *entity.Category, patch *dto.PatchCategoryDTO) *dto.UpdateCategoryDTO {
+	updateCategoryDTO := &dto.UpdateCategoryDTO{
+		ID:      current.ID(),
+		UserID:  current.UserID(),
+		Version: patch.Version,
+		Name:    current.Name(),
+		Type:    current.Type(),
+		Icon:    current.Icon(),
+	}
+	if updateCategoryDTO.Version == 0 {
+		updateCategoryDTO.Version = current.Version()
+	}
+	if patch.Name != nil {
+		updateCategoryDTO.Name = *patch.Name
+	}
+	if patch.Type != nil {
+		updateCategoryDTO.Type = *patch.Type
+	}
+	if patch.Icon != nil {
+		updateCategoryDTO.Icon = *patch.Icon
+	}
+	return updateCategoryDTO
+}
+
+// Delete moves the category to the trash along with its transactions, which come back when the
+// category is restored
+func (s *CategoryService) Delete(ctx context.Context, userID, id uuid.UUID, version int64) error {
+	ctx, span := tracer.Start(ctx, "CategoryService.Delete")
+	defer span.End()
+
+	var trashedTransactions []uuid.UUID
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		category, err := s.findCategory(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion("category", category.ID(), version, category.Version()); err != nil {
+			return err
+		}
+
+		before := category.AuditFields()
+		category.Trash(s.clock)
+		trashedCategory, err := s.categoryRepository.Update(ctx, category)
+		if err != nil {
+			return err
+		}
+
+		err = s.auditTrail.Record(ctx, userID, enum.AuditResourceCategory, id, enum.AuditActionDelete, before, trashedCategory.AuditFields())
+		if err != nil {
+			return err
+		}
+
+		trashedTransactions, err = s.transactionRepository.TrashByCategory(ctx, category.ID(), category.DeletedAt())
+		if err != nil {
+			return err
+		}
+
+		return s.recordCascade(ctx, userID, trashedTransactions, enum.AuditActionDelete, time.Time{}, category.DeletedAt())
+	})
+	if err != nil {
+		return recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "category trashed",
+		"category_id", id,
+		"user_id", userID,
+		"transactions", len(trashedTransactions),
+	)
+
+	return nil
+}
+
+// Restore takes the category out of the trash, along with the transactions trashed with it.
+// Transactions trashed on their own before stay in the trash.
+func (s *CategoryService) Restore(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.Restore")
+	defer span.End()
+
+	var restoredCategory *entity.Category
+	var restoredTransactions []uuid.UUID
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		category, err := s.categoryRepository.FindTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if category.UserID() != userID {
+			return domainerror.NewNotFound("category", id)
+		}
+
+		if err := checkVersion("category", category.ID(), version, category.Version()); err != nil {
+			return err
+		}
+
+		before := category.AuditFields()
+		deletedAt := category.DeletedAt()
+		category.Untrash(s.clock)
+		restoredCategory, err = s.categoryRepository.Update(ctx, category)
+		if err != nil {
+			return err
+		}
+
+		err = s.auditTrail.Record(ctx, userID, enum.AuditResourceCategory, id, enum.AuditActionRestore, before, restoredCategory.AuditFields())
+		if err != nil {
+			return err
+		}
+
+		restoredTransactions, err = s.transactionRepository.UntrashByCategory(ctx, category.ID(), deletedAt, category.UpdatedAt())
+		if err != nil {
+			return err
+		}
+
+		return s.recordCascade(ctx, userID, restoredTransactions, enum.AuditActionRestore, deletedAt, time.Time{})
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "category restored",
+		"category_id", id,
+		"user_id", userID,
+		"transactions", len(restoredTransactions),
+	)
+
+	return restoredCategory, nil
+}
+
+// recordCascade audits the transactions moved in or out of the trash along with their category
+func (s *CategoryService) recordCascade(ctx context.Context, userID uuid.UUID, transactionIDs []uuid.UUID, action enum.AuditAction, deletedBefore, deletedAfter time.Time) error {
+	for _, transactionID := range transactionIDs {
+		err := s.auditTrail.Record(ctx, userID, enum.AuditResourceTransaction, transactionID, action, entity.TrashAuditFields(deletedBefore), entity.TrashAuditFields(deletedAfter))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findCategory loads a category of the user, reporting another user's category as missing
+// so ids cannot be probed
+func (s *CategoryService) findCategory(ctx context.Context, userID, id uuid.UUID) (*entity.Category, error) {
+	category, err := s.categoryRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.UserID() != userID {
+		return nil, domainerror.NewNotFound("category", id)
+	}
+	return category, nil
+}
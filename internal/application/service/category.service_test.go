@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type categoryServiceFixture struct {
+	service      *CategoryService
+	store        *memory.Store
+	transactions repository.TransactionRepositoryInterface
+	auditTrail   interfaces.AuditTrailInterface
+	clock        *clock.Fixed
+	userID       uuid.UUID
+}
+
+func newCategoryServiceFixture() *categoryServiceFixture {
+	store := memory.NewStore()
+	fixture := &categoryServiceFixture{
+		store:        store,
+		transactions: memory.NewTransactionRepository(store),
+		clock:        clock.NewFixed(time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)),
+		userID:       uuid.New(),
+	}
+	fixture.auditTrail = NewAuditTrailService(memory.NewAuditRepository(store), fixture.clock, identifier.NewV7())
+	fixture.service = NewCategoryService(
+		memory.NewUnitOfWork(store),
+		memory.NewCategoryRepository(store),
+		fixture.transactions,
+		fixture.auditTrail,
+		fixture.clock,
+		identifier.NewV7(),
+	).(*CategoryService)
+	return fixture
+}
+
+func (f *categoryServiceFixture) create(t *testing.T, name string) *entity.Category {
+	category, err := f.service.Create(context.Background(), &dto.CreateCategoryDTO{
+		UserID: f.userID,
+		Name:   name,
+		Type:   enum.CategoryTypeExpense,
+	})
+	assert.Nil(t, err)
+	return category
+}
+
+func (f *categoryServiceFixture) seedTransaction(t *testing.T, category *entity.Category) *entity.Transaction {
+	transaction, err := entity.NewTransaction(f.clock, identifier.NewV7(), category.ID(), f.userID, 10, f.clock.Now(), "Lunch")
+	assert.Nil(t, err)
+	_, err = f.transactions.Create(context.Background(), transaction)
+	assert.Nil(t, err)
+	return transaction
+}
+
+func TestCategoryService(t *testing.T) {
+	t.Run("should list only the categories of the user, by name", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		fixture.create(t, "Transport")
+		fixture.create(t, "Food")
+		_, err := fixture.service.Create(context.Background(), &dto.CreateCategoryDTO{UserID: uuid.New(), Name: "Other", Type: enum.CategoryTypeIncome})
+		assert.Nil(t, err)
+
+		categories, paginate, err := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), paginate.TotalItems)
+		assert.Equal(t, "Food", categories[0].Name())
+		assert.Equal(t, "Transport", categories[1].Name())
+	})
+
+	t.Run("should update a category at the expected version", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+
+		updated, err := fixture.service.Update(context.Background(), &dto.UpdateCategoryDTO{
+			ID: category.ID(), UserID: fixture.userID, Version: 1, Name: "Groceries", Type: enum.CategoryTypeExpense,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Groceries", updated.Name())
+		assert.Equal(t, int64(2), updated.Version())
+
+		_, err = fixture.service.Update(context.Background(), &dto.UpdateCategoryDTO{
+			ID: category.ID(), UserID: fixture.userID, Version: 1, Name: "Market", Type: enum.CategoryTypeExpense,
+		})
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionFailed))
+	})
+
+	t.Run("should patch only the fields given", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+		name := "Groceries"
+
+		patched, err := fixture.service.Patch(context.Background(), &dto.PatchCategoryDTO{
+			ID: category.ID(), UserID: fixture.userID, Name: &name,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Groceries", patched.Name())
+		assert.Equal(t, category.Type(), patched.Type())
+		assert.Equal(t, int64(2), patched.Version())
+	})
+
+	t.Run("should trash the category with its transactions and restore them together", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+		transaction := fixture.seedTransaction(t, category)
+
+		err := fixture.service.Delete(context.Background(), fixture.userID, category.ID(), category.Version())
+		assert.Nil(t, err)
+
+		_, err = fixture.transactions.FindByID(context.Background(), transaction.ID())
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+		fixture.clock.Advance(time.Hour)
+		restored, err := fixture.service.Restore(context.Background(), fixture.userID, category.ID(), 0)
+
+		assert.Nil(t, err)
+		assert.False(t, restored.Trashed())
+		found, err := fixture.transactions.FindByID(context.Background(), transaction.ID())
+		assert.Nil(t, err)
+		assert.False(t, found.Trashed())
+
+		entries, _, err := fixture.auditTrail.FindAllPaginated(context.Background(), fixture.userID, enum.AuditResourceTransaction, transaction.ID(), 1, 10)
+		assert.Nil(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, enum.AuditActionDelete, entries[0].Action())
+			assert.Equal(t, enum.AuditActionRestore, entries[1].Action())
+			assert.Equal(t, "deletedAt", entries[1].Changes()[0].Field)
+		}
+	})
+
+	t.Run("should leave transactions trashed on their own in the trash", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+		transaction := fixture.seedTransaction(t, category)
+		transaction.Trash(fixture.clock)
+		_, err := fixture.transactions.Update(context.Background(), transaction)
+		assert.Nil(t, err)
+
+		fixture.clock.Advance(time.Hour)
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, category.ID(), 0))
+		_, err = fixture.service.Restore(context.Background(), fixture.userID, category.ID(), 0)
+		assert.Nil(t, err)
+
+		_, err = fixture.transactions.FindTrashedByID(context.Background(), transaction.ID())
+		assert.Nil(t, err)
+	})
+
+	t.Run("should only restore a trashed category of the user", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+
+		_, err := fixture.service.Restore(context.Background(), fixture.userID, category.ID(), 0)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, category.ID(), 0))
+		_, err = fixture.service.Restore(context.Background(), uuid.New(), category.ID(), 0)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+
+	t.Run("should delete an unused category of the user only", func(t *testing.T) {
+		fixture := newCategoryServiceFixture()
+		category := fixture.create(t, "Food")
+
+		err := fixture.service.Delete(context.Background(), uuid.New(), category.ID(), 0)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+		err = fixture.service.Delete(context.Background(), fixture.userID, category.ID(), 0)
+		assert.Nil(t, err)
+	})
+}
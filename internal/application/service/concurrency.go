@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// checkVersion fails when the caller based its change on a version other than the current one.
+// An expected version of 0 means the caller did not ask for the check.
+func checkVersion(resource string, id uuid.UUID, expected, current int64) error {
+	if expected != 0 && expected != current {
+		return repository.VersionMismatch(resource, id)
+	}
+	return nil
+}
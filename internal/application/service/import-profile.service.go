@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type ImportProfileService struct {
+	importProfileRepository repository.ImportProfileRepositoryInterface
+	categoryRepository      repository.CategoryRepositoryInterface
+	clock                   clock.Clock
+	ids                     identifier.Generator
+}
+
+func NewImportProfileService(
+	importProfileRepository repository.ImportProfileRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.ImportProfileServiceInterface {
+	return &ImportProfileService{
+		importProfileRepository: importProfileRepository,
+		categoryRepository:      categoryRepository,
+		clock:                   clock,
+		ids:                     ids,
+	}
+}
+
+func (s *ImportProfileService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.ImportProfile, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "ImportProfileService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	profiles, err := s.importProfileRepository.FindAllPaginated(ctx, userID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return profiles, paginate, nil
+}
+
+func (s *ImportProfileService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.ImportProfile, error) {
+	ctx, span := tracer.Start(ctx, "ImportProfileService.FindByID")
+	defer span.End()
+
+	profile, err := findImportProfile(ctx, s.importProfileRepository, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return profile, nil
+}
+
+func (s *ImportProfileService) Create(ctx context.Context, createImportProfileDTO *dto.CreateImportProfileDTO) (*entity.ImportProfile, error) {
+	ctx, span := tracer.Start(ctx, "ImportProfileService.Create")
+	defer span.End()
+
+	profile, err := entity.NewImportProfile(s.clock, s.ids, createImportProfileDTO.UserID, createImportProfileDTO.Name, createImportProfileDTO.Settings)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if err := checkImportCategories(ctx, s.categoryRepository, profile.UserID(), profile.Settings().ExpenseCategoryID, profile.Settings().IncomeCategoryID); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	createdProfile, err := s.importProfileRepository.Create(ctx, profile)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "import profile created",
+		"import_profile_id", createdProfile.ID(),
+		"user_id", createdProfile.UserID(),
+	)
+
+	return createdProfile, nil
+}
+
+func (s *ImportProfileService) Update(ctx context.Context, updateImportProfileDTO *dto.UpdateImportProfileDTO) (*entity.ImportProfile, error) {
+	ctx, span := tracer.Start(ctx, "ImportProfileService.Update")
+	defer span.End()
+
+	profile, err := findImportProfile(ctx, s.importProfileRepository, updateImportProfileDTO.UserID, updateImportProfileDTO.ID)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if err := profile.Update(s.clock, updateImportProfileDTO.Name, updateImportProfileDTO.Settings); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if err := checkImportCategories(ctx, s.categoryRepository, profile.UserID(), profile.Settings().ExpenseCategoryID, profile.Settings().IncomeCategoryID); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	updatedProfile, err := s.importProfileRepository.Update(ctx, profile)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "import profile updated",
+		"import_profile_id", updatedProfile.ID(),
+		"user_id", updatedProfile.UserID(),
+	)
+
+	return updatedProfile, nil
+}
+
+func (s *ImportProfileService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ImportProfileService.Delete")
+	defer span.End()
+
+	if _, err := findImportProfile(ctx, s.importProfileRepository, userID, id); err != nil {
+		return recordError(span, err)
+	}
+
+	if err := s.importProfileRepository.Delete(ctx, id); err != nil {
+		return recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "import profile deleted",
+		"import_profile_id", id,
+		"user_id", userID,
+	)
+
+	return nil
+}
+
+// findImportProfile loads an import profile of the user, reporting another user's profile as
+// missing so ids cannot be probed
+func findImportProfile(ctx context.Context, importProfileRepository repository.ImportProfileRepositoryInterface, userID, id uuid.UUID) (*entity.ImportProfile, error) {
+	profile, err := importProfileRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID() != userID {
+		return nil, domainerror.NewNotFound("import_profile", id)
+	}
+	return profile, nil
+}
+
+// checkImportCategories makes sure the categories given to the expenses and the income of a
+// statement, when given, belong to the user and have the type of the rows they receive
+func checkImportCategories(ctx context.Context, categoryRepository repository.CategoryRepositoryInterface, userID, expenseCategoryID, incomeCategoryID uuid.UUID) error {
+	defaults := []struct {
+		field        string
+		id           uuid.UUID
+		categoryType enum.CategoryType
+	}{
+		{"expenseCategoryId", expenseCategoryID, enum.CategoryTypeExpense},
+		{"incomeCategoryId", incomeCategoryID, enum.CategoryTypeIncome},
+	}
+
+	for _, category := range defaults {
+		if category.id == uuid.Nil {
+			continue
+		}
+
+		found, err := categoryRepository.FindByID(ctx, category.id)
+		if domainerror.IsKind(err, domainerror.KindNotFound) || (err == nil && found.UserID() != userID) {
+			return domainerror.NewValidation(category.field, "category_not_found", "category does not exist")
+		}
+		if err != nil {
+			return err
+		}
+		if found.Type() != category.categoryType {
+			return domainerror.NewValidation(category.field, "category_type_mismatch", "category must be of type "+string(category.categoryType)).
+				WithParams(map[string]string{"type": string(category.categoryType)})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/statement"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type ImportService struct {
+	importProfileRepository repository.ImportProfileRepositoryInterface
+	categoryRepository      repository.CategoryRepositoryInterface
+	transactionRepository   repository.TransactionRepositoryInterface
+	transactionService      interfaces.TransactionServiceInterface
+	maxRows                 int
+}
+
+// NewImportService creates the statement import service. Statements with more than maxRows
+// rows are refused; imported rows are created through transactionService.
+func NewImportService(
+	importProfileRepository repository.ImportProfileRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	transactionRepository repository.TransactionRepositoryInterface,
+	transactionService interfaces.TransactionServiceInterface,
+	maxRows int,
+) interfaces.ImportServiceInterface {
+	return &ImportService{
+		importProfileRepository: importProfileRepository,
+		categoryRepository:      categoryRepository,
+		transactionRepository:   transactionRepository,
+		transactionService:      transactionService,
+		maxRows:                 maxRows,
+	}
+}
+
+func (s *ImportService) ImportCSV(ctx context.Context, importCSVDTO *dto.ImportCSVDTO) (*dto.ImportResultDTO, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.ImportCSV")
+	defer span.End()
+
+	settings, err := s.settings(ctx, importCSVDTO)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	lines, err := statement.ParseCSV(importCSVDTO.Content, *settings, s.maxRows)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	result, err := s.process(ctx, "csv", importCSVDTO.UserID, lines, fallbackCategories{
+		expense: settings.ExpenseCategoryID,
+		income:  settings.IncomeCategoryID,
+	}, importCSVDTO.Commit)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return result, nil
+}
+
+func (s *ImportService) ImportOFX(ctx context.Context, importOFXDTO *dto.ImportOFXDTO) (*dto.ImportResultDTO, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.ImportOFX")
+	defer span.End()
+
+	if err := checkImportCategories(ctx, s.categoryRepository, importOFXDTO.UserID, importOFXDTO.ExpenseCategoryID, importOFXDTO.IncomeCategoryID); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	lines, err := statement.ParseOFX(importOFXDTO.Content, s.maxRows)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	result, err := s.process(ctx, "ofx", importOFXDTO.UserID, lines, fallbackCategories{
+		expense:         importOFXDTO.ExpenseCategoryID,
+		income:          importOFXDTO.IncomeCategoryID,
+		userDefaultsToo: true,
+	}, importOFXDTO.Commit)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return result, nil
+}
+
+// process turns the lines of a statement into rows, flags those already recorded and, when
+// committing, imports the rest
+func (s *ImportService) process(ctx context.Context, format string, userID uuid.UUID, lines []statement.Line, fallback fallbackCategories, commit bool) (*dto.ImportResultDTO, error) {
+	rows, err := s.prepare(ctx, userID, fallback, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markDuplicates(ctx, userID, rows); err != nil {
+		return nil, err
+	}
+
+	result := &dto.ImportResultDTO{Rows: rows}
+	if commit {
+		err := s.commit(ctx, userID, rows)
+		if isDuplicateTransaction(err) {
+			// an import of the same statement committed in the meantime: its rows are duplicates now
+			if err := s.markDuplicates(ctx, userID, rows); err != nil {
+				return nil, err
+			}
+			err = s.commit(ctx, userID, rows)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Committed = true
+	}
+
+	counts := make(map[enum.ImportRowStatus]int)
+	for _, row := range rows {
+		counts[row.Status]++
+	}
+	logger.FromContext(ctx).InfoContext(ctx, "statement import processed",
+		"user_id", userID,
+		"format", format,
+		"committed", result.Committed,
+		"rows", len(rows),
+		"imported", counts[enum.ImportRowStatusImported],
+		"duplicates", counts[enum.ImportRowStatusDuplicate],
+		"invalid", counts[enum.ImportRowStatusInvalid],
+	)
+
+	return result, nil
+}
+
+// settings returns the settings of the profile named by the import, or the settings it carries
+func (s *ImportService) settings(ctx context.Context, importCSVDTO *dto.ImportCSVDTO) (*entity.ImportSettings, error) {
+	if importCSVDTO.ProfileID != uuid.Nil {
+		profile, err := findImportProfile(ctx, s.importProfileRepository, importCSVDTO.UserID, importCSVDTO.ProfileID)
+		if err != nil {
+			return nil, err
+		}
+		settings := profile.Settings()
+		return &settings, nil
+	}
+
+	if importCSVDTO.Settings == nil {
+		return nil, domainerror.NewInvalidInput("settings", "import_settings_required", "either profileId or settings is required")
+	}
+	if err := importCSVDTO.Settings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkImportCategories(ctx, s.categoryRepository, importCSVDTO.UserID, importCSVDTO.Settings.ExpenseCategoryID, importCSVDTO.Settings.IncomeCategoryID); err != nil {
+		return nil, err
+	}
+	return importCSVDTO.Settings, nil
+}
+
+// fallbackCategories are the categories of the rows that name none, by the sign of their
+// amount. With userDefaultsToo, the default category of the user of each type stands in for
+// the one not given.
+type fallbackCategories struct {
+	expense, income uuid.UUID
+	userDefaultsToo bool
+}
+
+// prepare turns the statement lines into rows, resolving the category of each: the category
+// named in the row, matched by name, or the fallback category for the sign of its amount
+func (s *ImportService) prepare(ctx context.Context, userID uuid.UUID, fallback fallbackCategories, lines []statement.Line) ([]dto.ImportRowDTO, error) {
+	categories, err := s.categoryRepository.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]uuid.UUID, len(categories))
+	for _, category := range categories {
+		name := strings.ToLower(category.Name())
+		if _, taken := byName[name]; !taken {
+			byName[name] = category.ID()
+		}
+
+		if !fallback.userDefaultsToo || !category.Default() {
+			continue
+		}
+		if category.Type() == enum.CategoryTypeExpense && fallback.expense == uuid.Nil {
+			fallback.expense = category.ID()
+		}
+		if category.Type() == enum.CategoryTypeIncome && fallback.income == uuid.Nil {
+			fallback.income = category.ID()
+		}
+	}
+
+	rows := make([]dto.ImportRowDTO, len(lines))
+	for i, line := range lines {
+		rows[i] = dto.ImportRowDTO{Line: line.Number, Raw: line.Raw, Status: enum.ImportRowStatusInvalid, Err: line.Err}
+		if line.Err != nil {
+			continue
+		}
+
+		invalid := domainerror.NewValidation("", "row_invalid", "row cannot be imported")
+		if line.Amount == 0 {
+			invalid.WithDetails(domainerror.NewValidation("amount", "amount_must_be_positive", "amount must be greater than 0"))
+		}
+
+		categoryID := fallback.income
+		if line.Amount < 0 {
+			categoryID = fallback.expense
+		}
+		if line.Category != "" {
+			var found bool
+			if categoryID, found = byName[strings.ToLower(line.Category)]; !found {
+				invalid.WithDetails(domainerror.NewValidation("category", "import_category_unknown", "no category is named "+line.Category).
+					WithParams(map[string]string{"name": line.Category}))
+			}
+		} else if categoryID == uuid.Nil {
+			invalid.WithDetails(domainerror.NewValidation("category", "import_category_required", "row has no category and no fallback category is set for its sign"))
+		}
+
+		if len(invalid.Details) > 0 {
+			rows[i].Err = invalid
+			continue
+		}
+
+		rows[i].Status = enum.ImportRowStatusReady
+		rows[i].Err = nil
+		rows[i].Transaction = &dto.CreateTransactionDTO{
+			UserID:      userID,
+			CategoryID:  categoryID,
+			Amount:      math.Abs(line.Amount),
+			Datetime:    line.Date,
+			Description: line.Description,
+			ExternalID:  line.ExternalID,
+		}
+	}
+
+	return rows, nil
+}
+
+// markDuplicates flags the ready rows already recorded as transactions. A row with an external
+// id is a duplicate when a transaction, or an earlier row, carries the same id. Otherwise rows
+// match transactions of the same day, amount and description, ignoring case and spacing; rows
+// with an external id only match transactions without one, as both were entered separately.
+// Each recorded transaction matches a single row, so a statement listing the same purchase
+// twice keeps the second one unless it was recorded too.
+func (s *ImportService) markDuplicates(ctx context.Context, userID uuid.UUID, rows []dto.ImportRowDTO) error {
+	var from, to time.Time
+	var externalIDs []string
+	for _, row := range rows {
+		if row.Status != enum.ImportRowStatusReady {
+			continue
+		}
+		day := startOfDay(row.Transaction.Datetime)
+		if from.IsZero() || day.Before(from) {
+			from = day
+		}
+		if to.IsZero() || !day.Before(to) {
+			to = day.AddDate(0, 0, 1)
+		}
+		if row.Transaction.ExternalID != "" {
+			externalIDs = append(externalIDs, row.Transaction.ExternalID)
+		}
+	}
+	if from.IsZero() {
+		return nil
+	}
+
+	known := make(map[string]bool, len(externalIDs))
+	if len(externalIDs) > 0 {
+		found, err := s.transactionRepository.FindExternalIDs(ctx, userID, externalIDs)
+		if err != nil {
+			return err
+		}
+		for _, externalID := range found {
+			known[externalID] = true
+		}
+	}
+
+	recorded, err := s.transactionRepository.FindAllBetween(ctx, userID, from, to)
+	if err != nil {
+		return err
+	}
+
+	// unmatched holds, per key, whether each recorded transaction not matched yet has an external id
+	unmatched := make(map[duplicateKey][]bool, len(recorded))
+	for _, transaction := range recorded {
+		key := newDuplicateKey(transaction.Datetime(), transaction.Amount(), transaction.Description())
+		unmatched[key] = append(unmatched[key], transaction.ExternalID() != "")
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.Status != enum.ImportRowStatusReady {
+			continue
+		}
+
+		externalID := row.Transaction.ExternalID
+		if externalID != "" {
+			if known[externalID] {
+				row.Status = enum.ImportRowStatusDuplicate
+				continue
+			}
+			known[externalID] = true
+		}
+
+		key := newDuplicateKey(row.Transaction.Datetime, row.Transaction.Amount, row.Transaction.Description)
+		for j, imported := range unmatched[key] {
+			if externalID != "" && imported {
+				continue
+			}
+			unmatched[key] = append(unmatched[key][:j], unmatched[key][j+1:]...)
+			row.Status = enum.ImportRowStatusDuplicate
+			break
+		}
+	}
+
+	return nil
+}
+
+// isDuplicateTransaction reports whether err is the conflict of a transaction whose external id
+// the user already recorded
+func isDuplicateTransaction(err error) bool {
+	domainErr, ok := domainerror.As(err)
+	return ok && domainErr.Kind == domainerror.KindConflict && domainErr.Code == "transaction_already_exists"
+}
+
+// commit creates the ready rows as transactions in a single atomic batch
+func (s *ImportService) commit(ctx context.Context, userID uuid.UUID, rows []dto.ImportRowDTO) error {
+	var ready []int
+	var operations []dto.BatchOperationDTO
+	for i, row := range rows {
+		if row.Status == enum.ImportRowStatusReady {
+			ready = append(ready, i)
+			operations = append(operations, dto.BatchOperationDTO{Create: row.Transaction})
+		}
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	results, err := s.transactionService.Batch(ctx, &dto.BatchTransactionDTO{
+		UserID:     userID,
+		Atomic:     true,
+		Operations: operations,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, result := range results {
+		row := &rows[ready[i]]
+		row.Status = enum.ImportRowStatusImported
+		row.Imported = result.Transaction
+	}
+	return nil
+}
+
+// duplicateKey identifies what a statement row and a recorded transaction must share to be
+// taken for the same
+type duplicateKey struct {
+	day         string
+	cents       int64
+	description string
+}
+
+func newDuplicateKey(datetime time.Time, amount float64, description string) duplicateKey {
+	return duplicateKey{
+		day:         datetime.UTC().Format(time.DateOnly),
+		cents:       int64(math.Round(math.Abs(amount) * 100)),
+		description: strings.ToLower(strings.Join(strings.Fields(description), " ")),
+	}
+}
+
+func startOfDay(datetime time.Time) time.Time {
+	year, month, day := datetime.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
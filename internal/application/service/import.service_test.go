@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const statementCSV = "Data;Descrição;Valor\n" +
+	"05/03/2025;Mercado;-120,50\n" +
+	"06/03/2025;Salário;5.000,00\n" +
+	"07/03/2025;Estorno;0,00\n" +
+	"08/03/2025;Padaria;abc\n"
+
+type importServiceFixture struct {
+	*transactionServiceFixture
+	service  *ImportService
+	profiles *ImportProfileService
+	settings entity.ImportSettings
+}
+
+func newImportServiceFixture(t *testing.T) *importServiceFixture {
+	store := memory.NewStore()
+	transactions := newTransactionServiceFixture(t, uuid.New(), uuid.New(), uuid.New(), uuid.New())
+	importProfiles := memory.NewImportProfileRepository(store)
+
+	fixture := &importServiceFixture{transactionServiceFixture: transactions}
+	fixture.service = NewImportService(
+		importProfiles,
+		transactions.categories,
+		transactions.service.transactionRepository,
+		transactions.service,
+		100,
+	).(*ImportService)
+	fixture.profiles = NewImportProfileService(importProfiles, transactions.categories, transactions.clock, identifier.NewV7()).(*ImportProfileService)
+	fixture.settings = entity.ImportSettings{
+		Delimiter:         ";",
+		DecimalComma:      true,
+		DateFormat:        "DD/MM/YYYY",
+		HasHeader:         true,
+		Columns:           entity.ImportColumns{Date: "Data", Amount: "Valor", Description: "Descrição"},
+		ExpenseCategoryID: transactions.seedCategory(t, enum.CategoryTypeExpense).ID(),
+		IncomeCategoryID:  transactions.seedCategory(t, enum.CategoryTypeIncome).ID(),
+	}
+	return fixture
+}
+
+func (f *importServiceFixture) importCSV(t *testing.T, commit bool) *dto.ImportResultDTO {
+	result, err := f.service.ImportCSV(context.Background(), &dto.ImportCSVDTO{
+		UserID:   f.userID,
+		Settings: &f.settings,
+		Content:  []byte(statementCSV),
+		Commit:   commit,
+	})
+	assert.Nil(t, err)
+	return result
+}
+
+func statuses(result *dto.ImportResultDTO) []enum.ImportRowStatus {
+	var statuses []enum.ImportRowStatus
+	for _, row := range result.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	return statuses
+}
+
+func TestImportService_ImportCSV(t *testing.T) {
+	t.Run("should preview the rows without creating transactions", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+
+		result := fixture.importCSV(t, false)
+
+		assert.False(t, result.Committed)
+		assert.Equal(t, []enum.ImportRowStatus{
+			enum.ImportRowStatusReady,
+			enum.ImportRowStatusReady,
+			enum.ImportRowStatusInvalid,
+			enum.ImportRowStatusInvalid,
+		}, statuses(result))
+		assert.Equal(t, fixture.settings.ExpenseCategoryID, result.Rows[0].Transaction.CategoryID)
+		assert.Equal(t, 120.5, result.Rows[0].Transaction.Amount)
+		assert.Equal(t, fixture.settings.IncomeCategoryID, result.Rows[1].Transaction.CategoryID)
+
+		_, paginate, err := fixture.transactionServiceFixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), paginate.TotalItems)
+	})
+
+	t.Run("should import the ready rows and report them as duplicates afterwards", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+
+		result := fixture.importCSV(t, true)
+
+		assert.True(t, result.Committed)
+		assert.Equal(t, enum.ImportRowStatusImported, result.Rows[0].Status)
+		assert.Equal(t, "Mercado", result.Rows[0].Imported.Description())
+		assert.Equal(t, enum.ImportRowStatusImported, result.Rows[1].Status)
+
+		again := fixture.importCSV(t, true)
+
+		assert.Equal(t, enum.ImportRowStatusDuplicate, again.Rows[0].Status)
+		assert.Equal(t, enum.ImportRowStatusDuplicate, again.Rows[1].Status)
+		_, paginate, err := fixture.transactionServiceFixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), paginate.TotalItems)
+	})
+
+	t.Run("should match the category named in the row", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		fixture.settings.Columns.Category = "Categoria"
+
+		result, err := fixture.service.ImportCSV(context.Background(), &dto.ImportCSVDTO{
+			UserID:   fixture.userID,
+			Settings: &fixture.settings,
+			Content:  []byte("Data;Descrição;Valor;Categoria\n05/03/2025;Mercado;-10,00;FOOD\n05/03/2025;Bar;-10,00;Leisure\n"),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, enum.ImportRowStatusReady, result.Rows[0].Status)
+		invalid, ok := domainerror.As(result.Rows[1].Err)
+		assert.True(t, ok)
+		assert.Equal(t, "import_category_unknown", invalid.Details[0].Code)
+	})
+
+	t.Run("should read the file with a profile of the user only", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		profile, err := fixture.profiles.Create(context.Background(), &dto.CreateImportProfileDTO{
+			UserID:   fixture.userID,
+			Name:     "Banco",
+			Settings: fixture.settings,
+		})
+		assert.Nil(t, err)
+
+		result, err := fixture.service.ImportCSV(context.Background(), &dto.ImportCSVDTO{
+			UserID:    fixture.userID,
+			ProfileID: profile.ID(),
+			Content:   []byte(statementCSV),
+		})
+		assert.Nil(t, err)
+		assert.Len(t, result.Rows, 4)
+
+		_, err = fixture.service.ImportCSV(context.Background(), &dto.ImportCSVDTO{
+			UserID:    uuid.New(),
+			ProfileID: profile.ID(),
+			Content:   []byte(statementCSV),
+		})
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+}
+
+const ofxStatement = `<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><ACCTID>123</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20250305<TRNAMT>-120.50<FITID>1<MEMO>Mercado</STMTTRN>
+<STMTTRN><DTPOSTED>20250305<TRNAMT>-9.90<FITID>2<MEMO>Padaria</STMTTRN>
+<STMTTRN><DTPOSTED>20250306<TRNAMT>5000.00<FITID>3<MEMO>Salário</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+// staleExternalIDs misses the external ids recorded by the first import, as a look-up made
+// before a concurrent import committed would
+type staleExternalIDs struct {
+	repository.TransactionRepositoryInterface
+	stale bool
+}
+
+func (r *staleExternalIDs) FindExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) ([]string, error) {
+	if r.stale {
+		r.stale = false
+		return nil, nil
+	}
+	return r.TransactionRepositoryInterface.FindExternalIDs(ctx, userID, externalIDs)
+}
+
+func TestImportService_ImportOFX(t *testing.T) {
+	importOFX := func(t *testing.T, fixture *importServiceFixture, expenseCategoryID uuid.UUID, commit bool) *dto.ImportResultDTO {
+		result, err := fixture.service.ImportOFX(context.Background(), &dto.ImportOFXDTO{
+			UserID:            fixture.userID,
+			ExpenseCategoryID: expenseCategoryID,
+			IncomeCategoryID:  fixture.settings.IncomeCategoryID,
+			Content:           []byte(ofxStatement),
+			Commit:            commit,
+		})
+		assert.Nil(t, err)
+		return result
+	}
+
+	t.Run("should file expenses under the default expense category when none is given", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		defaultCategory, err := entity.NewCategory(fixture.clock, identifier.NewV7(), fixture.userID, "Others", enum.CategoryTypeExpense, true, "")
+		assert.Nil(t, err)
+		assert.Nil(t, fixture.categories.Save(context.Background(), defaultCategory))
+
+		result := importOFX(t, fixture, uuid.Nil, false)
+
+		assert.Equal(t, defaultCategory.ID(), result.Rows[0].Transaction.CategoryID)
+		assert.Equal(t, "123/1", result.Rows[0].Transaction.ExternalID)
+		assert.Equal(t, fixture.settings.IncomeCategoryID, result.Rows[2].Transaction.CategoryID)
+	})
+
+	t.Run("should report the entries imported before as duplicates by their FITID", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		fixture.settings.IncomeCategoryID = uuid.Nil
+		_, err := fixture.service.ImportOFX(context.Background(), &dto.ImportOFXDTO{
+			UserID:            fixture.userID,
+			ExpenseCategoryID: fixture.settings.ExpenseCategoryID,
+			Content:           []byte(ofxStatement),
+			Commit:            true,
+		})
+		assert.Nil(t, err)
+
+		result, err := fixture.service.ImportOFX(context.Background(), &dto.ImportOFXDTO{
+			UserID:            fixture.userID,
+			ExpenseCategoryID: fixture.settings.ExpenseCategoryID,
+			Content:           []byte(ofxStatement),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []enum.ImportRowStatus{
+			enum.ImportRowStatusDuplicate,
+			enum.ImportRowStatusDuplicate,
+			enum.ImportRowStatusInvalid,
+		}, statuses(result))
+	})
+
+	t.Run("should report the entries a concurrent import recorded first as duplicates", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		fixture.settings.IncomeCategoryID = uuid.Nil
+		importOFX(t, fixture, fixture.settings.ExpenseCategoryID, true)
+		fixture.service.transactionRepository = &staleExternalIDs{TransactionRepositoryInterface: fixture.service.transactionRepository, stale: true}
+
+		result := importOFX(t, fixture, fixture.settings.ExpenseCategoryID, true)
+
+		assert.True(t, result.Committed)
+		assert.Equal(t, []enum.ImportRowStatus{
+			enum.ImportRowStatusDuplicate,
+			enum.ImportRowStatusDuplicate,
+			enum.ImportRowStatusInvalid,
+		}, statuses(result))
+		transactions, _, err := fixture.transactionServiceFixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Nil(t, err)
+		assert.Len(t, transactions, 2)
+	})
+
+	t.Run("should match entries against transactions recorded by hand", func(t *testing.T) {
+		fixture := newImportServiceFixture(t)
+		_, err := fixture.transactionServiceFixture.service.Create(context.Background(), &dto.CreateTransactionDTO{
+			UserID:      fixture.userID,
+			CategoryID:  fixture.settings.ExpenseCategoryID,
+			Amount:      9.9,
+			Datetime:    time.Date(2025, 3, 5, 18, 0, 0, 0, time.UTC),
+			Description: "padaria",
+		})
+		assert.Nil(t, err)
+
+		result := importOFX(t, fixture, fixture.settings.ExpenseCategoryID, true)
+
+		assert.Equal(t, enum.ImportRowStatusImported, result.Rows[0].Status)
+		assert.Equal(t, enum.ImportRowStatusDuplicate, result.Rows[1].Status)
+		assert.Equal(t, enum.ImportRowStatusImported, result.Rows[2].Status)
+		assert.Equal(t, "123/3", result.Rows[2].Imported.ExternalID())
+	})
+}
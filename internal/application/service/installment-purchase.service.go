@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type InstallmentPurchaseService struct {
+	unitOfWork                    interfaces.UnitOfWorkInterface
+	installmentPurchaseRepository repository.InstallmentPurchaseRepositoryInterface
+	categoryRepository            repository.CategoryRepositoryInterface
+	transactionService            interfaces.TransactionServiceInterface
+	clock                         clock.Clock
+	ids                           identifier.Generator
+}
+
+func NewInstallmentPurchaseService(
+	unitOfWork interfaces.UnitOfWorkInterface,
+	installmentPurchaseRepository repository.InstallmentPurchaseRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	transactionService interfaces.TransactionServiceInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.InstallmentPurchaseServiceInterface {
+	return &InstallmentPurchaseService{
+		unitOfWork:                    unitOfWork,
+		installmentPurchaseRepository: installmentPurchaseRepository,
+		categoryRepository:            categoryRepository,
+		transactionService:            transactionService,
+		clock:                         clock,
+		ids:                           ids,
+	}
+}
+
+func (s *InstallmentPurchaseService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.InstallmentPurchase, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "InstallmentPurchaseService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	purchases, err := s.installmentPurchaseRepository.FindAllPaginated(ctx, userID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return purchases, paginate, nil
+}
+
+func (s *InstallmentPurchaseService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.InstallmentPurchase, error) {
+	ctx, span := tracer.Start(ctx, "InstallmentPurchaseService.FindByID")
+	defer span.End()
+
+	purchase, err := s.findInstallmentPurchase(ctx, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return purchase, nil
+}
+
+func (s *InstallmentPurchaseService) Create(ctx context.Context, createInstallmentPurchaseDTO *dto.CreateInstallmentPurchaseDTO) (*entity.InstallmentPurchase, error) {
+	ctx, span := tracer.Start(ctx, "InstallmentPurchaseService.Create")
+	defer span.End()
+
+	purchase, err := entity.NewInstallmentPurchase(s.clock, s.ids, createInstallmentPurchaseDTO.UserID, createInstallmentPurchaseDTO.CategoryID,
+		createInstallmentPurchaseDTO.Description, createInstallmentPurchaseDTO.TotalAmount, createInstallmentPurchaseDTO.Installments,
+		createInstallmentPurchaseDTO.FirstDueAt, createInstallmentPurchaseDTO.Remainder)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if err := checkCategory(ctx, s.categoryRepository, purchase.UserID(), purchase.CategoryID()); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	installments := purchase.Installments()
+	operations := make([]dto.BatchOperationDTO, len(installments))
+	for i, installment := range installments {
+		operations[i].Create = &dto.CreateTransactionDTO{
+			UserID:      purchase.UserID(),
+			CategoryID:  purchase.CategoryID(),
+			Amount:      installment.Amount,
+			Datetime:    installment.DueAt,
+			Description: purchase.TransactionDescription(installment),
+		}
+	}
+
+	var createdPurchase *entity.InstallmentPurchase
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		results, err := s.transactionService.Batch(ctx, &dto.BatchTransactionDTO{
+			UserID:     purchase.UserID(),
+			Atomic:     true,
+			Operations: operations,
+		})
+		if err != nil {
+			return err
+		}
+
+		transactionIDs := make([]uuid.UUID, len(results))
+		for i, result := range results {
+			transactionIDs[i] = result.Transaction.ID()
+		}
+		purchase.Link(transactionIDs)
+
+		createdPurchase, err = s.installmentPurchaseRepository.Create(ctx, purchase)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "installment purchase created",
+		"installment_purchase_id", createdPurchase.ID(),
+		"user_id", createdPurchase.UserID(),
+		"installments", len(installments),
+	)
+
+	return createdPurchase, nil
+}
+
+func (s *InstallmentPurchaseService) EditRemaining(ctx context.Context, editRemainingDTO *dto.EditRemainingInstallmentsDTO) (*entity.InstallmentPurchase, error) {
+	ctx, span := tracer.Start(ctx, "InstallmentPurchaseService.EditRemaining")
+	defer span.End()
+
+	var updatedPurchase *entity.InstallmentPurchase
+	var edited []entity.Installment
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		purchase, err := s.findInstallmentPurchase(ctx, editRemainingDTO.UserID, editRemainingDTO.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("installment_purchase", purchase.ID(), editRemainingDTO.Version, purchase.Version()); err != nil {
+			return err
+		}
+		if err := checkCategory(ctx, s.categoryRepository, purchase.UserID(), editRemainingDTO.CategoryID); err != nil {
+			return err
+		}
+
+		edited, err = purchase.EditRemaining(s.clock, editRemainingDTO.CategoryID, editRemainingDTO.Description, editRemainingDTO.TotalAmount)
+		if err != nil {
+			return err
+		}
+
+		operations := make([]dto.BatchOperationDTO, len(edited))
+		for i, installment := range edited {
+			operations[i].Update = &dto.UpdateTransactionDTO{
+				ID:          installment.TransactionID,
+				UserID:      purchase.UserID(),
+				CategoryID:  purchase.CategoryID(),
+				Amount:      installment.Amount,
+				Datetime:    installment.DueAt,
+				Description: purchase.TransactionDescription(installment),
+			}
+		}
+
+		updatedPurchase, err = s.updateWithTransactions(ctx, purchase, operations)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "installment purchase edited",
+		"installment_purchase_id", updatedPurchase.ID(),
+		"user_id", updatedPurchase.UserID(),
+		"installments", len(edited),
+	)
+
+	return updatedPurchase, nil
+}
+
+func (s *InstallmentPurchaseService) CancelRemaining(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.InstallmentPurchase, error) {
+	ctx, span := tracer.Start(ctx, "InstallmentPurchaseService.CancelRemaining")
+	defer span.End()
+
+	var updatedPurchase *entity.InstallmentPurchase
+	var cancelled []entity.Installment
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		purchase, err := s.findInstallmentPurchase(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("installment_purchase", purchase.ID(), version, purchase.Version()); err != nil {
+			return err
+		}
+
+		cancelled, err = purchase.Cancel(s.clock)
+		if err != nil {
+			return err
+		}
+
+		operations := make([]dto.BatchOperationDTO, len(cancelled))
+		for i, installment := range cancelled {
+			operations[i].Delete = &dto.DeleteTransactionDTO{ID: installment.TransactionID, UserID: userID}
+		}
+
+		updatedPurchase, err = s.updateWithTransactions(ctx, purchase, operations)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "installment purchase cancelled",
+		"installment_purchase_id", updatedPurchase.ID(),
+		"user_id", updatedPurchase.UserID(),
+		"installments", len(cancelled),
+	)
+
+	return updatedPurchase, nil
+}
+
+// updateWithTransactions stores the purchase and applies the operations to the transactions of its
+// installments, in the unit of work bound to ctx. A transaction the user already deleted is left
+// alone rather than failing the whole change.
+func (s *InstallmentPurchaseService) updateWithTransactions(ctx context.Context, purchase *entity.InstallmentPurchase, operations []dto.BatchOperationDTO) (*entity.InstallmentPurchase, error) {
+	updatedPurchase, err := s.installmentPurchaseRepository.Update(ctx, purchase)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.transactionService.Batch(ctx, &dto.BatchTransactionDTO{
+		UserID:     purchase.UserID(),
+		Operations: operations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		if result.Err != nil && !domainerror.IsKind(result.Err, domainerror.KindNotFound) {
+			return nil, withinBatch(i, result.Err)
+		}
+	}
+
+	return updatedPurchase, nil
+}
+
+// findInstallmentPurchase loads a purchase of the user, reporting another user's purchase as
+// missing so ids cannot be probed
+func (s *InstallmentPurchaseService) findInstallmentPurchase(ctx context.Context, userID, id uuid.UUID) (*entity.InstallmentPurchase, error) {
+	purchase, err := s.installmentPurchaseRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase.UserID() != userID {
+		return nil, domainerror.NewNotFound("installment_purchase", id)
+	}
+	return purchase, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type installmentPurchaseServiceFixture struct {
+	service      *InstallmentPurchaseService
+	transactions interfaces.TransactionServiceInterface
+	clock        *clock.Fixed
+	userID       uuid.UUID
+	categoryID   uuid.UUID
+}
+
+func newInstallmentPurchaseServiceFixture(t *testing.T) *installmentPurchaseServiceFixture {
+	store := memory.NewStore()
+	fixture := &installmentPurchaseServiceFixture{
+		clock:  clock.NewFixed(time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)),
+		userID: uuid.New(),
+	}
+
+	categories := memory.NewCategoryRepository(store).(*memory.CategoryRepository)
+	category, err := entity.NewCategory(fixture.clock, identifier.NewV7(), fixture.userID, "Electronics", enum.CategoryTypeExpense, false, "")
+	assert.Nil(t, err)
+	assert.Nil(t, categories.Save(context.Background(), category))
+	fixture.categoryID = category.ID()
+
+	unitOfWork := memory.NewUnitOfWork(store)
+	auditTrail := NewAuditTrailService(memory.NewAuditRepository(store), fixture.clock, identifier.NewV7())
+	fixture.transactions = NewTransactionService(unitOfWork, memory.NewTransactionRepository(store), categories, auditTrail,
+		&fakeMetrics{created: make(map[enum.CategoryType]int)}, fixture.clock, identifier.NewV7())
+	fixture.service = NewInstallmentPurchaseService(
+		unitOfWork,
+		memory.NewInstallmentPurchaseRepository(store),
+		categories,
+		fixture.transactions,
+		fixture.clock,
+		identifier.NewV7(),
+	).(*InstallmentPurchaseService)
+	return fixture
+}
+
+// create buys a notebook in 3 installments, the first already due
+func (f *installmentPurchaseServiceFixture) create(t *testing.T) *entity.InstallmentPurchase {
+	purchase, err := f.service.Create(context.Background(), &dto.CreateInstallmentPurchaseDTO{
+		UserID:       f.userID,
+		CategoryID:   f.categoryID,
+		Description:  "Notebook",
+		TotalAmount:  1000,
+		Installments: 3,
+		FirstDueAt:   time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC),
+		Remainder:    enum.InstallmentRemainderFirst,
+	})
+	assert.Nil(t, err)
+	return purchase
+}
+
+func TestInstallmentPurchaseService(t *testing.T) {
+	t.Run("should record a transaction for each installment", func(t *testing.T) {
+		fixture := newInstallmentPurchaseServiceFixture(t)
+
+		purchase := fixture.create(t)
+
+		for _, installment := range purchase.Installments() {
+			transaction, err := fixture.transactions.FindByID(context.Background(), fixture.userID, installment.TransactionID)
+			assert.Nil(t, err)
+			assert.Equal(t, installment.Amount, transaction.Amount())
+			assert.Equal(t, installment.DueAt, transaction.Datetime())
+		}
+		transaction, err := fixture.transactions.FindByID(context.Background(), fixture.userID, purchase.Installments()[0].TransactionID)
+		assert.Nil(t, err)
+		assert.Equal(t, 333.34, transaction.Amount())
+		assert.Equal(t, "Notebook (1/3)", transaction.Description())
+	})
+
+	t.Run("should record nothing when the category is not the user's", func(t *testing.T) {
+		fixture := newInstallmentPurchaseServiceFixture(t)
+
+		_, err := fixture.service.Create(context.Background(), &dto.CreateInstallmentPurchaseDTO{
+			UserID:       fixture.userID,
+			CategoryID:   uuid.New(),
+			TotalAmount:  1000,
+			Installments: 3,
+			FirstDueAt:   fixture.clock.Now(),
+			Remainder:    enum.InstallmentRemainderFirst,
+		})
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+		transactions, _, err := fixture.transactions.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Nil(t, err)
+		assert.Empty(t, transactions)
+	})
+
+	t.Run("should edit the transactions of the remaining installments", func(t *testing.T) {
+		fixture := newInstallmentPurchaseServiceFixture(t)
+		purchase := fixture.create(t)
+
+		edited, err := fixture.service.EditRemaining(context.Background(), &dto.EditRemainingInstallmentsDTO{
+			ID:          purchase.ID(),
+			UserID:      fixture.userID,
+			Version:     purchase.Version(),
+			CategoryID:  fixture.categoryID,
+			Description: "Laptop",
+			TotalAmount: 1200,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), edited.Version())
+		installments := edited.Installments()
+		first, err := fixture.transactions.FindByID(context.Background(), fixture.userID, installments[0].TransactionID)
+		assert.Nil(t, err)
+		assert.Equal(t, "Notebook (1/3)", first.Description())
+		last, err := fixture.transactions.FindByID(context.Background(), fixture.userID, installments[2].TransactionID)
+		assert.Nil(t, err)
+		assert.Equal(t, "Laptop (3/3)", last.Description())
+		assert.Equal(t, 433.33, last.Amount())
+	})
+
+	t.Run("should trash the transactions of the cancelled installments", func(t *testing.T) {
+		fixture := newInstallmentPurchaseServiceFixture(t)
+		purchase := fixture.create(t)
+		installments := purchase.Installments()
+		assert.Nil(t, fixture.transactions.Delete(context.Background(), fixture.userID, installments[2].TransactionID, 0))
+
+		cancelled, err := fixture.service.CancelRemaining(context.Background(), fixture.userID, purchase.ID(), purchase.Version())
+
+		assert.Nil(t, err)
+		assert.True(t, cancelled.Cancelled())
+		assert.Equal(t, 333.34, cancelled.TotalAmount())
+		_, err = fixture.transactions.FindByID(context.Background(), fixture.userID, installments[0].TransactionID)
+		assert.Nil(t, err)
+		_, err = fixture.transactions.FindByID(context.Background(), fixture.userID, installments[1].TransactionID)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+		_, err = fixture.service.CancelRemaining(context.Background(), fixture.userID, purchase.ID(), 0)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+	})
+
+	t.Run("should cancel a purchase before its first installment falls due", func(t *testing.T) {
+		fixture := newInstallmentPurchaseServiceFixture(t)
+		purchase, err := fixture.service.Create(context.Background(), &dto.CreateInstallmentPurchaseDTO{
+			UserID:       fixture.userID,
+			CategoryID:   fixture.categoryID,
+			Description:  "Notebook",
+			TotalAmount:  1000,
+			Installments: 3,
+			FirstDueAt:   time.Date(2025, 4, 5, 12, 0, 0, 0, time.UTC),
+			Remainder:    enum.InstallmentRemainderFirst,
+		})
+		assert.Nil(t, err)
+
+		cancelled, err := fixture.service.CancelRemaining(context.Background(), fixture.userID, purchase.ID(), purchase.Version())
+
+		assert.Nil(t, err)
+		assert.True(t, cancelled.Cancelled())
+		assert.Equal(t, 0.0, cancelled.TotalAmount())
+		for _, installment := range purchase.Installments() {
+			_, err = fixture.transactions.FindByID(context.Background(), fixture.userID, installment.TransactionID)
+			assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+		}
+	})
+}
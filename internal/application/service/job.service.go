@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// cancelAttempts bounds how many times a cancellation is retried when a worker updates the
+	// job in between
+	cancelAttempts = 3
+	// purgeBatchSize is how many jobs Purge removes per round trip
+	purgeBatchSize = 100
+)
+
+type JobService struct {
+	jobRepository repository.JobRepositoryInterface
+	storage       interfaces.FileStorageInterface
+	clock         clock.Clock
+	ids           identifier.Generator
+	maxAttempts   int
+	retention     time.Duration
+}
+
+// NewJobService creates the background job service. Jobs are attempted up to maxAttempts times,
+// and purged with their files once they have been finished for longer than retention.
+func NewJobService(
+	jobRepository repository.JobRepositoryInterface,
+	storage interfaces.FileStorageInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+	maxAttempts int,
+	retention time.Duration,
+) interfaces.JobServiceInterface {
+	return &JobService{
+		jobRepository: jobRepository,
+		storage:       storage,
+		clock:         clock,
+		ids:           ids,
+		maxAttempts:   maxAttempts,
+		retention:     retention,
+	}
+}
+
+func (s *JobService) Enqueue(ctx context.Context, enqueueJobDTO *dto.EnqueueJobDTO) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "JobService.Enqueue")
+	defer span.End()
+
+	payload, err := json.Marshal(enqueueJobDTO.Payload)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	job, err := entity.NewJob(s.clock, s.ids, enqueueJobDTO.UserID, enqueueJobDTO.Kind, payload, s.maxAttempts)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if enqueueJobDTO.Input != nil {
+		if err := s.store(ctx, job.InputKey(), enqueueJobDTO.Input); err != nil {
+			return nil, recordError(span, err)
+		}
+	}
+
+	created, err := s.jobRepository.Create(ctx, job)
+	if err != nil {
+		s.deleteFiles(ctx, job)
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "job enqueued",
+		"job_id", created.ID(),
+		"kind", created.Kind(),
+	)
+
+	return created, nil
+}
+
+func (s *JobService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "JobService.FindByID")
+	defer span.End()
+
+	job, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return job, nil
+}
+
+// Cancel reads the job again when a worker updated it in between, as heartbeats bump the
+// version of running jobs
+func (s *JobService) Cancel(ctx context.Context, userID, id uuid.UUID) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "JobService.Cancel")
+	defer span.End()
+
+	var err error
+	for range cancelAttempts {
+		var job *entity.Job
+		job, err = s.find(ctx, userID, id)
+		if err != nil {
+			return nil, recordError(span, err)
+		}
+
+		if err = job.Cancel(s.clock); err != nil {
+			return nil, recordError(span, err)
+		}
+
+		var cancelled *entity.Job
+		cancelled, err = s.jobRepository.Update(ctx, job)
+		if domainerror.IsKind(err, domainerror.KindPreconditionFailed) {
+			continue
+		}
+		if err != nil {
+			return nil, recordError(span, err)
+		}
+
+		logger.FromContext(ctx).InfoContext(ctx, "job cancelled",
+			"job_id", cancelled.ID(),
+			"kind", cancelled.Kind(),
+		)
+
+		return cancelled, nil
+	}
+
+	return nil, recordError(span, err)
+}
+
+func (s *JobService) OpenResult(ctx context.Context, userID, id uuid.UUID) (*entity.Job, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "JobService.OpenResult")
+	defer span.End()
+
+	job, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	if job.Status() != enum.JobStatusSucceeded || job.Result().Name == "" {
+		return nil, nil, recordError(span, domainerror.NewConflict("job_result_unavailable", "the job has no result to download", nil).
+			WithParams(map[string]string{"status": string(job.Status())}))
+	}
+
+	result, err := s.storage.Open(ctx, job.ResultKey())
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return job, result, nil
+}
+
+// Purge removes the files of a job before the job, so a failure never leaves files no job points to
+func (s *JobService) Purge(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "JobService.Purge")
+	defer span.End()
+
+	before := s.clock.Now().Add(-s.retention)
+
+	var purged int64
+	for {
+		jobs, err := s.jobRepository.FindFinishedBefore(ctx, before, purgeBatchSize)
+		if err != nil {
+			return purged, recordError(span, err)
+		}
+
+		for i := range jobs {
+			if err := s.deleteFiles(ctx, &jobs[i]); err != nil {
+				return purged, recordError(span, err)
+			}
+			if err := s.jobRepository.Delete(ctx, jobs[i].ID()); err != nil && !domainerror.IsKind(err, domainerror.KindNotFound) {
+				return purged, recordError(span, err)
+			}
+			purged++
+		}
+
+		if len(jobs) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (s *JobService) find(ctx context.Context, userID, id uuid.UUID) (*entity.Job, error) {
+	job, err := s.jobRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID() != userID {
+		return nil, domainerror.NewNotFound("job", id)
+	}
+	return job, nil
+}
+
+func (s *JobService) store(ctx context.Context, key string, content []byte) error {
+	file, err := s.storage.Create(ctx, key)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		s.storage.Delete(ctx, key)
+		return err
+	}
+	return file.Close()
+}
+
+func (s *JobService) deleteFiles(ctx context.Context, job *entity.Job) error {
+	if err := s.storage.Delete(ctx, job.InputKey()); err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, job.ResultKey())
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// materializeBatchSize is how many due series MaterializeDue loads at a time
+const materializeBatchSize = 100
+
+type RecurringTransactionService struct {
+	unitOfWork                     interfaces.UnitOfWorkInterface
+	recurringTransactionRepository repository.RecurringTransactionRepositoryInterface
+	categoryRepository             repository.CategoryRepositoryInterface
+	transactionService             interfaces.TransactionServiceInterface
+	clock                          clock.Clock
+	ids                            identifier.Generator
+}
+
+func NewRecurringTransactionService(
+	unitOfWork interfaces.UnitOfWorkInterface,
+	recurringTransactionRepository repository.RecurringTransactionRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	transactionService interfaces.TransactionServiceInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.RecurringTransactionServiceInterface {
+	return &RecurringTransactionService{
+		unitOfWork:                     unitOfWork,
+		recurringTransactionRepository: recurringTransactionRepository,
+		categoryRepository:             categoryRepository,
+		transactionService:             transactionService,
+		clock:                          clock,
+		ids:                            ids,
+	}
+}
+
+func (s *RecurringTransactionService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.RecurringTransaction, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	recurrings, err := s.recurringTransactionRepository.FindAllPaginated(ctx, userID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return recurrings, paginate, nil
+}
+
+func (s *RecurringTransactionService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.RecurringTransaction, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.FindByID")
+	defer span.End()
+
+	recurring, err := s.findRecurringTransaction(ctx, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return recurring, nil
+}
+
+func (s *RecurringTransactionService) Create(ctx context.Context, createRecurringTransactionDTO *dto.CreateRecurringTransactionDTO) (*entity.RecurringTransaction, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.Create")
+	defer span.End()
+
+	recurring, err := entity.NewRecurringTransaction(s.clock, s.ids, createRecurringTransactionDTO.UserID, createRecurringTransactionDTO.CategoryID,
+		createRecurringTransactionDTO.Amount, createRecurringTransactionDTO.Description, createRecurringTransactionDTO.StartAt, createRecurringTransactionDTO.Rule)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	if err := checkCategory(ctx, s.categoryRepository, recurring.UserID(), recurring.CategoryID()); err != nil {
+		return nil, recordError(span, err)
+	}
+
+	createdRecurring, err := s.recurringTransactionRepository.Create(ctx, recurring)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "recurring transaction created",
+		"recurring_transaction_id", createdRecurring.ID(),
+		"user_id", createdRecurring.UserID(),
+		"category_id", createdRecurring.CategoryID(),
+	)
+
+	return createdRecurring, nil
+}
+
+func (s *RecurringTransactionService) Delete(ctx context.Context, userID, id uuid.UUID, version int64) error {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.Delete")
+	defer span.End()
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recurring, err := s.findRecurringTransaction(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("recurring_transaction", id, version, recurring.Version()); err != nil {
+			return err
+		}
+
+		return s.recurringTransactionRepository.Delete(ctx, id, recurring.Version())
+	})
+	if err != nil {
+		return recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "recurring transaction deleted",
+		"recurring_transaction_id", id,
+		"user_id", userID,
+	)
+
+	return nil
+}
+
+func (s *RecurringTransactionService) Skip(ctx context.Context, skipOccurrenceDTO *dto.SkipOccurrenceDTO) (*entity.RecurringTransaction, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.Skip")
+	defer span.End()
+
+	var updatedRecurring *entity.RecurringTransaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recurring, err := s.findRecurringTransaction(ctx, skipOccurrenceDTO.UserID, skipOccurrenceDTO.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("recurring_transaction", recurring.ID(), skipOccurrenceDTO.Version, recurring.Version()); err != nil {
+			return err
+		}
+
+		if err := recurring.Skip(s.clock, skipOccurrenceDTO.Date); err != nil {
+			return err
+		}
+
+		updatedRecurring, err = s.recurringTransactionRepository.Update(ctx, recurring)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "recurring transaction occurrence skipped",
+		"recurring_transaction_id", updatedRecurring.ID(),
+		"user_id", updatedRecurring.UserID(),
+		"date", skipOccurrenceDTO.Date.Format(time.DateOnly),
+	)
+
+	return updatedRecurring, nil
+}
+
+func (s *RecurringTransactionService) Resume(ctx context.Context, resumeDTO *dto.ResumeRecurringTransactionDTO) (*entity.RecurringTransaction, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.Resume")
+	defer span.End()
+
+	var resumed *entity.RecurringTransaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recurring, err := s.findRecurringTransaction(ctx, resumeDTO.UserID, resumeDTO.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("recurring_transaction", recurring.ID(), resumeDTO.Version, recurring.Version()); err != nil {
+			return err
+		}
+		if err := checkCategory(ctx, s.categoryRepository, recurring.UserID(), recurring.CategoryID()); err != nil {
+			return err
+		}
+
+		if err := recurring.Resume(s.clock); err != nil {
+			return err
+		}
+
+		resumed, err = s.recurringTransactionRepository.Update(ctx, recurring)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "recurring transaction resumed",
+		"recurring_transaction_id", resumed.ID(),
+		"user_id", resumed.UserID(),
+	)
+
+	return resumed, nil
+}
+
+func (s *RecurringTransactionService) EditFollowing(ctx context.Context, editFollowingDTO *dto.EditFollowingOccurrencesDTO) (*entity.RecurringTransaction, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.EditFollowing")
+	defer span.End()
+
+	var edited *entity.RecurringTransaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recurring, err := s.findRecurringTransaction(ctx, editFollowingDTO.UserID, editFollowingDTO.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion("recurring_transaction", recurring.ID(), editFollowingDTO.Version, recurring.Version()); err != nil {
+			return err
+		}
+
+		index, at, err := recurring.Occurrence(s.clock, editFollowingDTO.Date)
+		if err != nil {
+			return err
+		}
+		if err := checkCategory(ctx, s.categoryRepository, recurring.UserID(), editFollowingDTO.CategoryID); err != nil {
+			return err
+		}
+
+		if index > 0 {
+			edited, err = s.split(ctx, recurring, index, at, editFollowingDTO)
+			return err
+		}
+		if err := recurring.Update(s.clock, editFollowingDTO.CategoryID, editFollowingDTO.Amount, editFollowingDTO.Description, editFollowingDTO.Rule); err != nil {
+			return err
+		}
+		edited, err = s.recurringTransactionRepository.Update(ctx, recurring)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "recurring transaction occurrences edited",
+		"recurring_transaction_id", editFollowingDTO.ID,
+		"edited_recurring_transaction_id", edited.ID(),
+		"user_id", edited.UserID(),
+		"date", editFollowingDTO.Date.Format(time.DateOnly),
+	)
+
+	return edited, nil
+}
+
+// split ends the series before the occurrence at index and starts the edited one at the time of
+// that occurrence, so the occurrences already recorded keep their values. It runs in the unit of
+// work bound to ctx.
+func (s *RecurringTransactionService) split(ctx context.Context, recurring *entity.RecurringTransaction, index int, at time.Time, editFollowingDTO *dto.EditFollowingOccurrencesDTO) (*entity.RecurringTransaction, error) {
+	following, err := entity.NewRecurringTransaction(s.clock, s.ids, recurring.UserID(), editFollowingDTO.CategoryID,
+		editFollowingDTO.Amount, editFollowingDTO.Description, at, editFollowingDTO.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	recurring.EndBefore(s.clock, index)
+	if _, err := s.recurringTransactionRepository.Update(ctx, recurring); err != nil {
+		return nil, err
+	}
+
+	return s.recurringTransactionRepository.Create(ctx, following)
+}
+
+// MaterializeDue goes through the due series in pages. Each series is moved past its due
+// occurrences and the transactions recorded in the same unit of work, conditional on the version
+// that was read: a series changed meanwhile, by its user or by another instance materializing it
+// first, is left for the next run rather than recorded twice. A series that fails does not hold
+// back the others. When its occurrences are refused, as when its category is gone, it is paused
+// so it is not retried on every run; other failures are logged and retried.
+func (s *RecurringTransactionService) MaterializeDue(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "RecurringTransactionService.MaterializeDue")
+	defer span.End()
+
+	now := s.clock.Now()
+
+	var recorded int64
+	after := uuid.Nil
+	for {
+		due, err := s.recurringTransactionRepository.FindDue(ctx, now, after, materializeBatchSize)
+		if err != nil {
+			return recorded, recordError(span, err)
+		}
+
+		for _, recurring := range due {
+			// materialize moves the series it is given along, so a failure is handled on the series as read
+			materialized := recurring
+			count, err := s.materialize(ctx, &materialized, now)
+			if err != nil {
+				s.materializeFailed(ctx, &recurring, err)
+				continue
+			}
+			recorded += count
+		}
+
+		if len(due) < materializeBatchSize {
+			return recorded, nil
+		}
+		after = due[len(due)-1].ID()
+	}
+}
+
+// materializeFailed pauses a series whose occurrences were refused, and logs other failures
+func (s *RecurringTransactionService) materializeFailed(ctx context.Context, recurring *entity.RecurringTransaction, err error) {
+	if domainerror.IsKind(err, domainerror.KindPreconditionFailed) {
+		return
+	}
+
+	domainErr, refused := domainerror.As(err)
+	if !refused {
+		logger.FromContext(ctx).ErrorContext(ctx, "recurring transaction not materialized",
+			"recurring_transaction_id", recurring.ID(),
+			"user_id", recurring.UserID(),
+			"error", err,
+		)
+		return
+	}
+
+	recurring.Pause(s.clock, domainErr.Code)
+	if _, err := s.recurringTransactionRepository.Update(ctx, recurring); err != nil {
+		if !domainerror.IsKind(err, domainerror.KindPreconditionFailed) {
+			logger.FromContext(ctx).ErrorContext(ctx, "recurring transaction not paused",
+				"recurring_transaction_id", recurring.ID(),
+				"user_id", recurring.UserID(),
+				"error", err,
+			)
+		}
+		return
+	}
+
+	logger.FromContext(ctx).WarnContext(ctx, "recurring transaction paused",
+		"recurring_transaction_id", recurring.ID(),
+		"user_id", recurring.UserID(),
+		"reason", domainErr.Code,
+	)
+}
+
+func (s *RecurringTransactionService) materialize(ctx context.Context, recurring *entity.RecurringTransaction, now time.Time) (int64, error) {
+	due := recurring.Materialize(now)
+
+	operations := make([]dto.BatchOperationDTO, len(due))
+	for i, at := range due {
+		operations[i].Create = &dto.CreateTransactionDTO{
+			UserID:      recurring.UserID(),
+			CategoryID:  recurring.CategoryID(),
+			Amount:      recurring.Amount(),
+			Datetime:    at,
+			Description: recurring.Description(),
+		}
+	}
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.recurringTransactionRepository.Update(ctx, recurring); err != nil {
+			return err
+		}
+		if len(operations) == 0 {
+			return nil
+		}
+
+		_, err := s.transactionService.Batch(ctx, &dto.BatchTransactionDTO{
+			UserID:     recurring.UserID(),
+			Atomic:     true,
+			Operations: operations,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(due)), nil
+}
+
+// findRecurringTransaction loads a recurring transaction of the user, reporting another user's
+// series as missing so ids cannot be probed
+func (s *RecurringTransactionService) findRecurringTransaction(ctx context.Context, userID, id uuid.UUID) (*entity.RecurringTransaction, error) {
+	recurring, err := s.recurringTransactionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recurring.UserID() != userID {
+		return nil, domainerror.NewNotFound("recurring_transaction", id)
+	}
+	return recurring, nil
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type recurringTransactionServiceFixture struct {
+	service      *RecurringTransactionService
+	categories   *memory.CategoryRepository
+	transactions repository.TransactionRepositoryInterface
+	category     *entity.Category
+	clock        *clock.Fixed
+	userID       uuid.UUID
+}
+
+func newRecurringTransactionServiceFixture(t *testing.T) *recurringTransactionServiceFixture {
+	store := memory.NewStore()
+	fixture := &recurringTransactionServiceFixture{
+		transactions: memory.NewTransactionRepository(store),
+		clock:        clock.NewFixed(time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)),
+		userID:       uuid.New(),
+	}
+
+	categories := memory.NewCategoryRepository(store).(*memory.CategoryRepository)
+	fixture.categories = categories
+	category, err := entity.NewCategory(fixture.clock, identifier.NewV7(), fixture.userID, "Housing", enum.CategoryTypeExpense, false, "")
+	assert.Nil(t, err)
+	assert.Nil(t, categories.Save(context.Background(), category))
+	fixture.category = category
+
+	unitOfWork := memory.NewUnitOfWork(store)
+	auditTrail := NewAuditTrailService(memory.NewAuditRepository(store), fixture.clock, identifier.NewV7())
+	transactionService := NewTransactionService(unitOfWork, fixture.transactions, categories, auditTrail,
+		&fakeMetrics{created: make(map[enum.CategoryType]int)}, fixture.clock, identifier.NewV7())
+	fixture.service = NewRecurringTransactionService(
+		unitOfWork,
+		memory.NewRecurringTransactionRepository(store),
+		categories,
+		transactionService,
+		fixture.clock,
+		identifier.NewV7(),
+	).(*RecurringTransactionService)
+	return fixture
+}
+
+func (f *recurringTransactionServiceFixture) create(t *testing.T, startAt time.Time) *entity.RecurringTransaction {
+	recurring, err := f.service.Create(context.Background(), &dto.CreateRecurringTransactionDTO{
+		UserID:      f.userID,
+		CategoryID:  f.category.ID(),
+		Amount:      1200,
+		Description: "Rent",
+		StartAt:     startAt,
+		Rule:        entity.RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1},
+	})
+	assert.Nil(t, err)
+	return recurring
+}
+
+func (f *recurringTransactionServiceFixture) recorded(t *testing.T) []entity.Transaction {
+	transactions, err := f.transactions.FindAllPaginated(context.Background(), f.userID, pagination.NewPagination(1, 100))
+	assert.Nil(t, err)
+	return transactions
+}
+
+func TestRecurringTransactionService_MaterializeDue(t *testing.T) {
+	t.Run("should record due occurrences once", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := fixture.create(t, time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC))
+		_, err := fixture.service.Skip(context.Background(), &dto.SkipOccurrenceDTO{
+			ID: recurring.ID(), UserID: fixture.userID, Date: time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		})
+		assert.Nil(t, err)
+
+		recorded, err := fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), recorded)
+
+		recorded, err = fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), recorded)
+
+		transactions := fixture.recorded(t)
+		assert.Len(t, transactions, 2)
+		for _, transaction := range transactions {
+			assert.Equal(t, "Rent", transaction.Description())
+			assert.Equal(t, 5, transaction.Datetime().Day())
+			assert.NotEqual(t, time.February, transaction.Datetime().Month())
+		}
+
+		stored, err := fixture.service.FindByID(context.Background(), fixture.userID, recurring.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2025, 4, 5, 9, 0, 0, 0, time.UTC), stored.NextAt())
+	})
+
+	t.Run("should pause a series whose category is gone", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		failing := fixture.create(t, time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+		fixture.category.Trash(fixture.clock)
+		assert.Nil(t, fixture.categories.Save(context.Background(), fixture.category))
+
+		recorded, err := fixture.service.MaterializeDue(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), recorded)
+		stored, err := fixture.service.FindByID(context.Background(), fixture.userID, failing.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, 0, stored.Materialized())
+		assert.True(t, stored.Paused())
+		assert.Equal(t, fixture.clock.Now(), stored.PausedAt())
+		assert.Equal(t, "category_not_found", stored.PauseReason())
+		assert.Equal(t, failing.Version()+1, stored.Version())
+
+		fixture.category.Untrash(fixture.clock)
+		assert.Nil(t, fixture.categories.Save(context.Background(), fixture.category))
+		fixture.clock.Advance(60 * 24 * time.Hour)
+
+		recorded, err = fixture.service.MaterializeDue(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), recorded)
+		assert.Empty(t, fixture.recorded(t))
+	})
+}
+
+func TestRecurringTransactionService_Resume(t *testing.T) {
+	paused := func(t *testing.T, fixture *recurringTransactionServiceFixture) *entity.RecurringTransaction {
+		recurring := fixture.create(t, time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+		fixture.category.Trash(fixture.clock)
+		assert.Nil(t, fixture.categories.Save(context.Background(), fixture.category))
+		_, err := fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+
+		stored, err := fixture.service.FindByID(context.Background(), fixture.userID, recurring.ID())
+		assert.Nil(t, err)
+		return stored
+	}
+
+	t.Run("should pass over the occurrences that fell due while paused", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := paused(t, fixture)
+		fixture.category.Untrash(fixture.clock)
+		assert.Nil(t, fixture.categories.Save(context.Background(), fixture.category))
+		fixture.clock.Set(time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC))
+
+		resumed, err := fixture.service.Resume(context.Background(), &dto.ResumeRecurringTransactionDTO{
+			ID: recurring.ID(), UserID: fixture.userID, Version: recurring.Version(),
+		})
+
+		assert.Nil(t, err)
+		assert.False(t, resumed.Paused())
+		assert.Equal(t, time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), resumed.NextAt())
+
+		recorded, err := fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), recorded)
+		assert.Empty(t, fixture.recorded(t))
+	})
+
+	t.Run("should not resume while the category is gone", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := paused(t, fixture)
+
+		_, err := fixture.service.Resume(context.Background(), &dto.ResumeRecurringTransactionDTO{
+			ID: recurring.ID(), UserID: fixture.userID, Version: recurring.Version(),
+		})
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+	})
+
+	t.Run("should refuse a series that is not paused", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := fixture.create(t, time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC))
+
+		_, err := fixture.service.Resume(context.Background(), &dto.ResumeRecurringTransactionDTO{
+			ID: recurring.ID(), UserID: fixture.userID, Version: recurring.Version(),
+		})
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+	})
+}
+
+func TestRecurringTransactionService_EditFollowing(t *testing.T) {
+	edit := func(fixture *recurringTransactionServiceFixture, recurring *entity.RecurringTransaction, date time.Time) (*entity.RecurringTransaction, error) {
+		return fixture.service.EditFollowing(context.Background(), &dto.EditFollowingOccurrencesDTO{
+			ID:          recurring.ID(),
+			UserID:      fixture.userID,
+			Version:     recurring.Version(),
+			Date:        date,
+			CategoryID:  fixture.category.ID(),
+			Amount:      1300,
+			Description: "Rent",
+			Rule:        entity.RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: 10},
+		})
+	}
+
+	t.Run("should change the series itself from its first occurrence", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := fixture.create(t, time.Date(2025, 4, 5, 9, 0, 0, 0, time.UTC))
+
+		edited, err := edit(fixture, recurring, time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, err)
+		assert.Equal(t, recurring.ID(), edited.ID())
+		assert.Equal(t, int64(2), edited.Version())
+		assert.Equal(t, time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC), edited.NextAt())
+	})
+
+	t.Run("should end the series and start another from a later occurrence", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := fixture.create(t, time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC))
+		_, err := fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+		recurring, err = fixture.service.FindByID(context.Background(), fixture.userID, recurring.ID())
+		assert.Nil(t, err)
+
+		edited, err := edit(fixture, recurring, time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, err)
+		assert.NotEqual(t, recurring.ID(), edited.ID())
+		assert.Equal(t, time.Date(2025, 5, 10, 9, 0, 0, 0, time.UTC), edited.NextAt())
+
+		ended, err := fixture.service.FindByID(context.Background(), fixture.userID, recurring.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ended.Rule().Count)
+		assert.Equal(t, time.Date(2025, 4, 5, 9, 0, 0, 0, time.UTC), ended.Upcoming(10)[0].At)
+		assert.Len(t, ended.Upcoming(10), 1)
+	})
+
+	t.Run("should not edit occurrences already recorded", func(t *testing.T) {
+		fixture := newRecurringTransactionServiceFixture(t)
+		recurring := fixture.create(t, time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC))
+		_, err := fixture.service.MaterializeDue(context.Background())
+		assert.Nil(t, err)
+
+		_, err = edit(fixture, recurring, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionFailed))
+
+		recurring, err = fixture.service.FindByID(context.Background(), fixture.userID, recurring.ID())
+		assert.Nil(t, err)
+		_, err = edit(fixture, recurring, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC))
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+	})
+}
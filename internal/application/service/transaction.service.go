@@ -1,55 +1,603 @@
 package service
 
 import (
-	"time"
+	"context"
+	"fmt"
 
 	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
 	"github.com/google/uuid"
 )
 
 type TransactionService struct {
+	unitOfWork            interfaces.UnitOfWorkInterface
 	transactionRepository repository.TransactionRepositoryInterface
+	categoryRepository    repository.CategoryRepositoryInterface
+	auditTrail            interfaces.AuditTrailInterface
+	metrics               interfaces.BusinessMetricsInterface
+	clock                 clock.Clock
+	ids                   identifier.Generator
 }
 
-func NewTransactionService(transactionRepository repository.TransactionRepositoryInterface) interfaces.TransactionServiceInterface {
-	return &TransactionService{transactionRepository: transactionRepository}
+func NewTransactionService(
+	unitOfWork interfaces.UnitOfWorkInterface,
+	transactionRepository repository.TransactionRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	auditTrail interfaces.AuditTrailInterface,
+	metrics interfaces.BusinessMetricsInterface,
+	clock clock.Clock,
+	ids identifier.Generator,
+) interfaces.TransactionServiceInterface {
+	return &TransactionService{
+		unitOfWork:            unitOfWork,
+		transactionRepository: transactionRepository,
+		categoryRepository:    categoryRepository,
+		auditTrail:            auditTrail,
+		metrics:               metrics,
+		clock:                 clock,
+		ids:                   ids,
+	}
 }
 
-func (s *TransactionService) FindAllPaginated(page, pageSize int) ([]entity.Transaction, *pagination.Pagination, error) {
+func (s *TransactionService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.Transaction, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.FindAllPaginated")
+	defer span.End()
+
 	paginate := pagination.NewPagination(page, pageSize)
 
-	transactions, err := s.transactionRepository.FindAllPaginated(paginate)
+	transactions, err := s.transactionRepository.FindAllPaginated(ctx, userID, paginate)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, recordError(span, err)
 	}
 
 	return transactions, paginate, nil
 }
 
-func (s *TransactionService) Create(createTransactionDTO *dto.CreateTransactionDTO) (*entity.Transaction, error) {
-	transaction, err := entity.NewTransaction(
-		uuid.New(),
+func (s *TransactionService) FindByID(ctx context.Context, userID, id uuid.UUID) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.FindByID")
+	defer span.End()
+
+	transaction, err := s.findTransaction(ctx, userID, id)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	return transaction, nil
+}
+
+func (s *TransactionService) Export(ctx context.Context, userID uuid.UUID, fn func(exported dto.ExportedTransactionDTO) error) error {
+	ctx, span := tracer.Start(ctx, "TransactionService.Export")
+	defer span.End()
+
+	owned, err := s.categoryRepository.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return recordError(span, err)
+	}
+	categories := make(map[uuid.UUID]*entity.Category, len(owned))
+	for i := range owned {
+		categories[owned[i].ID()] = &owned[i]
+	}
+
+	exported := 0
+	err = s.transactionRepository.Stream(ctx, userID, func(transaction *entity.Transaction) error {
+		row := dto.ExportedTransactionDTO{Transaction: transaction}
+		if category, found := categories[transaction.CategoryID()]; found {
+			row.CategoryName = category.Name()
+			row.CategoryType = category.Type()
+		}
+		exported++
+		return fn(row)
+	})
+	if err != nil {
+		return recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transactions exported",
+		"user_id", userID,
+		"transactions", exported,
+	)
+
+	return nil
+}
+
+func (s *TransactionService) Create(ctx context.Context, createTransactionDTO *dto.CreateTransactionDTO) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Create")
+	defer span.End()
+
+	transaction, err := entity.NewImportedTransaction(
+		s.clock,
+		s.ids,
+		createTransactionDTO.CategoryID,
+		createTransactionDTO.UserID,
+		createTransactionDTO.Amount,
+		createTransactionDTO.Datetime,
+		createTransactionDTO.Description,
+		createTransactionDTO.ExternalID,
+	)
+
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	var category *entity.Category
+	var createdTransaction *entity.Transaction
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		category, err = s.findCategory(ctx, transaction.UserID(), transaction.CategoryID())
+		if err != nil {
+			return err
+		}
+
+		createdTransaction, err = s.transactionRepository.Create(ctx, transaction)
+		if err != nil {
+			return err
+		}
+
+		return s.auditTrail.Record(ctx, createdTransaction.UserID(), enum.AuditResourceTransaction, createdTransaction.ID(), enum.AuditActionCreate, nil, createdTransaction.AuditFields())
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	s.metrics.TransactionCreated(category.Type())
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction created",
+		"transaction_id", createdTransaction.ID(),
+		"user_id", createdTransaction.UserID(),
+		"category_id", createdTransaction.CategoryID(),
+	)
+
+	return createdTransaction, nil
+}
+
+func (s *TransactionService) Update(ctx context.Context, updateTransactionDTO *dto.UpdateTransactionDTO) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Update")
+	defer span.End()
+
+	var updatedTransaction *entity.Transaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) (err error) {
+		updatedTransaction, err = s.update(ctx, updateTransactionDTO)
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction updated",
+		"transaction_id", updatedTransaction.ID(),
+		"user_id", updatedTransaction.UserID(),
+		"version", updatedTransaction.Version(),
+	)
+
+	return updatedTransaction, nil
+}
+
+// Patch merges the fields of patchTransactionDTO into the transaction as stored. The transaction
+// is read in the unit of work that writes it, so the merge is never based on a stale replica.
+func (s *TransactionService) Patch(ctx context.Context, patchTransactionDTO *dto.PatchTransactionDTO) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Patch")
+	defer span.End()
+
+	var updatedTransaction *entity.Transaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := s.findTransaction(ctx, patchTransactionDTO.UserID, patchTransactionDTO.ID)
+		if err != nil {
+			return err
+		}
+
+		updatedTransaction, err = s.update(ctx, mergeTransaction(current, patchTransactionDTO))
+		return err
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction updated",
+		"transaction_id", updatedTransaction.ID(),
+		"user_id", updatedTransaction.UserID(),
+		"version", updatedTransaction.Version(),
+	)
+
+	return updatedTransaction, nil
+}
+
+// Delete moves the transaction to the trash
+func (s *TransactionService) Delete(ctx context.Context, userID, id uuid.UUID, version int64) error {
+	ctx, span := tracer.Start(ctx, "TransactionService.Delete")
+	defer span.End()
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return s.trash(ctx, &dto.DeleteTransactionDTO{ID: id, UserID: userID, Version: version})
+	})
+	if err != nil {
+		return recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction trashed",
+		"transaction_id", id,
+		"user_id", userID,
+	)
+
+	return nil
+}
+
+func (s *TransactionService) Restore(ctx context.Context, userID, id uuid.UUID, version int64) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Restore")
+	defer span.End()
+
+	var restoredTransaction *entity.Transaction
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		transaction, err := s.transactionRepository.FindTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if transaction.UserID() != userID {
+			return domainerror.NewNotFound("transaction", id)
+		}
+
+		if err := checkVersion("transaction", transaction.ID(), version, transaction.Version()); err != nil {
+			return err
+		}
+
+		_, err = s.categoryRepository.FindByID(ctx, transaction.CategoryID())
+		if domainerror.IsKind(err, domainerror.KindNotFound) {
+			return domainerror.NewConflict("category_trashed", "the category of the transaction is in the trash, restore it first", nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		before := transaction.AuditFields()
+		transaction.Untrash(s.clock)
+		restoredTransaction, err = s.transactionRepository.Update(ctx, transaction)
+		if err != nil {
+			return err
+		}
+
+		return s.auditTrail.Record(ctx, userID, enum.AuditResourceTransaction, id, enum.AuditActionRestore, before, restoredTransaction.AuditFields())
+	})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction restored",
+		"transaction_id", id,
+		"user_id", userID,
+	)
+
+	return restoredTransaction, nil
+}
+
+// Batch applies the operations in order. An atomic batch is all or nothing: the first failing
+// operation rolls the whole batch back and is returned as the error, its fields named after its
+// position. Otherwise each operation succeeds or fails on its own, as reported in its result.
+// Creations are validated one by one and inserted together at the end; when that insert fails in
+// a best-effort batch they are retried one at a time.
+func (s *TransactionService) Batch(ctx context.Context, batchDTO *dto.BatchTransactionDTO) ([]dto.BatchResultDTO, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.Batch")
+	defer span.End()
+
+	var results []dto.BatchResultDTO
+	var created []createdTransaction
+	var err error
+	if batchDTO.Atomic {
+		err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			results, created, err = s.applyBatch(ctx, batchDTO)
+			return err
+		})
+	} else {
+		results, created, err = s.applyBatch(ctx, batchDTO)
+	}
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	for _, transaction := range created {
+		s.metrics.TransactionCreated(transaction.category.Type())
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "transaction batch applied",
+		"user_id", batchDTO.UserID,
+		"atomic", batchDTO.Atomic,
+		"operations", len(results),
+		"failed", failed,
+	)
+
+	return results, nil
+}
+
+// createdTransaction is a transaction of a batch waiting to be inserted, with its category
+type createdTransaction struct {
+	index       int
+	transaction *entity.Transaction
+	category    *entity.Category
+}
+
+// applyBatch runs the operations of a batch, each in its own unit of work, which becomes a
+// savepoint when the batch is atomic. It stops at the first failure of an atomic batch.
+func (s *TransactionService) applyBatch(ctx context.Context, batchDTO *dto.BatchTransactionDTO) ([]dto.BatchResultDTO, []createdTransaction, error) {
+	results := make([]dto.BatchResultDTO, len(batchDTO.Operations))
+	categories := make(map[uuid.UUID]*entity.Category)
+	var pending []createdTransaction
+
+	for i, operation := range batchDTO.Operations {
+		var err error
+		switch {
+		case operation.Err != nil:
+			err = operation.Err
+		case operation.Create != nil:
+			var created *createdTransaction
+			created, err = s.prepareCreate(ctx, operation.Create, categories)
+			if err == nil {
+				created.index = i
+				pending = append(pending, *created)
+			}
+		case operation.Update != nil:
+			err = s.unitOfWork.Do(ctx, func(ctx context.Context) (err error) {
+				results[i].Transaction, err = s.update(ctx, operation.Update)
+				return err
+			})
+		case operation.Delete != nil:
+			err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+				return s.trash(ctx, operation.Delete)
+			})
+		}
+
+		if err != nil {
+			if batchDTO.Atomic {
+				return nil, nil, withinBatch(i, err)
+			}
+			results[i].Err = err
+		}
+	}
+
+	if len(pending) == 0 {
+		return results, nil, nil
+	}
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		transactions := make([]*entity.Transaction, len(pending))
+		for i, created := range pending {
+			transactions[i] = created.transaction
+		}
+		if err := s.transactionRepository.CreateBatch(ctx, transactions); err != nil {
+			return err
+		}
+
+		for _, created := range pending {
+			if err := s.auditCreate(ctx, created.transaction); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if batchDTO.Atomic {
+			return nil, nil, err
+		}
+		return results, s.createOneByOne(ctx, pending, results), nil
+	}
+
+	for _, created := range pending {
+		results[created.index].Transaction = created.transaction
+	}
+	return results, pending, nil
+}
+
+// createOneByOne inserts the creations of a best-effort batch each in its own unit of work, once
+// inserting them together failed, so a row the database rejects fails alone. It returns the
+// creations that were inserted.
+func (s *TransactionService) createOneByOne(ctx context.Context, pending []createdTransaction, results []dto.BatchResultDTO) []createdTransaction {
+	var inserted []createdTransaction
+	for _, created := range pending {
+		err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+			if _, err := s.transactionRepository.Create(ctx, created.transaction); err != nil {
+				return err
+			}
+			return s.auditCreate(ctx, created.transaction)
+		})
+		if err != nil {
+			results[created.index].Err = err
+			continue
+		}
+		results[created.index].Transaction = created.transaction
+		inserted = append(inserted, created)
+	}
+	return inserted
+}
+
+// auditCreate records the creation of a transaction of a batch
+func (s *TransactionService) auditCreate(ctx context.Context, transaction *entity.Transaction) error {
+	return s.auditTrail.Record(ctx, transaction.UserID(), enum.AuditResourceTransaction, transaction.ID(), enum.AuditActionCreate, nil, transaction.AuditFields())
+}
+
+// prepareCreate builds a transaction to create and checks its category, looking categories up
+// once per batch
+func (s *TransactionService) prepareCreate(ctx context.Context, createTransactionDTO *dto.CreateTransactionDTO, categories map[uuid.UUID]*entity.Category) (*createdTransaction, error) {
+	transaction, err := entity.NewImportedTransaction(
+		s.clock,
+		s.ids,
 		createTransactionDTO.CategoryID,
 		createTransactionDTO.UserID,
 		createTransactionDTO.Amount,
 		createTransactionDTO.Datetime,
 		createTransactionDTO.Description,
-		time.Now(),
-		time.Now(),
+		createTransactionDTO.ExternalID,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	category, found := categories[transaction.CategoryID()]
+	if !found {
+		category, err = s.findCategory(ctx, transaction.UserID(), transaction.CategoryID())
+		if err != nil {
+			return nil, err
+		}
+		categories[category.ID()] = category
+	}
+
+	return &createdTransaction{transaction: transaction, category: category}, nil
+}
+
+// update changes a transaction of the user, in the unit of work bound to ctx
+func (s *TransactionService) update(ctx context.Context, updateTransactionDTO *dto.UpdateTransactionDTO) (*entity.Transaction, error) {
+	transaction, err := s.findTransaction(ctx, updateTransactionDTO.UserID, updateTransactionDTO.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion("transaction", transaction.ID(), updateTransactionDTO.Version, transaction.Version()); err != nil {
+		return nil, err
+	}
 
+	if _, err := s.findCategory(ctx, transaction.UserID(), updateTransactionDTO.CategoryID); err != nil {
+		return nil, err
+	}
+
+	before := transaction.AuditFields()
+	err = transaction.Update(
+		s.clock,
+		updateTransactionDTO.CategoryID,
+		updateTransactionDTO.Amount,
+		updateTransactionDTO.Datetime,
+		updateTransactionDTO.Description,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	createdTransaction, err := s.transactionRepository.Create(transaction)
+	updatedTransaction, err := s.transactionRepository.Update(ctx, transaction)
 	if err != nil {
 		return nil, err
 	}
 
-	return createdTransaction, nil
+	err = s.auditTrail.Record(ctx, updateTransactionDTO.UserID, enum.AuditResourceTransaction, transaction.ID(), enum.AuditActionUpdate, before, updatedTransaction.AuditFields())
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTransaction, nil
+}
+
+// mergeTransaction fills the fields missing from patch with those of current. Without a version
+// the change is based on that of current, the one the merge was built from.
+func mergeTransaction(current *entity.Transaction, patch *dto.PatchTransactionDTO) *dto.UpdateTransactionDTO {
+	updateTransactionDTO := &dto.UpdateTransactionDTO{
+		ID:          current.ID(),
+		UserID:      current.UserID(),
+		Version:     patch.Version,
+		CategoryID:  current.CategoryID(),
+		Amount:      current.Amount(),
+		Datetime:    current.Datetime(),
+		Description: current.Description(),
+	}
+	if updateTransactionDTO.Version == 0 {
+		updateTransactionDTO.Version = current.Version()
+	}
+	if patch.CategoryID != nil {
+		updateTransactionDTO.CategoryID = *patch.CategoryID
+	}
+	if patch.Amount != nil {
+		updateTransactionDTO.Amount = *patch.Amount
+	}
+	if patch.Datetime != nil {
+		updateTransactionDTO.Datetime = *patch.Datetime
+	}
+	if patch.Description != nil {
+		updateTransactionDTO.Description = *patch.Description
+	}
+	return updateTransactionDTO
+}
+
+// trash moves a transaction of the user to the trash, in the unit of work bound to ctx
+func (s *TransactionService) trash(ctx context.Context, deleteTransactionDTO *dto.DeleteTransactionDTO) error {
+	transaction, err := s.findTransaction(ctx, deleteTransactionDTO.UserID, deleteTransactionDTO.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion("transaction", transaction.ID(), deleteTransactionDTO.Version, transaction.Version()); err != nil {
+		return err
+	}
+
+	before := transaction.AuditFields()
+	transaction.Trash(s.clock)
+	trashedTransaction, err := s.transactionRepository.Update(ctx, transaction)
+	if err != nil {
+		return err
+	}
+
+	return s.auditTrail.Record(ctx, deleteTransactionDTO.UserID, enum.AuditResourceTransaction, transaction.ID(), enum.AuditActionDelete, before, trashedTransaction.AuditFields())
+}
+
+// withinBatch names the fields of the error of a batch operation after its position
+func withinBatch(index int, err error) error {
+	if domainErr, ok := domainerror.As(err); ok {
+		return domainErr.Within(fmt.Sprintf("operations[%d]", index))
+	}
+	return err
+}
+
+// History lists the changes made to a transaction of the user, including one in the trash
+func (s *TransactionService) History(ctx context.Context, userID, id uuid.UUID, page, pageSize int) ([]entity.AuditEntry, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "TransactionService.History")
+	defer span.End()
+
+	transaction, err := s.transactionRepository.FindByID(ctx, id)
+	if domainerror.IsKind(err, domainerror.KindNotFound) {
+		transaction, err = s.transactionRepository.FindTrashedByID(ctx, id)
+	}
+	if err == nil && transaction.UserID() != userID {
+		err = domainerror.NewNotFound("transaction", id)
+	}
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	entries, paginate, err := s.auditTrail.FindAllPaginated(ctx, userID, enum.AuditResourceTransaction, id, page, pageSize)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return entries, paginate, nil
+}
+
+// findTransaction loads a transaction of the user, reporting another user's transaction as
+// missing so ids cannot be probed
+func (s *TransactionService) findTransaction(ctx context.Context, userID, id uuid.UUID) (*entity.Transaction, error) {
+	transaction, err := s.transactionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.UserID() != userID {
+		return nil, domainerror.NewNotFound("transaction", id)
+	}
+	return transaction, nil
+}
+
+// findCategory loads the category of the user a transaction refers to, reporting a missing one
+// as a validation error of the transaction rather than a missing resource
+func (s *TransactionService) findCategory(ctx context.Context, userID, categoryID uuid.UUID) (*entity.Category, error) {
+	category, err := s.categoryRepository.FindByID(ctx, categoryID)
+	if err == nil && category.UserID() != userID {
+		err = domainerror.NewNotFound("category", categoryID)
+	}
+	if domainerror.IsKind(err, domainerror.KindNotFound) {
+		return nil, domainerror.NewValidation("categoryId", "category_not_found", "category does not exist")
+	}
+	return category, err
 }
@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/requestid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetrics struct {
+	created map[enum.CategoryType]int
+}
+
+func (m *fakeMetrics) TransactionCreated(categoryType enum.CategoryType) {
+	m.created[categoryType]++
+}
+
+type transactionServiceFixture struct {
+	service    *TransactionService
+	categories *memory.CategoryRepository
+	auditTrail interfaces.AuditTrailInterface
+	metrics    *fakeMetrics
+	clock      *clock.Fixed
+	userID     uuid.UUID
+}
+
+func newTransactionServiceFixture(t *testing.T, ids ...uuid.UUID) *transactionServiceFixture {
+	store := memory.NewStore()
+	fixture := &transactionServiceFixture{
+		categories: memory.NewCategoryRepository(store).(*memory.CategoryRepository),
+		metrics:    &fakeMetrics{created: make(map[enum.CategoryType]int)},
+		clock:      clock.NewFixed(time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)),
+		userID:     uuid.New(),
+	}
+	fixture.auditTrail = NewAuditTrailService(memory.NewAuditRepository(store), fixture.clock, identifier.NewV7())
+	fixture.service = NewTransactionService(
+		memory.NewUnitOfWork(store),
+		memory.NewTransactionRepository(store),
+		fixture.categories,
+		fixture.auditTrail,
+		fixture.metrics,
+		fixture.clock,
+		identifier.NewFixed(ids...),
+	).(*TransactionService)
+	return fixture
+}
+
+func (f *transactionServiceFixture) seedCategory(t *testing.T, categoryType enum.CategoryType) *entity.Category {
+	category, err := entity.NewCategory(f.clock, identifier.NewV7(), f.userID, "Food", categoryType, false, "")
+	assert.Nil(t, err)
+	assert.Nil(t, f.categories.Save(context.Background(), category))
+	return category
+}
+
+func TestTransactionService_Create(t *testing.T) {
+	t.Run("should create the transaction with a generated id and the current time", func(t *testing.T) {
+		id := uuid.MustParse("01959a2b-7c00-7000-8000-0000000000aa")
+		fixture := newTransactionServiceFixture(t, id)
+		category := fixture.seedCategory(t, enum.CategoryTypeExpense)
+
+		transaction, err := fixture.service.Create(context.Background(), &dto.CreateTransactionDTO{
+			UserID:      fixture.userID,
+			CategoryID:  category.ID(),
+			Amount:      35.9,
+			Datetime:    fixture.clock.Now().Add(-time.Hour),
+			Description: "Lunch",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, id, transaction.ID())
+		assert.Equal(t, fixture.clock.Now(), transaction.CreatedAt())
+		assert.Equal(t, 1, fixture.metrics.created[enum.CategoryTypeExpense])
+
+		transactions, paginate, err := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), paginate.TotalItems)
+		assert.Equal(t, id, transactions[0].ID())
+	})
+
+	t.Run("should reject a category that does not exist", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+
+		transaction, err := fixture.service.Create(context.Background(), &dto.CreateTransactionDTO{
+			UserID:     fixture.userID,
+			CategoryID: uuid.New(),
+			Amount:     10,
+			Datetime:   fixture.clock.Now(),
+		})
+
+		assert.Nil(t, transaction)
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, domainerror.KindValidation, domainErr.Kind)
+		assert.Equal(t, "category_not_found", domainErr.Code)
+		assert.Empty(t, fixture.metrics.created)
+	})
+}
+
+func (f *transactionServiceFixture) seedTransaction(t *testing.T) *entity.Transaction {
+	category := f.seedCategory(t, enum.CategoryTypeExpense)
+	transaction, err := f.service.Create(context.Background(), &dto.CreateTransactionDTO{
+		UserID:      f.userID,
+		CategoryID:  category.ID(),
+		Amount:      50,
+		Datetime:    f.clock.Now(),
+		Description: "Rent",
+	})
+	assert.Nil(t, err)
+	return transaction
+}
+
+func TestTransactionService_Update(t *testing.T) {
+	update := func(transaction *entity.Transaction, version int64) *dto.UpdateTransactionDTO {
+		return &dto.UpdateTransactionDTO{
+			ID:          transaction.ID(),
+			UserID:      transaction.UserID(),
+			Version:     version,
+			CategoryID:  transaction.CategoryID(),
+			Amount:      75,
+			Datetime:    transaction.Datetime(),
+			Description: "Rent and fees",
+		}
+	}
+
+	t.Run("should store the change and bump the version", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		updated, err := fixture.service.Update(context.Background(), update(transaction, transaction.Version()))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 75.0, updated.Amount())
+		assert.Equal(t, transaction.Version()+1, updated.Version())
+
+		found, _ := fixture.service.FindByID(context.Background(), fixture.userID, transaction.ID())
+		assert.Equal(t, updated.Version(), found.Version())
+	})
+
+	t.Run("should refuse a change based on an outdated version", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		_, err := fixture.service.Update(context.Background(), update(transaction, transaction.Version()))
+		assert.Nil(t, err)
+
+		updated, err := fixture.service.Update(context.Background(), update(transaction, transaction.Version()))
+
+		assert.Nil(t, updated)
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, domainerror.KindPreconditionFailed, domainErr.Kind)
+		assert.Equal(t, "version_mismatch", domainErr.Code)
+	})
+
+	t.Run("should overwrite any version when none is given", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		_, err := fixture.service.Update(context.Background(), update(transaction, 0))
+		assert.Nil(t, err)
+
+		updated, err := fixture.service.Update(context.Background(), update(transaction, 0))
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), updated.Version())
+	})
+
+	t.Run("should hide transactions of other users", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		changes := update(transaction, transaction.Version())
+		changes.UserID = uuid.New()
+
+		_, err := fixture.service.Update(context.Background(), changes)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+}
+
+func TestTransactionService_Patch(t *testing.T) {
+	t.Run("should keep the fields the patch leaves out", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		amount := 80.0
+
+		patched, err := fixture.service.Patch(context.Background(), &dto.PatchTransactionDTO{
+			ID: transaction.ID(), UserID: fixture.userID, Amount: &amount,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 80.0, patched.Amount())
+		assert.Equal(t, "Rent", patched.Description())
+		assert.Equal(t, transaction.CategoryID(), patched.CategoryID())
+		assert.Equal(t, transaction.Version()+1, patched.Version())
+	})
+
+	t.Run("should refuse a patch based on an outdated version", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		description := "Rent and fees"
+		_, err := fixture.service.Patch(context.Background(), &dto.PatchTransactionDTO{
+			ID: transaction.ID(), UserID: fixture.userID, Description: &description,
+		})
+		assert.Nil(t, err)
+
+		_, err = fixture.service.Patch(context.Background(), &dto.PatchTransactionDTO{
+			ID: transaction.ID(), UserID: fixture.userID, Version: transaction.Version(), Description: &description,
+		})
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionFailed))
+	})
+}
+
+func TestTransactionService_Delete(t *testing.T) {
+	t.Run("should delete the transaction at the expected version", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		err := fixture.service.Delete(context.Background(), fixture.userID, transaction.ID(), transaction.Version())
+
+		assert.Nil(t, err)
+		_, err = fixture.service.FindByID(context.Background(), fixture.userID, transaction.ID())
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+
+	t.Run("should keep the transaction when the version is outdated", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		err := fixture.service.Delete(context.Background(), fixture.userID, transaction.ID(), transaction.Version()+1)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionFailed))
+		_, err = fixture.service.FindByID(context.Background(), fixture.userID, transaction.ID())
+		assert.Nil(t, err)
+	})
+}
+
+func TestTransactionService_Restore(t *testing.T) {
+	t.Run("should bring a trashed transaction back", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, transaction.ID(), 0))
+
+		restored, err := fixture.service.Restore(context.Background(), fixture.userID, transaction.ID(), 0)
+
+		assert.Nil(t, err)
+		assert.False(t, restored.Trashed())
+		_, err = fixture.service.FindByID(context.Background(), fixture.userID, transaction.ID())
+		assert.Nil(t, err)
+	})
+
+	t.Run("should refuse while the category is in the trash", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, transaction.ID(), 0))
+		category, _ := fixture.categories.FindByID(context.Background(), transaction.CategoryID())
+		category.Trash(fixture.clock)
+		_, err := fixture.categories.Update(context.Background(), category)
+		assert.Nil(t, err)
+
+		_, err = fixture.service.Restore(context.Background(), fixture.userID, transaction.ID(), 0)
+
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, domainerror.KindConflict, domainErr.Kind)
+		assert.Equal(t, "category_trashed", domainErr.Code)
+	})
+
+	t.Run("should not restore a transaction that is not in the trash", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		_, err := fixture.service.Restore(context.Background(), fixture.userID, transaction.ID(), 0)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+}
+
+func TestTransactionService_History(t *testing.T) {
+	t.Run("should list every change with its actor and request, oldest first", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		ctx := requestid.WithRequestID(context.Background(), "req-42")
+		transaction := fixture.seedTransaction(t)
+		_, err := fixture.service.Update(ctx, &dto.UpdateTransactionDTO{
+			ID: transaction.ID(), UserID: fixture.userID, CategoryID: transaction.CategoryID(), Amount: 75, Datetime: transaction.Datetime(), Description: "Rent",
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, fixture.service.Delete(ctx, fixture.userID, transaction.ID(), 0))
+		_, err = fixture.service.Restore(ctx, fixture.userID, transaction.ID(), 0)
+		assert.Nil(t, err)
+
+		entries, paginate, err := fixture.service.History(context.Background(), fixture.userID, transaction.ID(), 1, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), paginate.TotalItems)
+		actions := make([]enum.AuditAction, len(entries))
+		for i, entry := range entries {
+			actions[i] = entry.Action()
+			assert.Equal(t, fixture.userID, entry.UserID())
+		}
+		assert.Equal(t, []enum.AuditAction{enum.AuditActionCreate, enum.AuditActionUpdate, enum.AuditActionDelete, enum.AuditActionRestore}, actions)
+		assert.Equal(t, "req-42", entries[1].RequestID())
+		assert.Equal(t, []entity.AuditChange{{Field: "amount", Before: 50.0, After: 75.0}}, entries[1].Changes())
+	})
+
+	t.Run("should not record a change that failed", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		err := fixture.service.Delete(context.Background(), fixture.userID, transaction.ID(), transaction.Version()+1)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionFailed))
+
+		entries, _, err := fixture.service.History(context.Background(), fixture.userID, transaction.ID(), 1, 10)
+		assert.Nil(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("should hide the history of other users' transactions", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New())
+		transaction := fixture.seedTransaction(t)
+
+		_, _, err := fixture.service.History(context.Background(), uuid.New(), transaction.ID(), 1, 10)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+}
+
+func TestTransactionService_Batch(t *testing.T) {
+	create := func(category *entity.Category, amount float64) dto.BatchOperationDTO {
+		return dto.BatchOperationDTO{Create: &dto.CreateTransactionDTO{
+			UserID:     category.UserID(),
+			CategoryID: category.ID(),
+			Amount:     amount,
+			Datetime:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		}}
+	}
+
+	t.Run("should apply every operation of an atomic batch", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		existing := fixture.seedTransaction(t)
+		category, _ := fixture.categories.FindByID(context.Background(), existing.CategoryID())
+		other := fixture.seedTransaction(t)
+		seeded := fixture.metrics.created[enum.CategoryTypeExpense]
+
+		results, err := fixture.service.Batch(context.Background(), &dto.BatchTransactionDTO{
+			UserID: fixture.userID,
+			Atomic: true,
+			Operations: []dto.BatchOperationDTO{
+				create(category, 10),
+				{Update: &dto.UpdateTransactionDTO{ID: existing.ID(), UserID: fixture.userID, Version: 1, CategoryID: category.ID(), Amount: 80, Datetime: existing.Datetime()}},
+				{Delete: &dto.DeleteTransactionDTO{ID: other.ID(), UserID: fixture.userID, Version: 1}},
+				create(category, 20),
+			},
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, results, 4)
+		assert.Equal(t, 10.0, results[0].Transaction.Amount())
+		assert.Equal(t, 80.0, results[1].Transaction.Amount())
+		assert.Nil(t, results[2].Transaction)
+		assert.Equal(t, 20.0, results[3].Transaction.Amount())
+		assert.Equal(t, seeded+2, fixture.metrics.created[enum.CategoryTypeExpense])
+
+		transactions, _, _ := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Len(t, transactions, 3)
+	})
+
+	t.Run("should roll an atomic batch back at its first failure", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New(), uuid.New(), uuid.New())
+		existing := fixture.seedTransaction(t)
+		category, _ := fixture.categories.FindByID(context.Background(), existing.CategoryID())
+
+		results, err := fixture.service.Batch(context.Background(), &dto.BatchTransactionDTO{
+			UserID: fixture.userID,
+			Atomic: true,
+			Operations: []dto.BatchOperationDTO{
+				{Delete: &dto.DeleteTransactionDTO{ID: existing.ID(), UserID: fixture.userID}},
+				create(category, 10),
+				create(category, -5),
+			},
+		})
+
+		assert.Nil(t, results)
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, "amount_must_be_positive", domainErr.Code)
+		assert.Equal(t, "operations[2].amount", domainErr.Field)
+
+		transactions, _, _ := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Len(t, transactions, 1)
+	})
+
+	t.Run("should report each failure of a best-effort batch on its own", func(t *testing.T) {
+		fixture := newTransactionServiceFixture(t, uuid.New(), uuid.New(), uuid.New())
+		existing := fixture.seedTransaction(t)
+		category, _ := fixture.categories.FindByID(context.Background(), existing.CategoryID())
+		unreadable := domainerror.NewInvalidInput("op", "unknown_operation", "op must be create, update or delete")
+
+		results, err := fixture.service.Batch(context.Background(), &dto.BatchTransactionDTO{
+			UserID: fixture.userID,
+			Operations: []dto.BatchOperationDTO{
+				create(category, 10),
+				{Delete: &dto.DeleteTransactionDTO{ID: existing.ID(), UserID: fixture.userID, Version: 7}},
+				{Err: unreadable},
+				{Delete: &dto.DeleteTransactionDTO{ID: uuid.New(), UserID: fixture.userID}},
+			},
+		})
+
+		assert.Nil(t, err)
+		assert.Nil(t, results[0].Err)
+		assert.True(t, domainerror.IsKind(results[1].Err, domainerror.KindPreconditionFailed))
+		assert.Equal(t, unreadable, results[2].Err)
+		assert.True(t, domainerror.IsKind(results[3].Err, domainerror.KindNotFound))
+
+		transactions, _, _ := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Len(t, transactions, 2)
+	})
+
+	t.Run("should insert the other creations of a best-effort batch when one row is rejected", func(t *testing.T) {
+		taken := uuid.New()
+		fixture := newTransactionServiceFixture(t, taken, uuid.New(), taken, uuid.New())
+		existing := fixture.seedTransaction(t)
+		category, _ := fixture.categories.FindByID(context.Background(), existing.CategoryID())
+
+		results, err := fixture.service.Batch(context.Background(), &dto.BatchTransactionDTO{
+			UserID:     fixture.userID,
+			Operations: []dto.BatchOperationDTO{create(category, 10), create(category, 20), create(category, 30)},
+		})
+
+		assert.Nil(t, err)
+		assert.Nil(t, results[0].Err)
+		assert.True(t, domainerror.IsKind(results[1].Err, domainerror.KindConflict))
+		assert.Nil(t, results[2].Err)
+		assert.Equal(t, 3, fixture.metrics.created[enum.CategoryTypeExpense])
+
+		transactions, _, _ := fixture.service.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+		assert.Len(t, transactions, 3)
+	})
+}
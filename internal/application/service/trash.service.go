@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type TrashService struct {
+	unitOfWork            interfaces.UnitOfWorkInterface
+	trashRepository       repository.TrashRepositoryInterface
+	transactionRepository repository.TransactionRepositoryInterface
+	categoryRepository    repository.CategoryRepositoryInterface
+	clock                 clock.Clock
+	retention             time.Duration
+}
+
+// NewTrashService creates the trash bin service. Items are purged once they have been in the
+// trash for longer than retention.
+func NewTrashService(
+	unitOfWork interfaces.UnitOfWorkInterface,
+	trashRepository repository.TrashRepositoryInterface,
+	transactionRepository repository.TransactionRepositoryInterface,
+	categoryRepository repository.CategoryRepositoryInterface,
+	clock clock.Clock,
+	retention time.Duration,
+) interfaces.TrashServiceInterface {
+	return &TrashService{
+		unitOfWork:            unitOfWork,
+		trashRepository:       trashRepository,
+		transactionRepository: transactionRepository,
+		categoryRepository:    categoryRepository,
+		clock:                 clock,
+		retention:             retention,
+	}
+}
+
+func (s *TrashService) FindAllPaginated(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]entity.TrashItem, *pagination.Pagination, error) {
+	ctx, span := tracer.Start(ctx, "TrashService.FindAllPaginated")
+	defer span.End()
+
+	paginate := pagination.NewPagination(page, pageSize)
+
+	items, err := s.trashRepository.FindAllPaginated(ctx, userID, paginate)
+	if err != nil {
+		return nil, nil, recordError(span, err)
+	}
+
+	return items, paginate, nil
+}
+
+func (s *TrashService) PurgeAt(item *entity.TrashItem) time.Time {
+	return item.DeletedAt().Add(s.retention)
+}
+
+// Purge removes the transactions before the categories, so no transaction is ever left pointing
+// at a category that was already removed
+func (s *TrashService) Purge(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "TrashService.Purge")
+	defer span.End()
+
+	before := s.clock.Now().Add(-s.retention)
+
+	var purged int64
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		transactions, err := s.transactionRepository.PurgeTrashed(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		categories, err := s.categoryRepository.PurgeTrashed(ctx, before)
+		purged = transactions + categories
+		return err
+	})
+	if err != nil {
+		return 0, recordError(span, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashService(t *testing.T) {
+	retention := 30 * 24 * time.Hour
+
+	setup := func() (*TrashService, *categoryServiceFixture) {
+		fixture := newCategoryServiceFixture()
+		trash := NewTrashService(
+			memory.NewUnitOfWork(fixture.store),
+			memory.NewTrashRepository(fixture.store),
+			fixture.transactions,
+			memory.NewCategoryRepository(fixture.store),
+			fixture.clock,
+			retention,
+		).(*TrashService)
+		return trash, fixture
+	}
+
+	t.Run("should list the trash of the user, most recently deleted first", func(t *testing.T) {
+		trash, fixture := setup()
+		category := fixture.create(t, "Food")
+		transaction := fixture.seedTransaction(t, category)
+		transaction.Trash(fixture.clock)
+		_, err := fixture.transactions.Update(context.Background(), transaction)
+		assert.Nil(t, err)
+		fixture.clock.Advance(time.Minute)
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, category.ID(), 0))
+
+		items, paginate, err := trash.FindAllPaginated(context.Background(), fixture.userID, 1, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), paginate.TotalItems)
+		assert.Equal(t, enum.TrashItemKindCategory, items[0].Kind())
+		assert.Equal(t, "Food", items[0].Name())
+		assert.Equal(t, enum.TrashItemKindTransaction, items[1].Kind())
+		assert.Equal(t, items[1].DeletedAt().Add(retention), trash.PurgeAt(&items[1]))
+
+		items, _, err = trash.FindAllPaginated(context.Background(), uuid.New(), 1, 10)
+		assert.Nil(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("should purge only what outlived the retention", func(t *testing.T) {
+		trash, fixture := setup()
+		old := fixture.create(t, "Food")
+		oldTransaction := fixture.seedTransaction(t, old)
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, old.ID(), 0))
+		fixture.clock.Advance(retention)
+		recent := fixture.create(t, "Transport")
+		assert.Nil(t, fixture.service.Delete(context.Background(), fixture.userID, recent.ID(), 0))
+		fixture.clock.Advance(time.Minute)
+
+		purged, err := trash.Purge(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), purged)
+		_, err = fixture.transactions.FindTrashedByID(context.Background(), oldTransaction.ID())
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+		_, err = fixture.service.Restore(context.Background(), fixture.userID, old.ID(), 0)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+		_, err = fixture.service.Restore(context.Background(), fixture.userID, recent.ID(), 0)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time, so domain logic never reads the wall clock directly
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// System returns a Clock backed by time.Now
+func System() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a Clock frozen at a given instant, used by tests and fixtures
+type Fixed struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFixed creates a Clock that always returns now until it is moved
+func NewFixed(now time.Time) *Fixed {
+	return &Fixed{now: now}
+}
+
+func (c *Fixed) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now
+func (c *Fixed) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *Fixed) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,143 @@
+package domainerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an error so outer layers can react to it without knowing where it came from
+type Kind string
+
+const (
+	KindInvalidInput Kind = "invalid_input" // The input could not be understood at all
+	KindValidation   Kind = "validation"    // The input was understood but breaks a business rule
+	KindNotFound     Kind = "not_found"     // The requested resource does not exist
+	KindConflict     Kind = "conflict"      // The change clashes with the current state
+	KindForbidden    Kind = "forbidden"     // The caller may not perform the operation
+
+	KindUnauthenticated Kind = "unauthenticated" // The caller could not be identified
+	KindTooLarge        Kind = "too_large"       // The request exceeds a size limit
+	KindRateLimited     Kind = "rate_limited"    // The caller sent too many requests
+
+	KindPreconditionFailed   Kind = "precondition_failed"   // The resource changed since the caller read it
+	KindPreconditionRequired Kind = "precondition_required" // The caller must say which version it read
+)
+
+// Error is the error type returned by the domain and application layers
+type Error struct {
+	Kind    Kind              // Category of the error
+	Code    string            // Stable, machine readable code (e.g., "amount_must_be_positive")
+	Field   string            // Offending field, when the error concerns a single field
+	Message string            // Human readable description, in English
+	Params  map[string]string // Values interpolated into localized messages (e.g., "field", "param")
+	Details []*Error          // Field-level errors that make up this one
+	Err     error             // Underlying cause, never shown to clients
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewInvalidInput creates an error for input that could not be parsed
+func NewInvalidInput(field, code, message string) *Error {
+	return &Error{Kind: KindInvalidInput, Code: code, Field: field, Message: message}
+}
+
+// WithParams sets the values interpolated into localized messages
+func (e *Error) WithParams(params map[string]string) *Error {
+	e.Params = params
+	return e
+}
+
+// WithDetails attaches field-level errors
+func (e *Error) WithDetails(details ...*Error) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// NewValidation creates an error for a field that breaks a business rule
+func NewValidation(field, code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Field: field, Message: message}
+}
+
+// NewNotFound creates an error for a missing resource
+func NewNotFound(resource string, id any) *Error {
+	return &Error{
+		Kind:    KindNotFound,
+		Code:    resource + "_not_found",
+		Message: fmt.Sprintf("%s %v not found", resource, id),
+		Params:  map[string]string{"resource": resource, "id": fmt.Sprint(id)},
+	}
+}
+
+// NewConflict creates an error for a change that clashes with the stored state
+func NewConflict(code, message string, err error) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message, Err: err}
+}
+
+// NewForbidden creates an error for an operation the caller is not allowed to perform
+func NewForbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// NewUnauthenticated creates an error for a caller that could not be identified
+func NewUnauthenticated(code, message string) *Error {
+	return &Error{Kind: KindUnauthenticated, Code: code, Message: message}
+}
+
+// NewTooLarge creates an error for a request that exceeds a size limit
+func NewTooLarge(code, message string) *Error {
+	return &Error{Kind: KindTooLarge, Code: code, Message: message}
+}
+
+// NewRateLimited creates an error for a caller that exhausted its request quota
+func NewRateLimited(code, message string) *Error {
+	return &Error{Kind: KindRateLimited, Code: code, Message: message}
+}
+
+// NewPreconditionFailed creates an error for a change based on an outdated version of a resource
+func NewPreconditionFailed(code, message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Message: message}
+}
+
+// NewPreconditionRequired creates an error for a change that does not say which version it is based on
+func NewPreconditionRequired(code, message string) *Error {
+	return &Error{Kind: KindPreconditionRequired, Code: code, Message: message}
+}
+
+// Within returns a copy of e whose fields are named relative to path, as for an item of a list:
+// within "operations[2]", "amount" becomes "operations[2].amount". An error that names no field
+// is attributed to path itself.
+func (e *Error) Within(path string) *Error {
+	within := *e
+	within.Field = path
+	if e.Field != "" {
+		within.Field = path + "." + e.Field
+	}
+
+	within.Details = make([]*Error, len(e.Details))
+	for i, detail := range e.Details {
+		within.Details[i] = detail.Within(path)
+	}
+
+	return &within
+}
+
+// As returns the domain error in err's chain, if any
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// IsKind reports whether err's chain holds a domain error of the given kind
+func IsKind(err error, kind Kind) bool {
+	domainErr, ok := As(err)
+	return ok && domainErr.Kind == kind
+}
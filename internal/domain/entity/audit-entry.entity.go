@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+)
+
+// AuditFields is the audited state of a record, keyed by field name. Values are strings,
+// numbers, booleans or nil, so they compare with == and survive a JSON round trip unchanged.
+type AuditFields map[string]any
+
+// AuditChange is the value of one field before and after a change; nil means the field had
+// no value, as on creation
+type AuditChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// AuditEntry records one change made by a user to one record. Entries are only ever appended.
+type AuditEntry struct {
+	id         uuid.UUID
+	userID     uuid.UUID
+	resource   enum.AuditResource
+	resourceID uuid.UUID
+	action     enum.AuditAction
+	requestID  string
+	changes    []AuditChange
+	occurredAt time.Time
+}
+
+func (e *AuditEntry) ID() uuid.UUID                { return e.id }
+func (e *AuditEntry) UserID() uuid.UUID            { return e.userID }
+func (e *AuditEntry) Resource() enum.AuditResource { return e.resource }
+func (e *AuditEntry) ResourceID() uuid.UUID        { return e.resourceID }
+func (e *AuditEntry) Action() enum.AuditAction     { return e.action }
+func (e *AuditEntry) RequestID() string            { return e.requestID }
+func (e *AuditEntry) Changes() []AuditChange       { return e.changes }
+func (e *AuditEntry) OccurredAt() time.Time        { return e.occurredAt }
+
+// NewAuditEntry records that userID changed a record from before to after, keeping only the
+// fields whose value changed
+func NewAuditEntry(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, action enum.AuditAction, requestID string, before, after AuditFields) *AuditEntry {
+	return RestoreAuditEntry(ids.NewID(), userID, resource, resourceID, action, requestID, diffAuditFields(before, after), clock.Now())
+}
+
+// RestoreAuditEntry rebuilds an entry that already exists
+func RestoreAuditEntry(id uuid.UUID, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, action enum.AuditAction, requestID string, changes []AuditChange, occurredAt time.Time) *AuditEntry {
+	return &AuditEntry{
+		id:         id,
+		userID:     userID,
+		resource:   resource,
+		resourceID: resourceID,
+		action:     action,
+		requestID:  requestID,
+		changes:    changes,
+		occurredAt: occurredAt,
+	}
+}
+
+// TrashAuditFields is the audited state of a record changed only by moving it in or out of
+// the trash
+func TrashAuditFields(deletedAt time.Time) AuditFields {
+	return AuditFields{"deletedAt": auditTime(deletedAt)}
+}
+
+// diffAuditFields lists, by field name, the fields whose value differs between before and after
+func diffAuditFields(before, after AuditFields) []AuditChange {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := make([]AuditChange, 0, len(fields))
+	for field := range fields {
+		if before[field] != after[field] {
+			changes = append(changes, AuditChange{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes
+}
+
+// auditTime renders t as an audited value, nil when unset
+func auditTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	fixedClock := clock.NewFixed(now)
+
+	t.Run("should keep only the fields that changed, by name", func(t *testing.T) {
+		id := uuid.New()
+		userID := uuid.New()
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), uuid.New(), userID, 50, now, "Rent")
+		assert.Nil(t, err)
+		before := transaction.AuditFields()
+		assert.Nil(t, transaction.Update(fixedClock, transaction.CategoryID(), 75, now, "Rent and fees"))
+
+		entry := NewAuditEntry(fixedClock, identifier.NewFixed(id), userID, enum.AuditResourceTransaction, transaction.ID(), enum.AuditActionUpdate, "req-1", before, transaction.AuditFields())
+
+		assert.Equal(t, id, entry.ID())
+		assert.Equal(t, userID, entry.UserID())
+		assert.Equal(t, transaction.ID(), entry.ResourceID())
+		assert.Equal(t, "req-1", entry.RequestID())
+		assert.Equal(t, now, entry.OccurredAt())
+		assert.Equal(t, []AuditChange{
+			{Field: "amount", Before: 50.0, After: 75.0},
+			{Field: "description", Before: "Rent", After: "Rent and fees"},
+		}, entry.Changes())
+	})
+
+	t.Run("should list every field of a created record", func(t *testing.T) {
+		category, err := NewCategory(fixedClock, identifier.NewV7(), uuid.New(), "Food", enum.CategoryTypeExpense, false, "")
+		assert.Nil(t, err)
+
+		entry := NewAuditEntry(fixedClock, identifier.NewV7(), category.UserID(), enum.AuditResourceCategory, category.ID(), enum.AuditActionCreate, "", nil, category.AuditFields())
+
+		fields := make([]string, len(entry.Changes()))
+		for i, change := range entry.Changes() {
+			assert.Nil(t, change.Before)
+			fields[i] = change.Field
+		}
+		// deletedAt is unset on both sides, so it is not a change
+		assert.Equal(t, []string{"default", "icon", "name", "type"}, fields)
+	})
+
+	t.Run("should record trashing as a change of deletedAt", func(t *testing.T) {
+		entry := NewAuditEntry(fixedClock, identifier.NewV7(), uuid.New(), enum.AuditResourceTransaction, uuid.New(), enum.AuditActionDelete, "", TrashAuditFields(time.Time{}), TrashAuditFields(now))
+
+		assert.Equal(t, []AuditChange{{Field: "deletedAt", Before: nil, After: "2025-03-10T14:30:00Z"}}, entry.Changes())
+	})
+}
@@ -1,10 +1,12 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 )
 
@@ -15,8 +17,10 @@ type Category struct {
 	typeCategory    enum.CategoryType
 	defaultCategory bool
 	icon            string
+	version         int64
 	createdAt       time.Time
 	updatedAt       time.Time
+	deletedAt       time.Time
 }
 
 func (c *Category) ID() uuid.UUID           { return c.id }
@@ -25,19 +29,24 @@ func (c *Category) Name() string            { return c.name }
 func (c *Category) Type() enum.CategoryType { return c.typeCategory }
 func (c *Category) Default() bool           { return c.defaultCategory }
 func (c *Category) Icon() string            { return c.icon }
+func (c *Category) Version() int64          { return c.version }
 func (c *Category) CreatedAt() time.Time    { return c.createdAt }
 func (c *Category) UpdatedAt() time.Time    { return c.updatedAt }
+func (c *Category) DeletedAt() time.Time    { return c.deletedAt }
+func (c *Category) Trashed() bool           { return !c.deletedAt.IsZero() }
 
-func NewCategory(userID uuid.UUID, name string, typeCategory enum.CategoryType, defaultCategory bool, icon string) (*Category, error) {
+func NewCategory(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, name string, typeCategory enum.CategoryType, defaultCategory bool, icon string) (*Category, error) {
+	now := clock.Now()
 	category := &Category{
-		id:              uuid.New(),
+		id:              ids.NewID(),
 		userID:          userID,
 		name:            name,
 		typeCategory:    typeCategory,
 		defaultCategory: defaultCategory,
 		icon:            icon,
-		createdAt:       time.Now(),
-		updatedAt:       time.Now(),
+		version:         1,
+		createdAt:       now,
+		updatedAt:       now,
 	}
 
 	err := category.validate()
@@ -48,17 +57,82 @@ func NewCategory(userID uuid.UUID, name string, typeCategory enum.CategoryType,
 	return category, nil
 }
 
+// RestoreCategory rebuilds a category that already exists, keeping its identity, version and
+// timestamps. A zero deletedAt means the category is not in the trash.
+func RestoreCategory(id uuid.UUID, userID uuid.UUID, name string, typeCategory enum.CategoryType, defaultCategory bool, icon string, version int64, createdAt time.Time, updatedAt time.Time, deletedAt time.Time) (*Category, error) {
+	category := &Category{
+		id:              id,
+		userID:          userID,
+		name:            name,
+		typeCategory:    typeCategory,
+		defaultCategory: defaultCategory,
+		icon:            icon,
+		version:         version,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+		deletedAt:       deletedAt,
+	}
+
+	err := category.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// Update replaces the editable fields, leaving the category untouched when the result is invalid.
+// The version is not changed here; repositories bump it when the update is stored.
+func (c *Category) Update(clock clock.Clock, name string, typeCategory enum.CategoryType, icon string) error {
+	updated := *c
+	updated.name = name
+	updated.typeCategory = typeCategory
+	updated.icon = icon
+	updated.updatedAt = clock.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+// Trash moves the category to the trash bin, from which it can be taken out until it is purged
+func (c *Category) Trash(clock clock.Clock) {
+	now := clock.Now()
+	c.deletedAt = now
+	c.updatedAt = now
+}
+
+// Untrash takes the category out of the trash bin
+func (c *Category) Untrash(clock clock.Clock) {
+	c.deletedAt = time.Time{}
+	c.updatedAt = clock.Now()
+}
+
+// AuditFields is the state of the category kept in its audit trail
+func (c *Category) AuditFields() AuditFields {
+	return AuditFields{
+		"name":      c.name,
+		"type":      string(c.typeCategory),
+		"default":   c.defaultCategory,
+		"icon":      c.icon,
+		"deletedAt": auditTime(c.deletedAt),
+	}
+}
+
 func (c *Category) validate() error {
 	if c.userID == uuid.Nil {
-		return errors.New("user id is required")
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
 	}
 
 	if c.name == "" {
-		return errors.New("name is required")
+		return domainerror.NewValidation("name", "name_required", "name is required")
 	}
 
 	if c.typeCategory != enum.CategoryTypeIncome && c.typeCategory != enum.CategoryTypeExpense {
-		return errors.New("invalid type")
+		return domainerror.NewValidation("type", "category_type_invalid", "invalid type")
 	}
 
 	return nil
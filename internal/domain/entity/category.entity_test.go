@@ -2,31 +2,39 @@ package entity
 
 import (
 	"testing"
+	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewCategory(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	fixedClock := clock.NewFixed(now)
+
 	t.Run("should create a new category successfully", func(t *testing.T) {
+		id := uuid.MustParse("01959a2b-7c00-7000-8000-000000000002")
 		userID := uuid.New()
-		category, err := NewCategory(userID, "Food", enum.CategoryTypeExpense, false, "food-icon")
+		category, err := NewCategory(fixedClock, identifier.NewFixed(id), userID, "Food", enum.CategoryTypeExpense, false, "food-icon")
 
 		assert.Nil(t, err)
 		assert.NotNil(t, category)
-		assert.NotEqual(t, uuid.Nil, category.ID())
+		assert.Equal(t, id, category.ID())
 		assert.Equal(t, userID, category.UserID())
 		assert.Equal(t, "Food", category.Name())
 		assert.Equal(t, enum.CategoryTypeExpense, category.Type())
 		assert.Equal(t, false, category.Default())
 		assert.Equal(t, "food-icon", category.Icon())
-		assert.False(t, category.CreatedAt().IsZero())
-		assert.False(t, category.UpdatedAt().IsZero())
+		assert.Equal(t, now, category.CreatedAt())
+		assert.Equal(t, now, category.UpdatedAt())
 	})
 
 	t.Run("should return error when user id is not provided", func(t *testing.T) {
-		category, err := NewCategory(uuid.Nil, "Food", enum.CategoryTypeExpense, false, "food-icon")
+		category, err := NewCategory(fixedClock, identifier.NewV7(), uuid.Nil, "Food", enum.CategoryTypeExpense, false, "food-icon")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, category)
@@ -35,7 +43,7 @@ func TestNewCategory(t *testing.T) {
 
 	t.Run("should return error when name is not provided", func(t *testing.T) {
 		userID := uuid.New()
-		category, err := NewCategory(userID, "", enum.CategoryTypeExpense, false, "food-icon")
+		category, err := NewCategory(fixedClock, identifier.NewV7(), userID, "", enum.CategoryTypeExpense, false, "food-icon")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, category)
@@ -44,7 +52,7 @@ func TestNewCategory(t *testing.T) {
 
 	t.Run("should return error when type is invalid", func(t *testing.T) {
 		userID := uuid.New()
-		category, err := NewCategory(userID, "Food", "invalid", false, "food-icon")
+		category, err := NewCategory(fixedClock, identifier.NewV7(), userID, "Food", "invalid", false, "food-icon")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, category)
@@ -53,7 +61,7 @@ func TestNewCategory(t *testing.T) {
 
 	t.Run("should create category with default values", func(t *testing.T) {
 		userID := uuid.New()
-		category, err := NewCategory(userID, "Salary", enum.CategoryTypeIncome, true, "salary-icon")
+		category, err := NewCategory(fixedClock, identifier.NewV7(), userID, "Salary", enum.CategoryTypeIncome, true, "salary-icon")
 
 		assert.Nil(t, err)
 		assert.NotNil(t, category)
@@ -61,3 +69,49 @@ func TestNewCategory(t *testing.T) {
 		assert.Equal(t, enum.CategoryTypeIncome, category.Type())
 	})
 }
+
+func TestCategoryUpdate(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	t.Run("should replace the editable fields", func(t *testing.T) {
+		category, _ := NewCategory(clock.NewFixed(createdAt), identifier.NewV7(), uuid.New(), "Food", enum.CategoryTypeExpense, false, "food")
+
+		err := category.Update(clock.NewFixed(updatedAt), "Groceries", enum.CategoryTypeExpense, "cart")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Groceries", category.Name())
+		assert.Equal(t, "cart", category.Icon())
+		assert.Equal(t, updatedAt, category.UpdatedAt())
+		assert.Equal(t, int64(1), category.Version())
+	})
+
+	t.Run("should leave the category untouched when the update is invalid", func(t *testing.T) {
+		category, _ := NewCategory(clock.NewFixed(createdAt), identifier.NewV7(), uuid.New(), "Food", enum.CategoryTypeExpense, false, "food")
+
+		err := category.Update(clock.NewFixed(updatedAt), "", enum.CategoryTypeExpense, "cart")
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+		assert.Equal(t, "Food", category.Name())
+		assert.Equal(t, createdAt, category.UpdatedAt())
+	})
+}
+
+func TestCategoryTrash(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	trashedAt := createdAt.Add(time.Hour)
+
+	t.Run("should move the category in and out of the trash", func(t *testing.T) {
+		category, _ := NewCategory(clock.NewFixed(createdAt), identifier.NewV7(), uuid.New(), "Food", enum.CategoryTypeExpense, false, "food")
+
+		category.Trash(clock.NewFixed(trashedAt))
+
+		assert.True(t, category.Trashed())
+		assert.Equal(t, trashedAt, category.DeletedAt())
+
+		category.Untrash(clock.NewFixed(trashedAt.Add(time.Hour)))
+
+		assert.False(t, category.Trashed())
+		assert.Equal(t, trashedAt.Add(time.Hour), category.UpdatedAt())
+	})
+}
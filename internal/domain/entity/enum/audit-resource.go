@@ -0,0 +1,10 @@
+package enum
+
+// AuditResource is the kind of record an audit entry is about. Users are not audited: they are
+// provisioned by the identity provider in front of the API and no code path here writes them.
+type AuditResource string
+
+const (
+	AuditResourceTransaction AuditResource = "transaction"
+	AuditResourceCategory    AuditResource = "category"
+)
@@ -0,0 +1,10 @@
+package enum
+
+type ImportRowStatus string
+
+const (
+	ImportRowStatusReady     ImportRowStatus = "ready"
+	ImportRowStatusInvalid   ImportRowStatus = "invalid"
+	ImportRowStatusDuplicate ImportRowStatus = "duplicate"
+	ImportRowStatusImported  ImportRowStatus = "imported"
+)
@@ -0,0 +1,10 @@
+package enum
+
+// InstallmentRemainder is the installment that absorbs the cents left over when a total does not
+// split evenly
+type InstallmentRemainder string
+
+const (
+	InstallmentRemainderFirst InstallmentRemainder = "first"
+	InstallmentRemainderLast  InstallmentRemainder = "last"
+)
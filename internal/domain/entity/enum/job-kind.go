@@ -0,0 +1,9 @@
+package enum
+
+type JobKind string
+
+const (
+	JobKindImportCSV          JobKind = "import_csv"
+	JobKindImportOFX          JobKind = "import_ofx"
+	JobKindExportTransactions JobKind = "export_transactions"
+)
@@ -0,0 +1,8 @@
+package enum
+
+type TrashItemKind string
+
+const (
+	TrashItemKindTransaction TrashItemKind = "transaction"
+	TrashItemKindCategory    TrashItemKind = "category"
+)
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord remembers the request made with an Idempotency-Key and, once it has
+// been handled, the response to replay when the request is retried
+type IdempotencyRecord struct {
+	userID      uuid.UUID
+	key         string
+	requestHash string
+	statusCode  int
+	contentType string
+	headers     map[string]string
+	body        []byte
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+func (r *IdempotencyRecord) UserID() uuid.UUID          { return r.userID }
+func (r *IdempotencyRecord) Key() string                { return r.key }
+func (r *IdempotencyRecord) RequestHash() string        { return r.requestHash }
+func (r *IdempotencyRecord) StatusCode() int            { return r.statusCode }
+func (r *IdempotencyRecord) ContentType() string        { return r.contentType }
+func (r *IdempotencyRecord) Headers() map[string]string { return r.headers }
+func (r *IdempotencyRecord) Body() []byte               { return r.body }
+func (r *IdempotencyRecord) CreatedAt() time.Time       { return r.createdAt }
+func (r *IdempotencyRecord) ExpiresAt() time.Time       { return r.expiresAt }
+
+// NewIdempotencyRecord reserves key for a request that is about to be handled
+func NewIdempotencyRecord(clock clock.Clock, userID uuid.UUID, key string, requestHash string, ttl time.Duration) (*IdempotencyRecord, error) {
+	now := clock.Now()
+	record := &IdempotencyRecord{
+		userID:      userID,
+		key:         key,
+		requestHash: requestHash,
+		createdAt:   now,
+		expiresAt:   now.Add(ttl),
+	}
+
+	err := record.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// RestoreIdempotencyRecord rebuilds a record that already exists
+func RestoreIdempotencyRecord(userID uuid.UUID, key string, requestHash string, statusCode int, contentType string, headers map[string]string, body []byte, createdAt time.Time, expiresAt time.Time) (*IdempotencyRecord, error) {
+	record := &IdempotencyRecord{
+		userID:      userID,
+		key:         key,
+		requestHash: requestHash,
+		statusCode:  statusCode,
+		contentType: contentType,
+		headers:     headers,
+		body:        body,
+		createdAt:   createdAt,
+		expiresAt:   expiresAt,
+	}
+
+	err := record.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Complete stores the response given to the request, with the headers that describe it
+func (r *IdempotencyRecord) Complete(statusCode int, contentType string, headers map[string]string, body []byte) {
+	r.statusCode = statusCode
+	r.contentType = contentType
+	r.headers = headers
+	r.body = body
+}
+
+// Completed reports whether the response is known, or the request is still being handled
+func (r *IdempotencyRecord) Completed() bool {
+	return r.statusCode != 0
+}
+
+// Matches reports whether a retry carries the same request as the original
+func (r *IdempotencyRecord) Matches(requestHash string) bool {
+	return r.requestHash == requestHash
+}
+
+// Expired reports whether the key may be reused for a new request
+func (r *IdempotencyRecord) Expired(now time.Time) bool {
+	return !now.Before(r.expiresAt)
+}
+
+func (r *IdempotencyRecord) validate() error {
+	if r.userID == uuid.Nil {
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
+	}
+
+	if r.key == "" {
+		return domainerror.NewValidation("Idempotency-Key", "idempotency_key_invalid", "Idempotency-Key must have between 1 and 255 printable characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIdempotencyRecord(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000001")
+
+	t.Run("should reserve the key until the ttl elapses", func(t *testing.T) {
+		record, err := NewIdempotencyRecord(clock.NewFixed(now), userID, "key-1", "hash", time.Hour)
+
+		assert.Nil(t, err)
+		assert.Equal(t, now.Add(time.Hour), record.ExpiresAt())
+		assert.False(t, record.Completed())
+		assert.False(t, record.Expired(now.Add(59*time.Minute)))
+		assert.True(t, record.Expired(now.Add(time.Hour)))
+	})
+
+	t.Run("should keep the completed response", func(t *testing.T) {
+		record, _ := NewIdempotencyRecord(clock.NewFixed(now), userID, "key-1", "hash", time.Hour)
+
+		record.Complete(201, "application/json", map[string]string{"Location": "/v1/transactions/1"}, []byte(`{}`))
+
+		assert.True(t, record.Completed())
+		assert.Equal(t, "/v1/transactions/1", record.Headers()["Location"])
+		assert.True(t, record.Matches("hash"))
+		assert.False(t, record.Matches("other"))
+	})
+
+	t.Run("should require a key", func(t *testing.T) {
+		_, err := NewIdempotencyRecord(clock.NewFixed(now), userID, "", "hash", time.Hour)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+	})
+}
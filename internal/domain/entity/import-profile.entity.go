@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+)
+
+// dateTokens turns the date format users write, such as DD/MM/YYYY, into a Go time layout
+var dateTokens = strings.NewReplacer("YYYY", "2006", "MM", "01", "DD", "02", "HH", "15", "mm", "04", "ss", "05")
+
+// ImportColumns names the statement columns read into each field of a transaction: header names
+// when the file has a header row, 1-based positions otherwise. Description and Category are optional.
+type ImportColumns struct {
+	Date        string
+	Amount      string
+	Description string
+	Category    string
+}
+
+// ImportSettings describes how the rows of a bank statement become transactions. Amounts are
+// signed: negative ones are expenses and positive ones income, unless NegateAmounts flips them,
+// as card statements list purchases as positive amounts. A row without a category column value
+// goes to ExpenseCategoryID or IncomeCategoryID according to its sign.
+type ImportSettings struct {
+	Delimiter         string
+	DecimalComma      bool
+	DateFormat        string
+	HasHeader         bool
+	NegateAmounts     bool
+	Columns           ImportColumns
+	ExpenseCategoryID uuid.UUID
+	IncomeCategoryID  uuid.UUID
+}
+
+// DateLayout is the DateFormat as a Go time layout
+func (s ImportSettings) DateLayout() string {
+	return dateTokens.Replace(s.DateFormat)
+}
+
+// Validate reports the first setting that cannot be used to read a statement
+func (s ImportSettings) Validate() error {
+	delimiter, size := utf8.DecodeRuneInString(s.Delimiter)
+	if size == 0 || size != len(s.Delimiter) || delimiter == '"' || delimiter == '\r' || delimiter == '\n' || delimiter == utf8.RuneError {
+		return domainerror.NewValidation("delimiter", "delimiter_invalid", "delimiter must be a single character other than a quote or a line break")
+	}
+
+	for _, token := range []string{"YYYY", "MM", "DD"} {
+		if !strings.Contains(s.DateFormat, token) {
+			return domainerror.NewValidation("dateFormat", "date_format_invalid", "date format must contain YYYY, MM and DD")
+		}
+	}
+	for _, r := range strings.NewReplacer("YYYY", "", "MM", "", "DD", "", "HH", "", "mm", "", "ss", "").Replace(s.DateFormat) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return domainerror.NewValidation("dateFormat", "date_format_invalid", "date format must contain YYYY, MM and DD")
+		}
+	}
+
+	columns := []struct {
+		field, value string
+		required     bool
+	}{
+		{"columns.date", s.Columns.Date, true},
+		{"columns.amount", s.Columns.Amount, true},
+		{"columns.description", s.Columns.Description, false},
+		{"columns.category", s.Columns.Category, false},
+	}
+	for _, column := range columns {
+		if column.value == "" {
+			if column.required {
+				return domainerror.NewValidation(column.field, "column_required", "column is required").
+					WithParams(map[string]string{"field": column.field})
+			}
+			continue
+		}
+		if position, err := strconv.Atoi(column.value); !s.HasHeader && (err != nil || position < 1) {
+			return domainerror.NewValidation(column.field, "column_position_invalid", "without a header row, columns must be given by position, starting at 1").
+				WithParams(map[string]string{"field": column.field})
+		}
+	}
+
+	return nil
+}
+
+// ImportProfile is a set of import settings saved by a user under a name, typically one per bank
+type ImportProfile struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	name      string
+	settings  ImportSettings
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (p *ImportProfile) ID() uuid.UUID            { return p.id }
+func (p *ImportProfile) UserID() uuid.UUID        { return p.userID }
+func (p *ImportProfile) Name() string             { return p.name }
+func (p *ImportProfile) Settings() ImportSettings { return p.settings }
+func (p *ImportProfile) CreatedAt() time.Time     { return p.createdAt }
+func (p *ImportProfile) UpdatedAt() time.Time     { return p.updatedAt }
+
+func NewImportProfile(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, name string, settings ImportSettings) (*ImportProfile, error) {
+	now := clock.Now()
+	return RestoreImportProfile(ids.NewID(), userID, name, settings, now, now)
+}
+
+// RestoreImportProfile rebuilds an import profile that already exists, keeping its identity and timestamps
+func RestoreImportProfile(id uuid.UUID, userID uuid.UUID, name string, settings ImportSettings, createdAt time.Time, updatedAt time.Time) (*ImportProfile, error) {
+	profile := &ImportProfile{
+		id:        id,
+		userID:    userID,
+		name:      name,
+		settings:  settings,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+
+	err := profile.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Update replaces the name and settings, leaving the profile untouched when the result is invalid
+func (p *ImportProfile) Update(clock clock.Clock, name string, settings ImportSettings) error {
+	updated := *p
+	updated.name = name
+	updated.settings = settings
+	updated.updatedAt = clock.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+func (p *ImportProfile) validate() error {
+	if p.userID == uuid.Nil {
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
+	}
+
+	if p.name == "" {
+		return domainerror.NewValidation("name", "name_required", "name is required")
+	}
+
+	return p.settings.Validate()
+}
@@ -0,0 +1,263 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+)
+
+// MaxInstallments is how many installments a purchase may be split into
+const MaxInstallments = 120
+
+// Installment is one parcel of an installment purchase, recorded as its own transaction
+type Installment struct {
+	// Number is the position of the installment, counted from 1
+	Number int
+	// TransactionID is the transaction the installment is recorded as, nil until it is linked
+	TransactionID uuid.UUID
+	DueAt         time.Time
+	Amount        float64
+	Cancelled     bool
+}
+
+// InstallmentPurchase is a purchase paid in monthly installments, such as a card purchase in
+// "12x". The total is split in cents, the remainder going to the first or the last installment,
+// and each installment falls on the day of the month of the first one, or the last day of shorter
+// months. Installments not due yet can be changed or cancelled together.
+type InstallmentPurchase struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	categoryID   uuid.UUID
+	description  string
+	totalAmount  float64
+	remainder    enum.InstallmentRemainder
+	installments []Installment
+	version      int64
+	createdAt    time.Time
+	updatedAt    time.Time
+	cancelledAt  time.Time
+}
+
+func (p *InstallmentPurchase) ID() uuid.UUID                        { return p.id }
+func (p *InstallmentPurchase) UserID() uuid.UUID                    { return p.userID }
+func (p *InstallmentPurchase) CategoryID() uuid.UUID                { return p.categoryID }
+func (p *InstallmentPurchase) Description() string                  { return p.description }
+func (p *InstallmentPurchase) TotalAmount() float64                 { return p.totalAmount }
+func (p *InstallmentPurchase) Remainder() enum.InstallmentRemainder { return p.remainder }
+func (p *InstallmentPurchase) Installments() []Installment          { return slices.Clone(p.installments) }
+func (p *InstallmentPurchase) Version() int64                       { return p.version }
+func (p *InstallmentPurchase) CreatedAt() time.Time                 { return p.createdAt }
+func (p *InstallmentPurchase) UpdatedAt() time.Time                 { return p.updatedAt }
+func (p *InstallmentPurchase) CancelledAt() time.Time               { return p.cancelledAt }
+func (p *InstallmentPurchase) Cancelled() bool                      { return !p.cancelledAt.IsZero() }
+
+// NewInstallmentPurchase splits totalAmount into count monthly installments, the first due at
+// firstDueAt. The installments are linked to their transactions once those are created.
+func NewInstallmentPurchase(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, categoryID uuid.UUID, description string, totalAmount float64, count int, firstDueAt time.Time, remainder enum.InstallmentRemainder) (*InstallmentPurchase, error) {
+	if count < 2 || count > MaxInstallments {
+		return nil, installmentsInvalid()
+	}
+	if firstDueAt.IsZero() {
+		return nil, domainerror.NewValidation("firstDueAt", "first_due_at_required", "first due date is required")
+	}
+
+	amounts, err := splitAmount(totalAmount, count, remainder)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly := RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1, Count: count}
+	installments := make([]Installment, 0, count)
+	for index, dueAt := range monthly.Occurrences(firstDueAt) {
+		installments = append(installments, Installment{Number: index + 1, DueAt: dueAt, Amount: amounts[index]})
+	}
+
+	now := clock.Now()
+	return RestoreInstallmentPurchase(ids.NewID(), userID, categoryID, description, totalAmount, remainder, installments, 1, now, now, time.Time{})
+}
+
+// RestoreInstallmentPurchase rebuilds an installment purchase that already exists, keeping its
+// identity, version and timestamps
+func RestoreInstallmentPurchase(id uuid.UUID, userID uuid.UUID, categoryID uuid.UUID, description string, totalAmount float64, remainder enum.InstallmentRemainder, installments []Installment, version int64, createdAt time.Time, updatedAt time.Time, cancelledAt time.Time) (*InstallmentPurchase, error) {
+	purchase := &InstallmentPurchase{
+		id:           id,
+		userID:       userID,
+		categoryID:   categoryID,
+		description:  description,
+		totalAmount:  totalAmount,
+		remainder:    remainder,
+		installments: installments,
+		version:      version,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+		cancelledAt:  cancelledAt,
+	}
+
+	err := purchase.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return purchase, nil
+}
+
+// Link records the transactions the installments were created as, in the order of the installments
+func (p *InstallmentPurchase) Link(transactionIDs []uuid.UUID) {
+	p.installments = slices.Clone(p.installments)
+	for i := range p.installments {
+		p.installments[i].TransactionID = transactionIDs[i]
+	}
+}
+
+// TransactionDescription is the description of the transaction of an installment, such as
+// "Notebook (3/12)"
+func (p *InstallmentPurchase) TransactionDescription(installment Installment) string {
+	return strings.TrimSpace(fmt.Sprintf("%s (%d/%d)", p.description, installment.Number, len(p.installments)))
+}
+
+// Remaining lists the installments not due by now and not cancelled
+func (p *InstallmentPurchase) Remaining(now time.Time) []Installment {
+	var remaining []Installment
+	for _, installment := range p.installments {
+		if !installment.Cancelled && installment.DueAt.After(now) {
+			remaining = append(remaining, installment)
+		}
+	}
+	return remaining
+}
+
+// EditRemaining moves the remaining installments to the category and description given and
+// spreads what is left of the new total over them, returning the edited installments. The
+// installments already due keep their amounts, and the purchase is left untouched when the
+// result is invalid. The version is not changed here; repositories bump it when the update is
+// stored.
+func (p *InstallmentPurchase) EditRemaining(clock clock.Clock, categoryID uuid.UUID, description string, totalAmount float64) ([]Installment, error) {
+	now := clock.Now()
+	remaining := p.Remaining(now)
+	if len(remaining) == 0 {
+		return nil, p.settled()
+	}
+
+	var dueCents int64
+	for _, installment := range p.installments {
+		if !installment.Cancelled && !installment.DueAt.After(now) {
+			dueCents += cents(installment.Amount)
+		}
+	}
+	amounts, err := splitAmount(float64(cents(totalAmount)-dueCents)/100, len(remaining), p.remainder)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *p
+	updated.categoryID = categoryID
+	updated.description = description
+	updated.totalAmount = totalAmount
+	updated.installments = slices.Clone(p.installments)
+	updated.updatedAt = now
+	for i, installment := range remaining {
+		remaining[i].Amount = amounts[i]
+		updated.installments[installment.Number-1].Amount = amounts[i]
+	}
+
+	if err := updated.validate(); err != nil {
+		return nil, err
+	}
+
+	*p = updated
+	return remaining, nil
+}
+
+// Cancel cancels the remaining installments, returning them so their transactions can be
+// removed. The total is reduced to what was due by then, 0 for a purchase cancelled before its
+// first installment fell due.
+func (p *InstallmentPurchase) Cancel(clock clock.Clock) ([]Installment, error) {
+	now := clock.Now()
+	remaining := p.Remaining(now)
+	if len(remaining) == 0 {
+		return nil, p.settled()
+	}
+
+	p.installments = slices.Clone(p.installments)
+	for _, installment := range remaining {
+		p.installments[installment.Number-1].Cancelled = true
+		p.totalAmount = float64(cents(p.totalAmount)-cents(installment.Amount)) / 100
+	}
+	p.cancelledAt = now
+	p.updatedAt = now
+	return remaining, nil
+}
+
+func installmentsInvalid() error {
+	return domainerror.NewValidation("installments", "installments_invalid", fmt.Sprintf("installments must be between 2 and %d", MaxInstallments)).
+		WithParams(map[string]string{"max": strconv.Itoa(MaxInstallments)})
+}
+
+// settled is the error of changing a purchase with no installment left to change
+func (p *InstallmentPurchase) settled() error {
+	return domainerror.NewConflict("installment_purchase_settled", "the purchase has no remaining installments", nil)
+}
+
+func (p *InstallmentPurchase) validate() error {
+	if p.userID == uuid.Nil {
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
+	}
+
+	if p.categoryID == uuid.Nil {
+		return domainerror.NewValidation("categoryId", "category_id_required", "category id is required")
+	}
+
+	// a purchase cancelled before any installment fell due is left with nothing to pay
+	if p.totalAmount < 0 || (p.totalAmount == 0 && !p.Cancelled()) {
+		return domainerror.NewValidation("totalAmount", "amount_must_be_positive", "amount must be greater than 0")
+	}
+
+	switch p.remainder {
+	case enum.InstallmentRemainderFirst, enum.InstallmentRemainderLast:
+	default:
+		return domainerror.NewValidation("remainder", "installment_remainder_invalid", "remainder must be first or last")
+	}
+
+	if len(p.installments) < 2 || len(p.installments) > MaxInstallments {
+		return installmentsInvalid()
+	}
+
+	return nil
+}
+
+// splitAmount splits total into count equal amounts that add up to total, all the leftover cents
+// going to the first or the last amount: 100 in 3 is 33.34, 33.33 and 33.33 with the first taking
+// them, and that amount exceeds the others by up to count-1 cents
+func splitAmount(total float64, count int, remainder enum.InstallmentRemainder) ([]float64, error) {
+	totalCents := cents(total)
+	if totalCents < int64(count) {
+		return nil, domainerror.NewValidation("totalAmount", "installment_amount_too_small", "amount must leave at least one cent for each remaining installment")
+	}
+
+	each, leftover := totalCents/int64(count), totalCents%int64(count)
+	amounts := make([]float64, count)
+	for i := range amounts {
+		amounts[i] = float64(each) / 100
+	}
+
+	target := 0
+	if remainder == enum.InstallmentRemainderLast {
+		target = count - 1
+	}
+	amounts[target] = float64(each+leftover) / 100
+	return amounts, nil
+}
+
+// cents is amount rounded to whole cents
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
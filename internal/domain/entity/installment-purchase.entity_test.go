@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallmentPurchase(t *testing.T) {
+	userID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000001")
+	categoryID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000002")
+	firstDueAt := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	newPurchase := func(fixedClock *clock.Fixed, total float64, count int, remainder enum.InstallmentRemainder) *InstallmentPurchase {
+		purchase, err := NewInstallmentPurchase(fixedClock, identifier.NewV7(), userID, categoryID, "Notebook", total, count, firstDueAt, remainder)
+		assert.Nil(t, err)
+		return purchase
+	}
+	amounts := func(installments []Installment) []float64 {
+		var all []float64
+		for _, installment := range installments {
+			all = append(all, installment.Amount)
+		}
+		return all
+	}
+
+	t.Run("should put the leftover cents on the chosen installment", func(t *testing.T) {
+		fixedClock := clock.NewFixed(firstDueAt)
+
+		assert.Equal(t, []float64{33.34, 33.33, 33.33}, amounts(newPurchase(fixedClock, 100, 3, enum.InstallmentRemainderFirst).Installments()))
+		assert.Equal(t, []float64{33.33, 33.33, 33.34}, amounts(newPurchase(fixedClock, 100, 3, enum.InstallmentRemainderLast).Installments()))
+	})
+
+	t.Run("should fall monthly on the day of the first installment", func(t *testing.T) {
+		purchase := newPurchase(clock.NewFixed(firstDueAt), 300, 3, enum.InstallmentRemainderFirst)
+		installments := purchase.Installments()
+
+		assert.Equal(t, time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC), installments[1].DueAt)
+		assert.Equal(t, time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC), installments[2].DueAt)
+		assert.Equal(t, "Notebook (2/3)", purchase.TransactionDescription(installments[1]))
+	})
+
+	t.Run("should reject totals that do not cover a cent per installment", func(t *testing.T) {
+		_, err := NewInstallmentPurchase(clock.NewFixed(firstDueAt), identifier.NewV7(), userID, categoryID, "Gum", 0.02, 3, firstDueAt, enum.InstallmentRemainderFirst)
+
+		domainErr, ok := domainerror.As(err)
+		if assert.True(t, ok) {
+			assert.Equal(t, "installment_amount_too_small", domainErr.Code)
+		}
+	})
+
+	t.Run("should spread the new total over the remaining installments only", func(t *testing.T) {
+		fixedClock := clock.NewFixed(firstDueAt)
+		purchase := newPurchase(fixedClock, 120, 4, enum.InstallmentRemainderFirst)
+
+		edited, err := purchase.EditRemaining(fixedClock, categoryID, "Laptop", 130)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []float64{33.34, 33.33, 33.33}, amounts(edited))
+		assert.Equal(t, []float64{30, 33.34, 33.33, 33.33}, amounts(purchase.Installments()))
+		assert.Equal(t, "Laptop (2/4)", purchase.TransactionDescription(edited[0]))
+
+		_, err = purchase.EditRemaining(fixedClock, categoryID, "Laptop", 30)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+		assert.Equal(t, 130.0, purchase.TotalAmount())
+	})
+
+	t.Run("should cancel the remaining installments once", func(t *testing.T) {
+		fixedClock := clock.NewFixed(firstDueAt.AddDate(0, 1, 0))
+		purchase := newPurchase(fixedClock, 120, 4, enum.InstallmentRemainderFirst)
+
+		cancelled, err := purchase.Cancel(fixedClock)
+
+		assert.Nil(t, err)
+		assert.Len(t, cancelled, 2)
+		assert.Equal(t, 60.0, purchase.TotalAmount())
+		assert.True(t, purchase.Cancelled())
+		assert.Empty(t, purchase.Remaining(fixedClock.Now()))
+
+		_, err = purchase.Cancel(fixedClock)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+	})
+
+	t.Run("should cancel a purchase before its first installment falls due", func(t *testing.T) {
+		fixedClock := clock.NewFixed(firstDueAt.AddDate(0, 0, -10))
+		purchase := newPurchase(fixedClock, 120, 4, enum.InstallmentRemainderFirst)
+
+		cancelled, err := purchase.Cancel(fixedClock)
+
+		assert.Nil(t, err)
+		assert.Len(t, cancelled, 4)
+		assert.Equal(t, 0.0, purchase.TotalAmount())
+
+		_, err = RestoreInstallmentPurchase(purchase.ID(), userID, categoryID, "Notebook", purchase.TotalAmount(), purchase.Remainder(),
+			purchase.Installments(), purchase.Version(), purchase.CreatedAt(), purchase.UpdatedAt(), purchase.CancelledAt())
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+)
+
+// JobResult describes the file a succeeded job produced, kept in file storage under ResultKey
+type JobResult struct {
+	Name        string
+	ContentType string
+}
+
+// JobFailure is why the last attempt of a job failed, as an error code to be translated with
+// its params; Message is the fallback text
+type JobFailure struct {
+	Code    string
+	Message string
+	Params  map[string]string
+}
+
+// Job is work a user asked for that runs in the background, such as a large import or export.
+// A queued job is claimed by a worker once runAt is due and runs under a lease the worker keeps
+// renewing; when the lease expires the worker is presumed gone and the job may be claimed again.
+// Failed attempts are retried until maxAttempts is reached.
+type Job struct {
+	id             uuid.UUID
+	userID         uuid.UUID
+	kind           enum.JobKind
+	status         enum.JobStatus
+	payload        []byte
+	progress       int
+	attempts       int
+	maxAttempts    int
+	runAt          time.Time
+	leaseExpiresAt time.Time
+	result         JobResult
+	failure        JobFailure
+	version        int64
+	createdAt      time.Time
+	updatedAt      time.Time
+	startedAt      time.Time
+	finishedAt     time.Time
+}
+
+func (j *Job) ID() uuid.UUID             { return j.id }
+func (j *Job) UserID() uuid.UUID         { return j.userID }
+func (j *Job) Kind() enum.JobKind        { return j.kind }
+func (j *Job) Status() enum.JobStatus    { return j.status }
+func (j *Job) Payload() []byte           { return j.payload }
+func (j *Job) Progress() int             { return j.progress }
+func (j *Job) Attempts() int             { return j.attempts }
+func (j *Job) MaxAttempts() int          { return j.maxAttempts }
+func (j *Job) RunAt() time.Time          { return j.runAt }
+func (j *Job) LeaseExpiresAt() time.Time { return j.leaseExpiresAt }
+func (j *Job) Result() JobResult         { return j.result }
+func (j *Job) Failure() JobFailure       { return j.failure }
+func (j *Job) Version() int64            { return j.version }
+func (j *Job) CreatedAt() time.Time      { return j.createdAt }
+func (j *Job) UpdatedAt() time.Time      { return j.updatedAt }
+func (j *Job) StartedAt() time.Time      { return j.startedAt }
+func (j *Job) FinishedAt() time.Time     { return j.finishedAt }
+
+// InputKey is where the file uploaded along with the job is kept in file storage
+func (j *Job) InputKey() string { return "jobs/" + j.id.String() + "/input" }
+
+// ResultKey is where the file produced by the job is kept in file storage
+func (j *Job) ResultKey() string { return "jobs/" + j.id.String() + "/result" }
+
+// Finished reports whether the job succeeded, failed for good or was cancelled
+func (j *Job) Finished() bool {
+	return j.status == enum.JobStatusSucceeded || j.status == enum.JobStatusFailed || j.status == enum.JobStatusCancelled
+}
+
+// CanRetry reports whether another attempt may follow the current one
+func (j *Job) CanRetry() bool { return j.attempts < j.maxAttempts }
+
+// NewJob queues a job of kind with the parameters in payload, to be attempted up to maxAttempts times
+func NewJob(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, kind enum.JobKind, payload []byte, maxAttempts int) (*Job, error) {
+	now := clock.Now()
+	return RestoreJob(ids.NewID(), userID, kind, enum.JobStatusQueued, payload, 0, 0, maxAttempts, now, time.Time{}, JobResult{}, JobFailure{}, 1, now, now, time.Time{}, time.Time{})
+}
+
+// RestoreJob rebuilds a job that already exists, keeping its identity, version and timestamps.
+// Zero times are unset.
+func RestoreJob(id uuid.UUID, userID uuid.UUID, kind enum.JobKind, status enum.JobStatus, payload []byte, progress int, attempts int, maxAttempts int, runAt time.Time, leaseExpiresAt time.Time, result JobResult, failure JobFailure, version int64, createdAt time.Time, updatedAt time.Time, startedAt time.Time, finishedAt time.Time) (*Job, error) {
+	job := &Job{
+		id:             id,
+		userID:         userID,
+		kind:           kind,
+		status:         status,
+		payload:        payload,
+		progress:       progress,
+		attempts:       attempts,
+		maxAttempts:    maxAttempts,
+		runAt:          runAt,
+		leaseExpiresAt: leaseExpiresAt,
+		result:         result,
+		failure:        failure,
+		version:        version,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+		startedAt:      startedAt,
+		finishedAt:     finishedAt,
+	}
+
+	err := job.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Start begins a new attempt, held by the worker that claimed the job for lease
+func (j *Job) Start(clock clock.Clock, lease time.Duration) {
+	now := clock.Now()
+	j.status = enum.JobStatusRunning
+	j.attempts++
+	j.progress = 0
+	j.leaseExpiresAt = now.Add(lease)
+	j.startedAt = now
+	j.updatedAt = now
+}
+
+// Heartbeat records the progress of a running job, as a percentage, and renews its lease
+func (j *Job) Heartbeat(clock clock.Clock, progress int, lease time.Duration) {
+	now := clock.Now()
+	j.progress = min(max(progress, 0), 100)
+	j.leaseExpiresAt = now.Add(lease)
+	j.updatedAt = now
+}
+
+// Succeed finishes the job with the file it produced
+func (j *Job) Succeed(clock clock.Clock, result JobResult) {
+	j.finish(clock, enum.JobStatusSucceeded)
+	j.progress = 100
+	j.result = result
+	j.failure = JobFailure{}
+}
+
+// Retry queues the job again after delay, keeping why the attempt failed
+func (j *Job) Retry(clock clock.Clock, failure JobFailure, delay time.Duration) {
+	now := clock.Now()
+	j.status = enum.JobStatusQueued
+	j.failure = failure
+	j.runAt = now.Add(delay)
+	j.leaseExpiresAt = time.Time{}
+	j.updatedAt = now
+}
+
+// Release puts a running job back in the queue without counting the attempt, for workers that
+// stop before the job is done
+func (j *Job) Release(clock clock.Clock) {
+	now := clock.Now()
+	j.status = enum.JobStatusQueued
+	j.attempts = max(j.attempts-1, 0)
+	j.runAt = now
+	j.leaseExpiresAt = time.Time{}
+	j.updatedAt = now
+}
+
+// Fail finishes the job for good
+func (j *Job) Fail(clock clock.Clock, failure JobFailure) {
+	j.finish(clock, enum.JobStatusFailed)
+	j.failure = failure
+}
+
+// Cancel stops a job that has not finished yet; a running job stops at its next heartbeat
+func (j *Job) Cancel(clock clock.Clock) error {
+	if j.Finished() {
+		return domainerror.NewConflict("job_finished", "the job has already finished", nil).
+			WithParams(map[string]string{"status": string(j.status)})
+	}
+
+	j.finish(clock, enum.JobStatusCancelled)
+	return nil
+}
+
+func (j *Job) finish(clock clock.Clock, status enum.JobStatus) {
+	now := clock.Now()
+	j.status = status
+	j.leaseExpiresAt = time.Time{}
+	j.finishedAt = now
+	j.updatedAt = now
+}
+
+func (j *Job) validate() error {
+	if j.userID == uuid.Nil {
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
+	}
+
+	if j.maxAttempts < 1 {
+		return domainerror.NewValidation("maxAttempts", "max_attempts_invalid", "a job must be attempted at least once")
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000001")
+
+	newJob := func(fixedClock *clock.Fixed, maxAttempts int) *Job {
+		job, err := NewJob(fixedClock, identifier.NewV7(), userID, enum.JobKindExportTransactions, []byte(`{}`), maxAttempts)
+		assert.Nil(t, err)
+		return job
+	}
+
+	t.Run("should queue the job to run right away", func(t *testing.T) {
+		job := newJob(clock.NewFixed(now), 3)
+
+		assert.Equal(t, enum.JobStatusQueued, job.Status())
+		assert.Equal(t, now, job.RunAt())
+		assert.Equal(t, 0, job.Attempts())
+		assert.Equal(t, "jobs/"+job.ID().String()+"/result", job.ResultKey())
+	})
+
+	t.Run("should hold a lease while running and renew it on heartbeats", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		job := newJob(fixedClock, 3)
+
+		job.Start(fixedClock, time.Minute)
+		assert.Equal(t, enum.JobStatusRunning, job.Status())
+		assert.Equal(t, 1, job.Attempts())
+		assert.Equal(t, now.Add(time.Minute), job.LeaseExpiresAt())
+
+		fixedClock.Advance(10 * time.Second)
+		job.Heartbeat(fixedClock, 140, time.Minute)
+		assert.Equal(t, 100, job.Progress())
+		assert.Equal(t, now.Add(70*time.Second), job.LeaseExpiresAt())
+	})
+
+	t.Run("should retry until the attempts run out", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		job := newJob(fixedClock, 2)
+		failure := JobFailure{Code: "internal_error", Message: "boom"}
+
+		job.Start(fixedClock, time.Minute)
+		assert.True(t, job.CanRetry())
+		job.Retry(fixedClock, failure, 30*time.Second)
+		assert.Equal(t, enum.JobStatusQueued, job.Status())
+		assert.Equal(t, now.Add(30*time.Second), job.RunAt())
+		assert.Equal(t, failure, job.Failure())
+
+		job.Start(fixedClock, time.Minute)
+		assert.False(t, job.CanRetry())
+		job.Fail(fixedClock, failure)
+		assert.Equal(t, enum.JobStatusFailed, job.Status())
+		assert.True(t, job.Finished())
+	})
+
+	t.Run("should not count the attempt of a released job", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		job := newJob(fixedClock, 1)
+
+		job.Start(fixedClock, time.Minute)
+		job.Release(fixedClock)
+
+		assert.Equal(t, enum.JobStatusQueued, job.Status())
+		assert.Equal(t, 0, job.Attempts())
+		assert.True(t, job.CanRetry())
+	})
+
+	t.Run("should succeed with the result", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		job := newJob(fixedClock, 1)
+
+		job.Start(fixedClock, time.Minute)
+		job.Succeed(fixedClock, JobResult{Name: "transactions.csv", ContentType: "text/csv"})
+
+		assert.Equal(t, enum.JobStatusSucceeded, job.Status())
+		assert.Equal(t, 100, job.Progress())
+		assert.Equal(t, "transactions.csv", job.Result().Name)
+		assert.Equal(t, now, job.FinishedAt())
+	})
+
+	t.Run("should cancel an unfinished job only", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		job := newJob(fixedClock, 1)
+
+		assert.Nil(t, job.Cancel(fixedClock))
+		assert.Equal(t, enum.JobStatusCancelled, job.Status())
+
+		err := job.Cancel(fixedClock)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+	})
+
+	t.Run("should require at least one attempt", func(t *testing.T) {
+		_, err := NewJob(clock.NewFixed(now), identifier.NewV7(), userID, enum.JobKindImportCSV, nil, 0)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+	})
+}
@@ -0,0 +1,403 @@
+package entity
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+)
+
+// MaxOccurrenceYearsAhead bounds how far from now an occurrence can be looked up, as finding one
+// walks the series from its start
+const MaxOccurrenceYearsAhead = 10
+
+// RecurrenceRule says when the occurrences of a recurring transaction fall, in the spirit of the
+// iCalendar RRULE. Occurrences keep the time of day the series starts at and are computed in UTC.
+// A series with both Until and Count ends with whichever comes first.
+type RecurrenceRule struct {
+	Frequency enum.RecurrenceFrequency
+	// Interval repeats the series every Interval days, weeks, months or years
+	Interval int
+	// DayOfMonth is the day monthly occurrences fall on, the last day of shorter months. Zero
+	// keeps the day the series starts on.
+	DayOfMonth int
+	// LastBusinessDay moves monthly occurrences to the last weekday of the month
+	LastBusinessDay bool
+	// Until is the last day an occurrence may fall on, zero for none
+	Until time.Time
+	// Count is how many occurrences the series has, zero for no limit
+	Count int
+}
+
+// Occurrences yields the occurrences of a series starting at start, in order, until the rule ends
+func (r RecurrenceRule) Occurrences(start time.Time) iter.Seq2[int, time.Time] {
+	return func(yield func(int, time.Time) bool) {
+		if r.Interval < 1 {
+			return
+		}
+		start = start.UTC()
+
+		index := 0
+		for period := 0; ; period++ {
+			occurrence := r.occurrence(start, period)
+			if occurrence.Before(start) {
+				// a day of month earlier than the start only counts from the next period
+				continue
+			}
+			if !r.Until.IsZero() && dateOf(occurrence).After(dateOf(r.Until)) {
+				return
+			}
+			if r.Count > 0 && index == r.Count {
+				return
+			}
+			if !yield(index, occurrence) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// occurrence is the candidate of the given period, counted from the start of the series
+func (r RecurrenceRule) occurrence(start time.Time, period int) time.Time {
+	n := period * r.Interval
+	switch r.Frequency {
+	case enum.RecurrenceFrequencyDaily:
+		return start.AddDate(0, 0, n)
+	case enum.RecurrenceFrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case enum.RecurrenceFrequencyMonthly:
+		month := time.Date(start.Year(), start.Month()+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+		if r.LastBusinessDay {
+			day := month.AddDate(0, 1, -1)
+			for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+				day = day.AddDate(0, 0, -1)
+			}
+			return day
+		}
+		return onDay(month, cmp.Or(r.DayOfMonth, start.Day()))
+	default:
+		year := time.Date(start.Year()+n, start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+		return onDay(year, start.Day())
+	}
+}
+
+func (r RecurrenceRule) validate(start time.Time) error {
+	switch r.Frequency {
+	case enum.RecurrenceFrequencyDaily, enum.RecurrenceFrequencyWeekly, enum.RecurrenceFrequencyMonthly, enum.RecurrenceFrequencyYearly:
+	default:
+		return domainerror.NewValidation("schedule.frequency", "recurrence_frequency_invalid", "frequency must be daily, weekly, monthly or yearly")
+	}
+
+	if r.Interval < 1 {
+		return domainerror.NewValidation("schedule.interval", "recurrence_interval_invalid", "interval must be at least 1")
+	}
+
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 || (r.DayOfMonth != 0 && r.Frequency != enum.RecurrenceFrequencyMonthly) {
+		return domainerror.NewValidation("schedule.dayOfMonth", "recurrence_day_of_month_invalid", "day of month must be between 1 and 31, and only applies to monthly schedules")
+	}
+
+	if r.LastBusinessDay && (r.Frequency != enum.RecurrenceFrequencyMonthly || r.DayOfMonth != 0) {
+		return domainerror.NewValidation("schedule.lastBusinessDay", "recurrence_last_business_day_invalid", "last business day only applies to monthly schedules without a day of month")
+	}
+
+	if r.Count < 0 {
+		return domainerror.NewValidation("schedule.count", "recurrence_count_invalid", "count must not be negative")
+	}
+
+	if !r.Until.IsZero() && dateOf(r.Until).Before(dateOf(start)) {
+		return domainerror.NewValidation("schedule.until", "recurrence_until_invalid", "until must not be before the start of the series")
+	}
+
+	return nil
+}
+
+// RecurringOccurrence is an occurrence of a recurring transaction that is not recorded yet
+type RecurringOccurrence struct {
+	At      time.Time
+	Skipped bool
+}
+
+// RecurringTransaction is a transaction repeated on a schedule, such as rent or a salary. Its
+// occurrences are recorded as transactions once they are due, in order: Materialized counts those
+// already dealt with, skipped ones included. A series whose occurrences cannot be recorded, such
+// as one whose category was deleted, is paused until its user resumes it.
+type RecurringTransaction struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	categoryID   uuid.UUID
+	amount       float64
+	description  string
+	startAt      time.Time
+	rule         RecurrenceRule
+	skipped      []time.Time
+	materialized int
+	nextAt       time.Time
+	pausedAt     time.Time
+	pauseReason  string
+	version      int64
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func (r *RecurringTransaction) ID() uuid.UUID         { return r.id }
+func (r *RecurringTransaction) UserID() uuid.UUID     { return r.userID }
+func (r *RecurringTransaction) CategoryID() uuid.UUID { return r.categoryID }
+func (r *RecurringTransaction) Amount() float64       { return r.amount }
+func (r *RecurringTransaction) Description() string   { return r.description }
+func (r *RecurringTransaction) StartAt() time.Time    { return r.startAt }
+func (r *RecurringTransaction) Rule() RecurrenceRule  { return r.rule }
+func (r *RecurringTransaction) Skipped() []time.Time  { return slices.Clone(r.skipped) }
+func (r *RecurringTransaction) Materialized() int     { return r.materialized }
+func (r *RecurringTransaction) PausedAt() time.Time   { return r.pausedAt }
+func (r *RecurringTransaction) PauseReason() string   { return r.pauseReason }
+func (r *RecurringTransaction) Paused() bool          { return !r.pausedAt.IsZero() }
+func (r *RecurringTransaction) Version() int64        { return r.version }
+func (r *RecurringTransaction) CreatedAt() time.Time  { return r.createdAt }
+func (r *RecurringTransaction) UpdatedAt() time.Time  { return r.updatedAt }
+
+// NextAt is when the next occurrence falls, zero once the series has ended
+func (r *RecurringTransaction) NextAt() time.Time { return r.nextAt }
+func (r *RecurringTransaction) Ended() bool       { return r.nextAt.IsZero() }
+
+func NewRecurringTransaction(clock clock.Clock, ids identifier.Generator, userID uuid.UUID, categoryID uuid.UUID, amount float64, description string, startAt time.Time, rule RecurrenceRule) (*RecurringTransaction, error) {
+	now := clock.Now()
+	return RestoreRecurringTransaction(ids.NewID(), userID, categoryID, amount, description, startAt, rule, nil, 0, time.Time{}, "", 1, now, now)
+}
+
+// RestoreRecurringTransaction rebuilds a recurring transaction that already exists, keeping its
+// identity, version and timestamps, how many of its occurrences were dealt with and whether it is
+// paused
+func RestoreRecurringTransaction(id uuid.UUID, userID uuid.UUID, categoryID uuid.UUID, amount float64, description string, startAt time.Time, rule RecurrenceRule, skipped []time.Time, materialized int, pausedAt time.Time, pauseReason string, version int64, createdAt time.Time, updatedAt time.Time) (*RecurringTransaction, error) {
+	recurring := &RecurringTransaction{
+		id:           id,
+		userID:       userID,
+		categoryID:   categoryID,
+		amount:       amount,
+		description:  description,
+		startAt:      startAt,
+		rule:         rule,
+		skipped:      skipped,
+		materialized: materialized,
+		pausedAt:     pausedAt,
+		pauseReason:  pauseReason,
+		version:      version,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+
+	err := recurring.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	recurring.nextAt = recurring.next()
+	return recurring, nil
+}
+
+// Upcoming lists up to limit of the occurrences not recorded yet, skipped ones included
+func (r *RecurringTransaction) Upcoming(limit int) []RecurringOccurrence {
+	var upcoming []RecurringOccurrence
+	for _, at := range r.pending() {
+		if len(upcoming) == limit {
+			break
+		}
+		upcoming = append(upcoming, RecurringOccurrence{At: at, Skipped: r.isSkipped(at)})
+	}
+	return upcoming
+}
+
+// Materialize moves the series past its occurrences due by now, returning those that were not
+// skipped, which are to be recorded as transactions
+func (r *RecurringTransaction) Materialize(now time.Time) []time.Time {
+	var due []time.Time
+	for index, at := range r.pending() {
+		if at.After(now) {
+			break
+		}
+		r.materialized = index + 1
+		if !r.isSkipped(at) {
+			due = append(due, at)
+		}
+	}
+
+	r.nextAt = r.next()
+	r.skipped = slices.DeleteFunc(slices.Clone(r.skipped), func(skipped time.Time) bool {
+		return r.nextAt.IsZero() || skipped.Before(r.nextAt)
+	})
+	return due
+}
+
+// Pause stops the occurrences of the series from being recorded, for the reason given by an
+// error code. Pausing a paused series keeps the first reason.
+func (r *RecurringTransaction) Pause(clock clock.Clock, reason string) {
+	if r.Paused() {
+		return
+	}
+	r.pausedAt = clock.Now()
+	r.pauseReason = reason
+	r.updatedAt = r.pausedAt
+}
+
+// Resume records the occurrences of a paused series again from now on. Those that fell due while
+// it was paused are passed over rather than recorded all at once.
+func (r *RecurringTransaction) Resume(clock clock.Clock) error {
+	if !r.Paused() {
+		return domainerror.NewConflict("recurring_transaction_not_paused", "the recurring transaction is not paused", nil)
+	}
+
+	now := clock.Now()
+	r.Materialize(now)
+	r.pausedAt = time.Time{}
+	r.pauseReason = ""
+	r.updatedAt = now
+	return nil
+}
+
+// Occurrence finds the occurrence falling on the day of date, which must not be recorded yet nor
+// more than MaxOccurrenceYearsAhead years from now, returning its position in the series and when
+// it falls
+func (r *RecurringTransaction) Occurrence(clock clock.Clock, date time.Time) (int, time.Time, error) {
+	day := dateOf(date)
+	if day.After(clock.Now().AddDate(MaxOccurrenceYearsAhead, 0, 0)) {
+		return 0, time.Time{}, domainerror.NewInvalidInput("date", "too_far_in_future", fmt.Sprintf("date must be at most %d years in the future", MaxOccurrenceYearsAhead)).
+			WithParams(map[string]string{"field": "date", "param": strconv.Itoa(MaxOccurrenceYearsAhead)})
+	}
+
+	for index, at := range r.rule.Occurrences(r.startAt) {
+		if dateOf(at).After(day) {
+			break
+		}
+		if !dateOf(at).Equal(day) {
+			continue
+		}
+		if index < r.materialized {
+			return 0, time.Time{}, domainerror.NewConflict("occurrence_recorded", "the occurrence was already recorded as a transaction", nil).
+				WithParams(map[string]string{"date": day.Format(time.DateOnly)})
+		}
+		return index, at, nil
+	}
+	return 0, time.Time{}, domainerror.NewNotFound("occurrence", day.Format(time.DateOnly))
+}
+
+// Skip keeps the occurrence falling on the day of date from being recorded. Skipping it again
+// changes nothing.
+func (r *RecurringTransaction) Skip(clock clock.Clock, date time.Time) error {
+	_, at, err := r.Occurrence(clock, date)
+	if err != nil {
+		return err
+	}
+	if r.isSkipped(at) {
+		return nil
+	}
+
+	r.skipped = append(slices.Clone(r.skipped), at)
+	slices.SortFunc(r.skipped, time.Time.Compare)
+	r.updatedAt = clock.Now()
+	return nil
+}
+
+// Update replaces what is recorded and when, leaving the series untouched when the result is
+// invalid. It only suits series with no occurrence recorded yet; later edits go through EndBefore
+// and a new series. The version is not changed here; repositories bump it when the update is stored.
+func (r *RecurringTransaction) Update(clock clock.Clock, categoryID uuid.UUID, amount float64, description string, rule RecurrenceRule) error {
+	updated := *r
+	updated.categoryID = categoryID
+	updated.amount = amount
+	updated.description = description
+	updated.rule = rule
+	updated.updatedAt = clock.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+
+	updated.nextAt = updated.next()
+	*r = updated
+	return nil
+}
+
+// EndBefore ends the series before the occurrence at index, dropping the skips that came after
+func (r *RecurringTransaction) EndBefore(clock clock.Clock, index int) {
+	r.rule.Count = index
+	r.nextAt = r.next()
+	r.skipped = slices.DeleteFunc(slices.Clone(r.skipped), func(skipped time.Time) bool {
+		return r.nextAt.IsZero() || !r.occurs(skipped)
+	})
+	r.updatedAt = clock.Now()
+}
+
+// pending yields the occurrences not recorded yet, with their position in the series
+func (r *RecurringTransaction) pending() iter.Seq2[int, time.Time] {
+	return func(yield func(int, time.Time) bool) {
+		for index, at := range r.rule.Occurrences(r.startAt) {
+			if index < r.materialized {
+				continue
+			}
+			if !yield(index, at) {
+				return
+			}
+		}
+	}
+}
+
+func (r *RecurringTransaction) next() time.Time {
+	for _, at := range r.pending() {
+		return at
+	}
+	return time.Time{}
+}
+
+// occurs reports whether at is a pending occurrence of the series
+func (r *RecurringTransaction) occurs(at time.Time) bool {
+	for _, occurrence := range r.pending() {
+		if !occurrence.Before(at) {
+			return occurrence.Equal(at)
+		}
+	}
+	return false
+}
+
+func (r *RecurringTransaction) isSkipped(at time.Time) bool {
+	return slices.ContainsFunc(r.skipped, at.Equal)
+}
+
+func (r *RecurringTransaction) validate() error {
+	if r.userID == uuid.Nil {
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
+	}
+
+	if r.categoryID == uuid.Nil {
+		return domainerror.NewValidation("categoryId", "category_id_required", "category id is required")
+	}
+
+	if r.amount <= 0 {
+		return domainerror.NewValidation("amount", "amount_must_be_positive", "amount must be greater than 0")
+	}
+
+	if r.startAt.IsZero() {
+		return domainerror.NewValidation("startAt", "start_at_required", "start is required")
+	}
+
+	return r.rule.validate(r.startAt)
+}
+
+// onDay is the given day of the month starting at first, or its last day when the month is shorter
+func onDay(first time.Time, day int) time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// dateOf is the UTC day t falls on
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
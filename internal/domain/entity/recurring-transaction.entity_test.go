@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurrenceRule(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	occurrences := func(rule RecurrenceRule, start time.Time, limit int) []time.Time {
+		var all []time.Time
+		for _, at := range rule.Occurrences(start) {
+			if len(all) == limit {
+				break
+			}
+			all = append(all, at)
+		}
+		return all
+	}
+
+	t.Run("should fall on the last day of months shorter than the start day", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1}
+
+		assert.Equal(t,
+			[]time.Time{day(2025, 1, 31), day(2025, 2, 28), day(2025, 3, 31), day(2025, 4, 30)},
+			occurrences(rule, day(2025, 1, 31), 4))
+	})
+
+	t.Run("should start on the next day of month at or after the start", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: 5}
+
+		assert.Equal(t,
+			[]time.Time{day(2025, 2, 5), day(2025, 3, 5)},
+			occurrences(rule, day(2025, 1, 10), 2))
+	})
+
+	t.Run("should fall on the last weekday of the month", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1, LastBusinessDay: true}
+
+		assert.Equal(t,
+			[]time.Time{day(2025, 5, 30), day(2025, 6, 30), day(2025, 7, 31), day(2025, 8, 29)},
+			occurrences(rule, day(2025, 5, 1), 4))
+	})
+
+	t.Run("should repeat every interval until the count is reached", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyWeekly, Interval: 2, Count: 3}
+
+		assert.Equal(t,
+			[]time.Time{day(2025, 3, 3), day(2025, 3, 17), day(2025, 3, 31)},
+			occurrences(rule, day(2025, 3, 3), 10))
+	})
+
+	t.Run("should include the until day", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyDaily, Interval: 1, Until: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)}
+
+		assert.Equal(t,
+			[]time.Time{day(2025, 3, 1), day(2025, 3, 2), day(2025, 3, 3)},
+			occurrences(rule, day(2025, 3, 1), 10))
+	})
+
+	t.Run("should keep leap days on leap years only", func(t *testing.T) {
+		rule := RecurrenceRule{Frequency: enum.RecurrenceFrequencyYearly, Interval: 1}
+
+		assert.Equal(t,
+			[]time.Time{day(2024, 2, 29), day(2025, 2, 28), day(2026, 2, 28), day(2027, 2, 28), day(2028, 2, 29)},
+			occurrences(rule, day(2024, 2, 29), 5))
+	})
+
+	t.Run("should reject inconsistent rules", func(t *testing.T) {
+		start := day(2025, 3, 1)
+		cases := map[string]RecurrenceRule{
+			"recurrence_frequency_invalid":         {Frequency: "hourly", Interval: 1},
+			"recurrence_interval_invalid":          {Frequency: enum.RecurrenceFrequencyDaily},
+			"recurrence_day_of_month_invalid":      {Frequency: enum.RecurrenceFrequencyWeekly, Interval: 1, DayOfMonth: 3},
+			"recurrence_last_business_day_invalid": {Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: 3, LastBusinessDay: true},
+			"recurrence_count_invalid":             {Frequency: enum.RecurrenceFrequencyDaily, Interval: 1, Count: -1},
+			"recurrence_until_invalid":             {Frequency: enum.RecurrenceFrequencyDaily, Interval: 1, Until: day(2025, 2, 1)},
+		}
+
+		for code, rule := range cases {
+			err := rule.validate(start)
+			domainErr, ok := domainerror.As(err)
+			if assert.True(t, ok, code) {
+				assert.Equal(t, code, domainErr.Code)
+			}
+		}
+	})
+}
+
+func TestRecurringTransaction(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000001")
+	categoryID := uuid.MustParse("0195a1b2-0000-7000-8000-000000000002")
+	monthly := RecurrenceRule{Frequency: enum.RecurrenceFrequencyMonthly, Interval: 1}
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 8, 0, 0, 0, time.UTC)
+	}
+
+	newRecurring := func(fixedClock *clock.Fixed, rule RecurrenceRule) *RecurringTransaction {
+		recurring, err := NewRecurringTransaction(fixedClock, identifier.NewV7(), userID, categoryID, 1200, "Rent", at(1, 10), rule)
+		assert.Nil(t, err)
+		return recurring
+	}
+
+	t.Run("should materialize due occurrences once, leaving out skipped ones", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		recurring := newRecurring(fixedClock, monthly)
+		assert.Equal(t, at(1, 10), recurring.NextAt())
+
+		assert.Nil(t, recurring.Skip(fixedClock, date(2, 10)))
+		assert.Equal(t, []time.Time{at(1, 10)}, recurring.Materialize(now))
+		assert.Equal(t, 2, recurring.Materialized())
+		assert.Equal(t, at(3, 10), recurring.NextAt())
+		assert.Empty(t, recurring.Skipped())
+
+		assert.Empty(t, recurring.Materialize(now))
+		assert.Equal(t, []time.Time{at(3, 10)}, recurring.Materialize(at(3, 10)))
+	})
+
+	t.Run("should list upcoming occurrences with their skips", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		recurring := newRecurring(fixedClock, monthly)
+		recurring.Materialize(now)
+
+		assert.Nil(t, recurring.Skip(fixedClock, date(4, 10)))
+		assert.Nil(t, recurring.Skip(fixedClock, date(4, 10)))
+
+		assert.Equal(t, []RecurringOccurrence{
+			{At: at(3, 10)},
+			{At: at(4, 10), Skipped: true},
+			{At: at(5, 10)},
+		}, recurring.Upcoming(3))
+	})
+
+	t.Run("should only skip pending occurrences", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		recurring := newRecurring(fixedClock, monthly)
+		recurring.Materialize(now)
+
+		err := recurring.Skip(fixedClock, date(1, 10))
+		assert.True(t, domainerror.IsKind(err, domainerror.KindConflict))
+
+		err = recurring.Skip(fixedClock, date(3, 11))
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+	})
+
+	t.Run("should not look up occurrences too far ahead", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		daily := RecurrenceRule{Frequency: enum.RecurrenceFrequencyDaily, Interval: 1}
+		recurring := newRecurring(fixedClock, daily)
+
+		_, _, err := recurring.Occurrence(fixedClock, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, domainerror.KindInvalidInput, domainErr.Kind)
+		assert.Equal(t, "too_far_in_future", domainErr.Code)
+	})
+
+	t.Run("should end before an occurrence, dropping later skips", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		recurring := newRecurring(fixedClock, monthly)
+		recurring.Materialize(now)
+		assert.Nil(t, recurring.Skip(fixedClock, date(3, 10)))
+		assert.Nil(t, recurring.Skip(fixedClock, date(5, 10)))
+
+		index, _, err := recurring.Occurrence(fixedClock, date(4, 10))
+		assert.Nil(t, err)
+		recurring.EndBefore(fixedClock, index)
+
+		assert.Equal(t, 3, recurring.Rule().Count)
+		assert.Equal(t, []time.Time{at(3, 10)}, recurring.Skipped())
+		assert.Len(t, recurring.Upcoming(10), 1)
+
+		recurring.Materialize(at(12, 31))
+		assert.True(t, recurring.Ended())
+	})
+
+	t.Run("should keep the series when an update is invalid", func(t *testing.T) {
+		fixedClock := clock.NewFixed(now)
+		recurring := newRecurring(fixedClock, monthly)
+
+		err := recurring.Update(fixedClock, categoryID, 0, "Rent", monthly)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+		assert.Equal(t, 1200.0, recurring.Amount())
+
+		weekly := RecurrenceRule{Frequency: enum.RecurrenceFrequencyWeekly, Interval: 1}
+		assert.Nil(t, recurring.Update(fixedClock, categoryID, 1300, "Rent", weekly))
+		assert.Equal(t, at(1, 10), recurring.NextAt())
+		assert.Equal(t, at(1, 17), recurring.Upcoming(2)[1].At)
+	})
+}
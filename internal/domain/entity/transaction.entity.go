@@ -1,9 +1,11 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 )
 
@@ -14,8 +16,11 @@ type Transaction struct {
 	amount      float64
 	datetime    time.Time
 	description string
+	externalID  string
+	version     int64
 	createdAt   time.Time
 	updatedAt   time.Time
+	deletedAt   time.Time
 }
 
 func (t *Transaction) ID() uuid.UUID         { return t.id }
@@ -24,10 +29,28 @@ func (t *Transaction) UserID() uuid.UUID     { return t.userID }
 func (t *Transaction) Amount() float64       { return t.amount }
 func (t *Transaction) Datetime() time.Time   { return t.datetime }
 func (t *Transaction) Description() string   { return t.description }
+func (t *Transaction) ExternalID() string    { return t.externalID }
+func (t *Transaction) Version() int64        { return t.version }
 func (t *Transaction) CreatedAt() time.Time  { return t.createdAt }
 func (t *Transaction) UpdatedAt() time.Time  { return t.updatedAt }
+func (t *Transaction) DeletedAt() time.Time  { return t.deletedAt }
+func (t *Transaction) Trashed() bool         { return !t.deletedAt.IsZero() }
 
-func NewTransaction(id uuid.UUID, categoryID uuid.UUID, userID uuid.UUID, amount float64, datetime time.Time, description string, createdAt time.Time, updatedAt time.Time) (*Transaction, error) {
+func NewTransaction(clock clock.Clock, ids identifier.Generator, categoryID uuid.UUID, userID uuid.UUID, amount float64, datetime time.Time, description string) (*Transaction, error) {
+	return NewImportedTransaction(clock, ids, categoryID, userID, amount, datetime, description, "")
+}
+
+// NewImportedTransaction creates a transaction read from a bank statement, keeping the id the
+// bank gave the entry so importing the statement again does not record it twice. An empty
+// externalID creates an ordinary transaction.
+func NewImportedTransaction(clock clock.Clock, ids identifier.Generator, categoryID uuid.UUID, userID uuid.UUID, amount float64, datetime time.Time, description string, externalID string) (*Transaction, error) {
+	now := clock.Now()
+	return RestoreTransaction(ids.NewID(), categoryID, userID, amount, datetime, description, externalID, 1, now, now, time.Time{})
+}
+
+// RestoreTransaction rebuilds a transaction that already exists, keeping its identity, version and
+// timestamps. A zero deletedAt means the transaction is not in the trash.
+func RestoreTransaction(id uuid.UUID, categoryID uuid.UUID, userID uuid.UUID, amount float64, datetime time.Time, description string, externalID string, version int64, createdAt time.Time, updatedAt time.Time, deletedAt time.Time) (*Transaction, error) {
 	transaction := &Transaction{
 		id:          id,
 		categoryID:  categoryID,
@@ -35,8 +58,11 @@ func NewTransaction(id uuid.UUID, categoryID uuid.UUID, userID uuid.UUID, amount
 		amount:      amount,
 		datetime:    datetime,
 		description: description,
+		externalID:  externalID,
+		version:     version,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
 	}
 
 	err := transaction.validate()
@@ -47,21 +73,63 @@ func NewTransaction(id uuid.UUID, categoryID uuid.UUID, userID uuid.UUID, amount
 	return transaction, nil
 }
 
+// Update replaces the editable fields, leaving the transaction untouched when the result is invalid.
+// The version is not changed here; repositories bump it when the update is stored.
+func (t *Transaction) Update(clock clock.Clock, categoryID uuid.UUID, amount float64, datetime time.Time, description string) error {
+	updated := *t
+	updated.categoryID = categoryID
+	updated.amount = amount
+	updated.datetime = datetime
+	updated.description = description
+	updated.updatedAt = clock.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+
+	*t = updated
+	return nil
+}
+
+// Trash moves the transaction to the trash bin, from which it can be taken out until it is purged
+func (t *Transaction) Trash(clock clock.Clock) {
+	now := clock.Now()
+	t.deletedAt = now
+	t.updatedAt = now
+}
+
+// Untrash takes the transaction out of the trash bin
+func (t *Transaction) Untrash(clock clock.Clock) {
+	t.deletedAt = time.Time{}
+	t.updatedAt = clock.Now()
+}
+
+// AuditFields is the state of the transaction kept in its audit trail
+func (t *Transaction) AuditFields() AuditFields {
+	return AuditFields{
+		"categoryId":  t.categoryID.String(),
+		"amount":      t.amount,
+		"datetime":    auditTime(t.datetime),
+		"description": t.description,
+		"deletedAt":   auditTime(t.deletedAt),
+	}
+}
+
 func (t *Transaction) validate() error {
 	if t.categoryID == uuid.Nil {
-		return errors.New("category id is required")
+		return domainerror.NewValidation("categoryId", "category_id_required", "category id is required")
 	}
 
 	if t.userID == uuid.Nil {
-		return errors.New("user id is required")
+		return domainerror.NewValidation("userId", "user_id_required", "user id is required")
 	}
 
 	if t.amount <= 0 {
-		return errors.New("amount must be greater than 0")
+		return domainerror.NewValidation("amount", "amount_must_be_positive", "amount must be greater than 0")
 	}
 
 	if t.datetime.IsZero() {
-		return errors.New("datetime is required")
+		return domainerror.NewValidation("datetime", "datetime_required", "datetime is required")
 	}
 
 	return nil
@@ -4,35 +4,42 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewTransaction(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	fixedClock := clock.NewFixed(now)
+
 	t.Run("should create a new transaction successfully", func(t *testing.T) {
+		id := uuid.MustParse("01959a2b-7c00-7000-8000-000000000001")
 		categoryID := uuid.New()
 		userID := uuid.New()
 		amount := 100.0
-		datetime := time.Now()
+		datetime := now.Add(-time.Hour)
 		description := "Grocery shopping"
 
-		transaction, err := NewTransaction(categoryID, userID, uuid.Nil, amount, datetime, description, time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewFixed(id), categoryID, userID, amount, datetime, description)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, transaction)
-		assert.NotEqual(t, uuid.Nil, transaction.ID())
+		assert.Equal(t, id, transaction.ID())
 		assert.Equal(t, categoryID, transaction.CategoryID())
 		assert.Equal(t, userID, transaction.UserID())
 		assert.Equal(t, amount, transaction.Amount())
 		assert.Equal(t, datetime, transaction.Datetime())
 		assert.Equal(t, description, transaction.Description())
-		assert.False(t, transaction.CreatedAt().IsZero())
-		assert.False(t, transaction.UpdatedAt().IsZero())
+		assert.Equal(t, now, transaction.CreatedAt())
+		assert.Equal(t, now, transaction.UpdatedAt())
 	})
 
 	t.Run("should return error when category id is not provided", func(t *testing.T) {
 		userID := uuid.New()
-		transaction, err := NewTransaction(uuid.Nil, userID, uuid.Nil, 100.0, time.Now(), "description", time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), uuid.Nil, userID, 100.0, now, "description")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
@@ -41,7 +48,7 @@ func TestNewTransaction(t *testing.T) {
 
 	t.Run("should return error when user id is not provided", func(t *testing.T) {
 		categoryID := uuid.New()
-		transaction, err := NewTransaction(categoryID, uuid.Nil, uuid.Nil, 100.0, time.Now(), "description", time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), categoryID, uuid.Nil, 100.0, now, "description")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
@@ -52,21 +59,26 @@ func TestNewTransaction(t *testing.T) {
 		categoryID := uuid.New()
 		userID := uuid.New()
 
-		transaction, err := NewTransaction(categoryID, userID, uuid.Nil, 0, time.Now(), "description", time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), categoryID, userID, 0, now, "description")
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
 		assert.Equal(t, "amount must be greater than 0", err.Error())
 
-		transaction, err = NewTransaction(categoryID, userID, uuid.Nil, -10.0, time.Now(), "description", time.Time{}, time.Time{})
+		transaction, err = NewTransaction(fixedClock, identifier.NewV7(), categoryID, userID, -10.0, now, "description")
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
 		assert.Equal(t, "amount must be greater than 0", err.Error())
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, domainerror.KindValidation, domainErr.Kind)
+		assert.Equal(t, "amount", domainErr.Field)
 	})
 
 	t.Run("should return error when datetime is zero", func(t *testing.T) {
 		categoryID := uuid.New()
 		userID := uuid.New()
-		transaction, err := NewTransaction(categoryID, userID, uuid.Nil, 100.0, time.Time{}, "description", time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), categoryID, userID, 100.0, time.Time{}, "description")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
@@ -76,10 +88,94 @@ func TestNewTransaction(t *testing.T) {
 	t.Run("should create transaction with empty description", func(t *testing.T) {
 		categoryID := uuid.New()
 		userID := uuid.New()
-		transaction, err := NewTransaction(categoryID, userID, uuid.Nil, 100.0, time.Now(), "", time.Time{}, time.Time{})
+		transaction, err := NewTransaction(fixedClock, identifier.NewV7(), categoryID, userID, 100.0, now, "")
 
 		assert.Nil(t, err)
 		assert.NotNil(t, transaction)
 		assert.Equal(t, "", transaction.Description())
 	})
+
+	t.Run("should generate time-ordered ids by default", func(t *testing.T) {
+		ids := identifier.NewV7()
+		first, err := NewTransaction(fixedClock, ids, uuid.New(), uuid.New(), 100.0, now, "first")
+		assert.Nil(t, err)
+		second, err := NewTransaction(fixedClock, ids, uuid.New(), uuid.New(), 100.0, now, "second")
+		assert.Nil(t, err)
+
+		assert.Equal(t, uuid.Version(7), first.ID().Version())
+		assert.Less(t, first.ID().String(), second.ID().String())
+	})
+}
+
+func TestRestoreTransaction(t *testing.T) {
+	t.Run("should keep the stored identity, version and timestamps", func(t *testing.T) {
+		id := uuid.New()
+		createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		updatedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		transaction, err := RestoreTransaction(id, uuid.New(), uuid.New(), 50.0, createdAt, "rent", "", 7, createdAt, updatedAt, time.Time{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, id, transaction.ID())
+		assert.Equal(t, int64(7), transaction.Version())
+		assert.False(t, transaction.Trashed())
+		assert.Equal(t, createdAt, transaction.CreatedAt())
+		assert.Equal(t, updatedAt, transaction.UpdatedAt())
+	})
+}
+
+func TestTransactionUpdate(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	newTransaction := func() *Transaction {
+		transaction, _ := NewTransaction(clock.NewFixed(createdAt), identifier.NewV7(), uuid.New(), uuid.New(), 100.0, createdAt, "rent")
+		return transaction
+	}
+
+	t.Run("should replace the editable fields and keep the version", func(t *testing.T) {
+		transaction := newTransaction()
+		categoryID := uuid.New()
+
+		err := transaction.Update(clock.NewFixed(updatedAt), categoryID, 120.0, updatedAt, "rent and fees")
+
+		assert.Nil(t, err)
+		assert.Equal(t, categoryID, transaction.CategoryID())
+		assert.Equal(t, 120.0, transaction.Amount())
+		assert.Equal(t, "rent and fees", transaction.Description())
+		assert.Equal(t, createdAt, transaction.CreatedAt())
+		assert.Equal(t, updatedAt, transaction.UpdatedAt())
+		assert.Equal(t, int64(1), transaction.Version())
+	})
+
+	t.Run("should leave the transaction untouched when the update is invalid", func(t *testing.T) {
+		transaction := newTransaction()
+
+		err := transaction.Update(clock.NewFixed(updatedAt), transaction.CategoryID(), 0, updatedAt, "free")
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindValidation))
+		assert.Equal(t, 100.0, transaction.Amount())
+		assert.Equal(t, "rent", transaction.Description())
+		assert.Equal(t, createdAt, transaction.UpdatedAt())
+	})
+}
+
+func TestTransactionTrash(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	trashedAt := createdAt.Add(time.Hour)
+
+	t.Run("should move the transaction in and out of the trash", func(t *testing.T) {
+		transaction, _ := NewTransaction(clock.NewFixed(createdAt), identifier.NewV7(), uuid.New(), uuid.New(), 100.0, createdAt, "rent")
+
+		transaction.Trash(clock.NewFixed(trashedAt))
+
+		assert.True(t, transaction.Trashed())
+		assert.Equal(t, trashedAt, transaction.DeletedAt())
+		assert.Equal(t, trashedAt, transaction.UpdatedAt())
+
+		transaction.Untrash(clock.NewFixed(trashedAt.Add(time.Hour)))
+
+		assert.False(t, transaction.Trashed())
+		assert.Equal(t, trashedAt.Add(time.Hour), transaction.UpdatedAt())
+	})
 }
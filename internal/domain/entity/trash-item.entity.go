@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+// TrashItem is a transaction or category in the trash bin, as listed to its owner
+type TrashItem struct {
+	kind      enum.TrashItemKind
+	id        uuid.UUID
+	userID    uuid.UUID
+	name      string
+	deletedAt time.Time
+}
+
+func (i *TrashItem) Kind() enum.TrashItemKind { return i.kind }
+func (i *TrashItem) ID() uuid.UUID            { return i.id }
+func (i *TrashItem) UserID() uuid.UUID        { return i.userID }
+func (i *TrashItem) Name() string             { return i.name }
+func (i *TrashItem) DeletedAt() time.Time     { return i.deletedAt }
+
+// RestoreTrashItem rebuilds a trash listing entry from the trashed record. name is the category
+// name or the transaction description.
+func RestoreTrashItem(kind enum.TrashItemKind, id uuid.UUID, userID uuid.UUID, name string, deletedAt time.Time) *TrashItem {
+	return &TrashItem{
+		kind:      kind,
+		id:        id,
+		userID:    userID,
+		name:      name,
+		deletedAt: deletedAt,
+	}
+}
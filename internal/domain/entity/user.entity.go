@@ -1,10 +1,12 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 )
 
@@ -28,16 +30,17 @@ func (u *User) Status() enum.UserStatus { return u.status }
 func (u *User) CreatedAt() time.Time    { return u.createdAt }
 func (u *User) UpdatedAt() time.Time    { return u.updatedAt }
 
-func NewUser(keycloakID string, name string, email string, username string, status enum.UserStatus) (*User, error) {
+func NewUser(clock clock.Clock, ids identifier.Generator, keycloakID string, name string, email string, username string, status enum.UserStatus) (*User, error) {
+	now := clock.Now()
 	user := &User{
-		id:         uuid.New(),
+		id:         ids.NewID(),
 		keycloakID: keycloakID,
 		name:       name,
 		email:      email,
 		username:   username,
 		status:     status,
-		createdAt:  time.Now(),
-		updatedAt:  time.Now(),
+		createdAt:  now,
+		updatedAt:  now,
 	}
 
 	err := user.validate()
@@ -50,23 +53,23 @@ func NewUser(keycloakID string, name string, email string, username string, stat
 
 func (u *User) validate() error {
 	if u.keycloakID == "" {
-		return errors.New("keycloak id is required")
+		return domainerror.NewValidation("keycloakId", "keycloak_id_required", "keycloak id is required")
 	}
 
 	if u.name == "" {
-		return errors.New("name is required")
+		return domainerror.NewValidation("name", "name_required", "name is required")
 	}
 
 	if u.email == "" {
-		return errors.New("email is required")
+		return domainerror.NewValidation("email", "email_required", "email is required")
 	}
 
 	if u.username == "" {
-		return errors.New("username is required")
+		return domainerror.NewValidation("username", "username_required", "username is required")
 	}
 
 	if u.status != enum.UserStatusPending && u.status != enum.UserStatusActive && u.status != enum.UserStatusInactive {
-		return errors.New("invalid status")
+		return domainerror.NewValidation("status", "user_status_invalid", "invalid status")
 	}
 
 	return nil
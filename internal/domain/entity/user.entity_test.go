@@ -2,30 +2,37 @@ package entity
 
 import (
 	"testing"
+	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewUser(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	fixedClock := clock.NewFixed(now)
+
 	t.Run("should create a new user successfully", func(t *testing.T) {
-		user, err := NewUser("keycloak-123", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
+		id := uuid.MustParse("01959a2b-7c00-7000-8000-000000000003")
+		user, err := NewUser(fixedClock, identifier.NewFixed(id), "keycloak-123", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, user)
-		assert.NotEqual(t, uuid.Nil, user.ID())
+		assert.Equal(t, id, user.ID())
 		assert.Equal(t, "keycloak-123", user.KeycloakID())
 		assert.Equal(t, "John Doe", user.Name())
 		assert.Equal(t, "john@example.com", user.Email())
 		assert.Equal(t, "johndoe", user.Username())
 		assert.Equal(t, enum.UserStatusActive, user.Status())
-		assert.False(t, user.CreatedAt().IsZero())
-		assert.False(t, user.UpdatedAt().IsZero())
+		assert.Equal(t, now, user.CreatedAt())
+		assert.Equal(t, now, user.UpdatedAt())
 	})
 
 	t.Run("should return error when keycloak id is not provided", func(t *testing.T) {
-		user, err := NewUser("", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
+		user, err := NewUser(fixedClock, identifier.NewV7(), "", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
@@ -33,7 +40,7 @@ func TestNewUser(t *testing.T) {
 	})
 
 	t.Run("should return error when keycloak id is not provided", func(t *testing.T) {
-		user, err := NewUser("", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
+		user, err := NewUser(fixedClock, identifier.NewV7(), "", "John Doe", "john@example.com", "johndoe", enum.UserStatusActive)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
@@ -41,7 +48,7 @@ func TestNewUser(t *testing.T) {
 	})
 
 	t.Run("should return error when name is not provided", func(t *testing.T) {
-		user, err := NewUser("keycloak-123", "", "john@example.com", "johndoe", enum.UserStatusActive)
+		user, err := NewUser(fixedClock, identifier.NewV7(), "keycloak-123", "", "john@example.com", "johndoe", enum.UserStatusActive)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
@@ -49,7 +56,7 @@ func TestNewUser(t *testing.T) {
 	})
 
 	t.Run("should return error when email is not provided", func(t *testing.T) {
-		user, err := NewUser("keycloak-123", "John Doe", "", "johndoe", enum.UserStatusActive)
+		user, err := NewUser(fixedClock, identifier.NewV7(), "keycloak-123", "John Doe", "", "johndoe", enum.UserStatusActive)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
@@ -57,7 +64,7 @@ func TestNewUser(t *testing.T) {
 	})
 
 	t.Run("should return error when username is not provided", func(t *testing.T) {
-		user, err := NewUser("keycloak-123", "John Doe", "john@example.com", "", enum.UserStatusActive)
+		user, err := NewUser(fixedClock, identifier.NewV7(), "keycloak-123", "John Doe", "john@example.com", "", enum.UserStatusActive)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
@@ -65,7 +72,7 @@ func TestNewUser(t *testing.T) {
 	})
 
 	t.Run("should return error when status is invalid", func(t *testing.T) {
-		user, err := NewUser("keycloak-123", "John Doe", "john@example.com", "johndoe", "invalid")
+		user, err := NewUser(fixedClock, identifier.NewV7(), "keycloak-123", "John Doe", "john@example.com", "johndoe", "invalid")
 
 		assert.NotNil(t, err)
 		assert.Nil(t, user)
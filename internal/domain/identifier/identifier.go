@@ -0,0 +1,50 @@
+package identifier
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Generator creates identifiers for new entities
+type Generator interface {
+	NewID() uuid.UUID
+}
+
+type v7Generator struct{}
+
+// NewV7 returns a Generator of time-ordered UUIDv7 values, which keep primary key inserts
+// appending to the end of the index instead of scattering across it
+func NewV7() Generator {
+	return v7Generator{}
+}
+
+func (v7Generator) NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// Fixed hands out a predefined list of identifiers in order, used by tests and fixtures
+type Fixed struct {
+	mu   sync.Mutex
+	ids  []uuid.UUID
+	next int
+}
+
+// NewFixed creates a Generator that returns ids in order and panics once they run out
+func NewFixed(ids ...uuid.UUID) *Fixed {
+	return &Fixed{ids: ids}
+}
+
+func (g *Fixed) NewID() uuid.UUID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next >= len(g.ids) {
+		panic(fmt.Sprintf("identifier: fixed generator exhausted after %d ids", len(g.ids)))
+	}
+
+	id := g.ids[g.next]
+	g.next++
+	return id
+}
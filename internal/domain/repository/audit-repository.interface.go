@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+// AuditRepositoryInterface stores the audit trail. It is append-only: entries are never
+// updated or removed, not even when the record they describe is purged.
+type AuditRepositoryInterface interface {
+	Append(ctx context.Context, entry *entity.AuditEntry) error
+	// FindAllPaginated lists the entries of a record made by the user, oldest first
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, paginate *pagination.Pagination) ([]entity.AuditEntry, error)
+}
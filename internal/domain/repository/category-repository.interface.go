@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+// CategoryRepositoryInterface stores categories. Finders skip trashed categories unless their
+// name says otherwise.
+type CategoryRepositoryInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Category, error)
+	// FindAllByUserID returns every category of the user, ordered by name
+	FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Category, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Category, error)
+	FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Category, error)
+	Create(ctx context.Context, category *entity.Category) (*entity.Category, error)
+	// Update stores category, trash state included, if the stored version still matches
+	// category.Version(), returning it with the bumped version
+	Update(ctx context.Context, category *entity.Category) (*entity.Category, error)
+	// PurgeTrashed permanently removes the categories trashed before the given time
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/google/uuid"
+)
+
+// VersionMismatch reports a change based on a version of the resource that is no longer the stored one
+func VersionMismatch(resource string, id uuid.UUID) *domainerror.Error {
+	return domainerror.NewPreconditionFailed("version_mismatch", fmt.Sprintf("%s %s was modified since it was read", resource, id)).
+		WithParams(map[string]string{"resource": resource, "id": id.String()})
+}
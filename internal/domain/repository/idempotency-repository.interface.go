@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type IdempotencyRepositoryInterface interface {
+	// Reserve stores record, unless the user already holds an unexpired record with the same
+	// key, which is returned instead and left untouched
+	Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error)
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+	// Release forgets a reservation, so the request can be retried from scratch
+	Release(ctx context.Context, userID uuid.UUID, key string) error
+	// DeleteExpired removes the records that expired before now
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
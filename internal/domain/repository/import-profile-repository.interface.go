@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+// ImportProfileRepositoryInterface stores the import profiles of users. Names are unique per user.
+type ImportProfileRepositoryInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.ImportProfile, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error)
+	Create(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error)
+	Update(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type InstallmentPurchaseRepositoryInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.InstallmentPurchase, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.InstallmentPurchase, error)
+	Create(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error)
+	// Update stores purchase if the stored version still matches purchase.Version(), returning it
+	// with the bumped version
+	Update(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// JobRepositoryInterface stores background jobs. Workers claim a job by updating the version
+// they read, so only one of them wins a job they both found due.
+type JobRepositoryInterface interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Job, error)
+	// FindNextDue returns the job due the longest at now, either queued or running under a lease
+	// that has expired, or nil when no job is due
+	FindNextDue(ctx context.Context, now time.Time) (*entity.Job, error)
+	// FindFinishedBefore returns up to limit jobs that finished before the given time
+	FindFinishedBefore(ctx context.Context, before time.Time, limit int) ([]entity.Job, error)
+	Create(ctx context.Context, job *entity.Job) (*entity.Job, error)
+	// Update stores job if the stored version still matches job.Version(), returning it with the
+	// bumped version
+	Update(ctx context.Context, job *entity.Job) (*entity.Job, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+// RecurringTransactionRepositoryInterface stores recurring transactions. Writes are conditional on
+// the version that was read, so materializing a series never races an edit made meanwhile.
+type RecurringTransactionRepositoryInterface interface {
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.RecurringTransaction, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.RecurringTransaction, error)
+	// FindDue returns up to limit series, ordered by id and after the given one, whose next
+	// occurrence falls at or before now
+	FindDue(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]entity.RecurringTransaction, error)
+	Create(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error)
+	// Update stores recurring if the stored version still matches recurring.Version(), returning
+	// it with the bumped version
+	Update(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error)
+	// Delete removes the series if the stored version still matches version
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
+}
@@ -1,11 +1,40 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
 )
 
+// TransactionRepositoryInterface stores transactions. Finders skip trashed transactions unless
+// their name says otherwise.
 type TransactionRepositoryInterface interface {
-	FindAllPaginated(paginate *pagination.Pagination) ([]entity.Transaction, error)
-	Create(transaction *entity.Transaction) (*entity.Transaction, error)
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Transaction, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
+	FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
+	// FindAllBetween returns the transactions of the user dated from from up to, but not including, to
+	FindAllBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.Transaction, error)
+	// Stream calls fn with every transaction of the user, oldest first, reading them as fn goes
+	// rather than loading them all at once. It stops at the first error fn returns.
+	Stream(ctx context.Context, userID uuid.UUID, fn func(transaction *entity.Transaction) error) error
+	// FindExternalIDs returns which of the given external ids are already carried by transactions
+	// of the user, trashed ones included
+	FindExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) ([]string, error)
+	Create(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error)
+	// CreateBatch stores new transactions with as few round trips as possible, all or none of them
+	CreateBatch(ctx context.Context, transactions []*entity.Transaction) error
+	// Update stores transaction, trash state included, if the stored version still matches
+	// transaction.Version(), returning it with the bumped version
+	Update(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error)
+	// TrashByCategory moves the transactions of a category that are not trashed yet to the trash,
+	// stamping them with the deletedAt of the category, and returns their ids
+	TrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time) ([]uuid.UUID, error)
+	// UntrashByCategory takes out of the trash the transactions trashed along with their category,
+	// recognized by sharing its deletedAt, and returns their ids
+	UntrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time, updatedAt time.Time) ([]uuid.UUID, error)
+	// PurgeTrashed permanently removes the transactions trashed before the given time
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
 }
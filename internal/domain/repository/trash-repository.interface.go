@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+)
+
+type TrashRepositoryInterface interface {
+	// FindAllPaginated lists the trashed transactions and categories of the user, most recently
+	// trashed first
+	FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.TrashItem, error)
+}
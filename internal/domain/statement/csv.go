@@ -0,0 +1,251 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+)
+
+// utf8BOM is written at the start of CSV files by some spreadsheet programs
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Line is a data row of a statement, numbered as in the file. Amount is signed, with
+// ImportSettings.NegateAmounts already applied. ExternalID is the id the bank gave the entry,
+// when the format has one. When the row cannot be read, Err holds one detail per offending
+// field and the other fields hold whatever could be read.
+type Line struct {
+	Number      int
+	Raw         []string
+	Date        time.Time
+	Amount      float64
+	Description string
+	Category    string
+	ExternalID  string
+	Err         error
+}
+
+// ParseCSV reads the rows of a CSV statement as described by settings, which must be valid.
+// Files that are not UTF-8 are read as Latin-1, the encoding most Brazilian banks export in.
+// An error is returned only when the file as a whole cannot be read; rows that cannot be read
+// are returned with their Err set.
+func ParseCSV(content []byte, settings entity.ImportSettings, maxRows int) ([]Line, error) {
+	reader := csv.NewReader(bytes.NewReader(decode(content)))
+	reader.Comma, _ = utf8.DecodeRuneInString(settings.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var columns *columnIndex
+	var lines []Line
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseError *csv.ParseError
+			line := 0
+			if errors.As(err, &parseError) {
+				line = parseError.Line
+			}
+			return nil, domainerror.NewInvalidInput("file", "csv_malformed", "file is not a valid CSV").
+				WithParams(map[string]string{"line": strconv.Itoa(line)})
+		}
+		if blank(record) {
+			continue
+		}
+
+		if columns == nil {
+			columns, err = newColumnIndex(settings, record)
+			if err != nil {
+				return nil, err
+			}
+			if settings.HasHeader {
+				continue
+			}
+		}
+
+		if len(lines) == maxRows {
+			return nil, domainerror.NewTooLarge("too_many_rows", "the file has more rows than can be imported at once").
+				WithParams(map[string]string{"max": strconv.Itoa(maxRows)})
+		}
+
+		number, _ := reader.FieldPos(0)
+		lines = append(lines, columns.read(number, record, settings))
+	}
+
+	if len(lines) == 0 {
+		return nil, domainerror.NewInvalidInput("file", "csv_empty", "file has no rows to import")
+	}
+
+	return lines, nil
+}
+
+// decode returns content as UTF-8 without a byte order mark
+func decode(content []byte) []byte {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if utf8.Valid(content) {
+		return content
+	}
+
+	decoded := make([]rune, len(content))
+	for i, b := range content {
+		decoded[i] = rune(b)
+	}
+	return []byte(string(decoded))
+}
+
+func blank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// columnIndex holds the position of each mapped column, -1 for unmapped optional ones
+type columnIndex struct {
+	date, amount, description, category int
+}
+
+// newColumnIndex resolves the mapped columns against the header row, or as positions when the
+// file has none
+func newColumnIndex(settings entity.ImportSettings, header []string) (*columnIndex, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, seen := positions[name]; !seen {
+			positions[name] = i
+		}
+	}
+
+	resolve := func(column string) (int, error) {
+		if column == "" {
+			return -1, nil
+		}
+		if !settings.HasHeader {
+			position, _ := strconv.Atoi(column)
+			return position - 1, nil
+		}
+		position, found := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !found {
+			return 0, domainerror.NewValidation("file", "column_not_found", "the file has no column named "+column).
+				WithParams(map[string]string{"column": column})
+		}
+		return position, nil
+	}
+
+	var index columnIndex
+	var err error
+	for _, column := range []struct {
+		name     string
+		position *int
+	}{
+		{settings.Columns.Date, &index.date},
+		{settings.Columns.Amount, &index.amount},
+		{settings.Columns.Description, &index.description},
+		{settings.Columns.Category, &index.category},
+	} {
+		if *column.position, err = resolve(column.name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &index, nil
+}
+
+func (c *columnIndex) read(number int, record []string, settings entity.ImportSettings) Line {
+	line := Line{Number: number, Raw: record}
+	invalid := domainerror.NewValidation("", "row_invalid", "row cannot be imported")
+
+	value := func(field string, position int) (string, bool) {
+		if position < 0 {
+			return "", true
+		}
+		if position >= len(record) {
+			invalid.WithDetails(domainerror.NewValidation(field, "column_missing", "row has no value for "+field).
+				WithParams(map[string]string{"field": field}))
+			return "", false
+		}
+		return strings.TrimSpace(record[position]), true
+	}
+
+	if date, ok := value("date", c.date); ok {
+		parsed, err := time.Parse(settings.DateLayout(), date)
+		if err != nil {
+			invalid.WithDetails(domainerror.NewValidation("date", "date_invalid", "date does not match the format "+settings.DateFormat).
+				WithParams(map[string]string{"value": date, "format": settings.DateFormat}))
+		}
+		line.Date = parsed
+	}
+
+	if amount, ok := value("amount", c.amount); ok {
+		parsed, err := ParseAmount(amount, settings.DecimalComma)
+		if err != nil {
+			invalid.WithDetails(domainerror.NewValidation("amount", "amount_invalid", "amount is not a number").
+				WithParams(map[string]string{"value": amount}))
+		}
+		if settings.NegateAmounts {
+			parsed = -parsed
+		}
+		line.Amount = parsed
+	}
+
+	line.Description, _ = value("description", c.description)
+	line.Category, _ = value("category", c.category)
+
+	if len(invalid.Details) > 0 {
+		line.Err = invalid
+	}
+	return line
+}
+
+// ParseAmount reads a signed amount as written in bank statements, such as "-1.234,56",
+// "R$ 10,00" or "(12.50)", rounded to cents. With decimalComma the comma separates the
+// decimals and dots group thousands; otherwise it is the other way around.
+func ParseAmount(value string, decimalComma bool) (float64, error) {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', 'R', '$':
+			return -1
+		}
+		return r
+	}, value)
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+	if trimmed, found := strings.CutSuffix(value, "-"); found {
+		negative = !negative
+		value = trimmed
+	}
+
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, errors.New("invalid amount")
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return math.Round(amount*100) / 100, nil
+}
@@ -0,0 +1,132 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	settings := entity.ImportSettings{
+		Delimiter:    ";",
+		DecimalComma: true,
+		DateFormat:   "DD/MM/YYYY",
+		HasHeader:    true,
+		Columns:      entity.ImportColumns{Date: "Data", Amount: "Valor", Description: "Descrição"},
+	}
+
+	t.Run("should read the mapped columns by header name", func(t *testing.T) {
+		content := "\xEF\xBB\xBFData;Descrição;Valor\n05/03/2025;Mercado;-1.234,56\n\n06/03/2025;Salário;5.000,00\n"
+
+		lines, err := ParseCSV([]byte(content), settings, 10)
+
+		assert.Nil(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, 2, lines[0].Number)
+		assert.Equal(t, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), lines[0].Date)
+		assert.Equal(t, -1234.56, lines[0].Amount)
+		assert.Equal(t, "Mercado", lines[0].Description)
+		assert.Nil(t, lines[0].Err)
+		assert.Equal(t, 4, lines[1].Number)
+		assert.Equal(t, 5000.0, lines[1].Amount)
+	})
+
+	t.Run("should read files that are not UTF-8 as Latin-1", func(t *testing.T) {
+		content := "Data;Descri\xe7\xe3o;Valor\n05/03/2025;P\xe3o;-8,50\n"
+
+		lines, err := ParseCSV([]byte(content), settings, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Pão", lines[0].Description)
+	})
+
+	t.Run("should read columns by position when there is no header row", func(t *testing.T) {
+		positional := settings
+		positional.HasHeader = false
+		positional.NegateAmounts = true
+		positional.Columns = entity.ImportColumns{Date: "1", Amount: "3", Description: "2"}
+
+		lines, err := ParseCSV([]byte("05/03/2025;Cinema;42,00\n"), positional, 10)
+
+		assert.Nil(t, err)
+		assert.Len(t, lines, 1)
+		assert.Equal(t, -42.0, lines[0].Amount)
+		assert.Equal(t, "Cinema", lines[0].Description)
+	})
+
+	t.Run("should report every field of a row that cannot be read", func(t *testing.T) {
+		lines, err := ParseCSV([]byte("Data;Descrição;Valor\n2025-03-05;Mercado;abc\n06/03/2025\n"), settings, 10)
+
+		assert.Nil(t, err)
+		assert.Len(t, lines, 2)
+
+		first, ok := domainerror.As(lines[0].Err)
+		assert.True(t, ok)
+		assert.Equal(t, "row_invalid", first.Code)
+		assert.Len(t, first.Details, 2)
+		assert.Equal(t, "date_invalid", first.Details[0].Code)
+		assert.Equal(t, "amount_invalid", first.Details[1].Code)
+
+		second, ok := domainerror.As(lines[1].Err)
+		assert.True(t, ok)
+		assert.Equal(t, "column_missing", second.Details[0].Code)
+		assert.Equal(t, "amount", second.Details[0].Field)
+	})
+
+	t.Run("should reject a file missing a mapped column", func(t *testing.T) {
+		_, err := ParseCSV([]byte("Data;Valor\n05/03/2025;1,00\n"), settings, 10)
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, "column_not_found", domainErr.Code)
+		assert.Equal(t, "Descrição", domainErr.Params["column"])
+	})
+
+	t.Run("should reject a file with more rows than allowed", func(t *testing.T) {
+		_, err := ParseCSV([]byte("Data;Descrição;Valor\n05/03/2025;A;1,00\n05/03/2025;B;2,00\n"), settings, 1)
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, domainerror.KindTooLarge, domainErr.Kind)
+		assert.Equal(t, "too_many_rows", domainErr.Code)
+	})
+
+	t.Run("should reject a file without data rows", func(t *testing.T) {
+		_, err := ParseCSV([]byte("Data;Descrição;Valor\n"), settings, 10)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindInvalidInput))
+	})
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value        string
+		decimalComma bool
+		expected     float64
+	}{
+		{"1,234.56", false, 1234.56},
+		{"-1.234,56", true, -1234.56},
+		{"R$ 10,00", true, 10},
+		{"(12.50)", false, -12.5},
+		{"7,99-", true, -7.99},
+		{"0.005", false, 0.01},
+	}
+
+	for _, test := range tests {
+		t.Run("should read "+test.value, func(t *testing.T) {
+			amount, err := ParseAmount(test.value, test.decimalComma)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, amount)
+		})
+	}
+
+	t.Run("should reject values that are not numbers", func(t *testing.T) {
+		_, err := ParseAmount("NaN", false)
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,223 @@
+package statement
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+)
+
+// ofxDateFormat is how OFX dates are described to users when one cannot be read
+const ofxDateFormat = "YYYYMMDDHHMMSS"
+
+// ofxElement is a tag of an OFX document with the text that follows it, which is the value of
+// the leaf elements. OFX 1.x is SGML and leaves leaf elements unclosed, so no attempt is made to
+// pair tags beyond the aggregates the parser cares about.
+type ofxElement struct {
+	name    string
+	value   string
+	closing bool
+	line    int
+}
+
+// ParseOFX reads the transactions (STMTTRN) of an OFX or QFX statement, either OFX 1.x (SGML)
+// or 2.x (XML), of bank and credit card accounts alike. Each Line carries the FITID of its
+// entry, prefixed by the account id, as its ExternalID. Files that are not UTF-8 are read as
+// Latin-1. As with ParseCSV, an error is returned only when the file as a whole cannot be read.
+func ParseOFX(content []byte, maxRows int) ([]Line, error) {
+	elements := ofxElements(string(decode(content)))
+
+	isOFX := false
+	for _, element := range elements {
+		if element.name == "OFX" && !element.closing {
+			isOFX = true
+			break
+		}
+	}
+	if !isOFX {
+		return nil, domainerror.NewInvalidInput("file", "ofx_malformed", "file is not an OFX statement")
+	}
+
+	var lines []Line
+	var entry *ofxEntry
+	account := ""
+
+	finish := func() error {
+		if entry == nil {
+			return nil
+		}
+		if len(lines) == maxRows {
+			return domainerror.NewTooLarge("too_many_rows", "the file has more rows than can be imported at once").
+				WithParams(map[string]string{"max": strconv.Itoa(maxRows)})
+		}
+		lines = append(lines, entry.read(account))
+		entry = nil
+		return nil
+	}
+
+	for _, element := range elements {
+		switch {
+		case element.name == "STMTTRN" && !element.closing:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			entry = &ofxEntry{number: element.line, fields: make(map[string]string)}
+		case element.name == "STMTTRN":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		case element.closing:
+		case entry != nil:
+			entry.add(element)
+		case element.name == "STMTRS" || element.name == "CCSTMTRS":
+			account = ""
+		case element.name == "ACCTID":
+			account = element.value
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, domainerror.NewInvalidInput("file", "ofx_empty", "statement has no transactions to import")
+	}
+
+	return lines, nil
+}
+
+// ofxElements splits an OFX document into its tags, skipping the SGML headers of OFX 1.x and the
+// processing instructions of OFX 2.x
+func ofxElements(document string) []ofxElement {
+	var elements []ofxElement
+	line := 1
+	for {
+		start := strings.IndexByte(document, '<')
+		if start < 0 {
+			return elements
+		}
+		line += strings.Count(document[:start], "\n")
+		document = document[start+1:]
+
+		end := strings.IndexByte(document, '>')
+		if end < 0 {
+			return elements
+		}
+		tag := document[:end]
+		line += strings.Count(tag, "\n")
+		document = document[end+1:]
+
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		element := ofxElement{line: line}
+		if tag[0] == '/' {
+			element.closing = true
+			tag = tag[1:]
+		}
+		tag = strings.TrimSuffix(strings.TrimSpace(tag), "/")
+		if space := strings.IndexAny(tag, " \t\r\n"); space >= 0 {
+			tag = tag[:space]
+		}
+		element.name = strings.ToUpper(tag)
+
+		if !element.closing {
+			value := document
+			if next := strings.IndexByte(value, '<'); next >= 0 {
+				value = value[:next]
+			}
+			element.value = html.UnescapeString(strings.TrimSpace(value))
+		}
+
+		elements = append(elements, element)
+	}
+}
+
+// ofxEntry collects the leaf elements of a STMTTRN aggregate
+type ofxEntry struct {
+	number int
+	fields map[string]string
+	raw    []string
+}
+
+func (e *ofxEntry) add(element ofxElement) {
+	if element.value == "" {
+		return
+	}
+	e.raw = append(e.raw, element.value)
+	if _, seen := e.fields[element.name]; !seen {
+		e.fields[element.name] = element.value
+	}
+}
+
+func (e *ofxEntry) read(account string) Line {
+	line := Line{Number: e.number, Raw: e.raw}
+	invalid := domainerror.NewValidation("", "row_invalid", "row cannot be imported")
+
+	missing := func(field string) {
+		invalid.WithDetails(domainerror.NewValidation(field, "column_missing", "row has no value for "+field).
+			WithParams(map[string]string{"field": field}))
+	}
+
+	if date, ok := e.fields["DTPOSTED"]; !ok {
+		missing("date")
+	} else if parsed, err := parseOFXDate(date); err != nil {
+		invalid.WithDetails(domainerror.NewValidation("date", "date_invalid", "date does not match the format "+ofxDateFormat).
+			WithParams(map[string]string{"value": date, "format": ofxDateFormat}))
+	} else {
+		line.Date = parsed
+	}
+
+	if amount, ok := e.fields["TRNAMT"]; !ok {
+		missing("amount")
+	} else if parsed, err := ParseAmount(amount, strings.Contains(amount, ",") && !strings.Contains(amount, ".")); err != nil {
+		invalid.WithDetails(domainerror.NewValidation("amount", "amount_invalid", "amount is not a number").
+			WithParams(map[string]string{"value": amount}))
+	} else {
+		line.Amount = parsed
+	}
+
+	line.Description = e.fields["MEMO"]
+	if line.Description == "" {
+		line.Description = e.fields["NAME"]
+	}
+
+	if fitID := e.fields["FITID"]; fitID != "" {
+		line.ExternalID = fitID
+		if account != "" {
+			line.ExternalID = account + "/" + fitID
+		}
+	}
+
+	if len(invalid.Details) > 0 {
+		line.Err = invalid
+	}
+	return line
+}
+
+// parseOFXDate reads an OFX datetime such as 20250305, 20250305120000 or
+// 20250305120000.000[-3:BRT]; without a time zone it is taken as UTC
+func parseOFXDate(value string) (time.Time, error) {
+	location := time.UTC
+	if open := strings.IndexByte(value, '['); open >= 0 {
+		zone := strings.TrimSuffix(value[open+1:], "]")
+		value = value[:open]
+		offset, _, _ := strings.Cut(zone, ":")
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		location = time.FixedZone("", int(hours*3600))
+	}
+	value, _, _ = strings.Cut(strings.TrimSpace(value), ".")
+
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(value)]
+	if !ok {
+		return time.Time{}, &time.ParseError{Value: value, Message: ": unexpected length"}
+	}
+	return time.ParseInLocation(layout, value, location)
+}
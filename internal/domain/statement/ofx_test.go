@@ -0,0 +1,132 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/stretchr/testify/assert"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>BRL
+<BANKACCTFROM>
+<BANKID>0260
+<ACCTID>12345-6
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250305000000[-3:BRT]
+<TRNAMT>-120.50
+<FITID>abc-1
+<MEMO>Padaria P` + "\xe3" + `o &amp; Cia
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250306
+<TRNAMT>5000,00
+<FITID>abc-2
+<NAME>Salario
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>ontem
+<FITID>abc-3
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20250307143000.000</DTPOSTED>
+            <TRNAMT>-42.00</TRNAMT>
+            <FITID>9</FITID>
+            <MEMO>Cinema &#34;Estrela&#34; &#x26; Cia</MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	t.Run("should read the entries of an OFX 1.x statement", func(t *testing.T) {
+		lines, err := ParseOFX([]byte(sgmlStatement), 10)
+
+		assert.Nil(t, err)
+		assert.Len(t, lines, 3)
+
+		assert.Equal(t, 16, lines[0].Number)
+		assert.True(t, time.Date(2025, 3, 5, 3, 0, 0, 0, time.UTC).Equal(lines[0].Date))
+		assert.Equal(t, -120.5, lines[0].Amount)
+		assert.Equal(t, "Padaria Pão & Cia", lines[0].Description)
+		assert.Equal(t, "12345-6/abc-1", lines[0].ExternalID)
+		assert.Nil(t, lines[0].Err)
+
+		assert.Equal(t, time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC), lines[1].Date)
+		assert.Equal(t, 5000.0, lines[1].Amount)
+		assert.Equal(t, "Salario", lines[1].Description)
+
+		invalid, ok := domainerror.As(lines[2].Err)
+		assert.True(t, ok)
+		assert.Equal(t, "row_invalid", invalid.Code)
+		assert.Equal(t, "date_invalid", invalid.Details[0].Code)
+		assert.Equal(t, "column_missing", invalid.Details[1].Code)
+		assert.Equal(t, "amount", invalid.Details[1].Field)
+	})
+
+	t.Run("should read the entries of an OFX 2.x statement", func(t *testing.T) {
+		lines, err := ParseOFX([]byte(xmlStatement), 10)
+
+		assert.Nil(t, err)
+		assert.Len(t, lines, 1)
+		assert.Equal(t, time.Date(2025, 3, 7, 14, 30, 0, 0, time.UTC), lines[0].Date)
+		assert.Equal(t, -42.0, lines[0].Amount)
+		assert.Equal(t, `Cinema "Estrela" & Cia`, lines[0].Description)
+		assert.Equal(t, "4111/9", lines[0].ExternalID)
+	})
+
+	t.Run("should reject a file that is not OFX", func(t *testing.T) {
+		_, err := ParseOFX([]byte("Data;Valor\n05/03/2025;1,00\n"), 10)
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, "ofx_malformed", domainErr.Code)
+	})
+
+	t.Run("should reject a statement without transactions", func(t *testing.T) {
+		_, err := ParseOFX([]byte("<OFX><BANKMSGSRSV1></BANKMSGSRSV1></OFX>"), 10)
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, "ofx_empty", domainErr.Code)
+	})
+
+	t.Run("should reject a statement with more entries than allowed", func(t *testing.T) {
+		_, err := ParseOFX([]byte(sgmlStatement), 2)
+
+		assert.True(t, domainerror.IsKind(err, domainerror.KindTooLarge))
+	})
+}
@@ -1,18 +1,463 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
-func LoadConfig() (*viper.Viper, error) {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
+// EnvPrefix prefixes every environment override, e.g. FLUX_DB_CONNECTION_STRING
+const EnvPrefix = "FLUX"
 
-	err := viper.ReadInConfig()
-	if err != nil {
+// secretKeys can also be read from a file named by "<key>_file", e.g.
+// FLUX_DB_CONNECTION_STRING_FILE=/run/secrets/db, so secrets stay out of the environment
+var secretKeys = []string{"db.connection_string", "auth.gateway_secret"}
+
+// secretListKeys are read the same way, one value per line
+var secretListKeys = []string{"db.replicas"}
+
+const (
+	AuthModeHeader = "header"
+	AuthModeDev    = "dev"
+)
+
+type Config struct {
+	Server      ServerConfig      `mapstructure:"server"`
+	DB          DBConfig          `mapstructure:"db"`
+	Auth        AuthConfig        `mapstructure:"auth"`
+	Health      HealthConfig      `mapstructure:"health"`
+	Logging     LoggingConfig     `mapstructure:"logging"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	Concurrency ConcurrencyConfig `mapstructure:"concurrency"`
+	Trash       TrashConfig       `mapstructure:"trash"`
+	Batch       BatchConfig       `mapstructure:"batch"`
+	Import      ImportConfig      `mapstructure:"import"`
+	Export      ExportConfig      `mapstructure:"export"`
+	Jobs        JobsConfig        `mapstructure:"jobs"`
+	Recurring   RecurringConfig   `mapstructure:"recurring"`
+	Metrics     MetricsConfig     `mapstructure:"metrics"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+}
+
+type ServerConfig struct {
+	Port              int           `mapstructure:"port"`
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	DrainDelay        time.Duration `mapstructure:"drain_delay"`
+	ShutdownTimeout   time.Duration `mapstructure:"shutdown_timeout"`
+	MaxBodyBytes      int64         `mapstructure:"max_body_bytes"`
+	TrustedProxies    []string      `mapstructure:"trusted_proxies"`
+}
+
+type DBConfig struct {
+	ConnectionString string        `mapstructure:"connection_string"`
+	Replicas         []string      `mapstructure:"replicas"`
+	AutoMigrate      bool          `mapstructure:"auto_migrate"`
+	MaxOpenConns     int           `mapstructure:"max_open_conns"`
+	MaxIdleConns     int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime  time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime  time.Duration `mapstructure:"conn_max_idle_time"`
+}
+
+// AuthConfig describes how the authenticated user is resolved. In "header" mode a trusted
+// gateway forwards the user ID in UserHeader, and proves it is the gateway by sending
+// GatewaySecret in SecretHeader; "dev" mode uses DevUserID for every request
+type AuthConfig struct {
+	Mode          string `mapstructure:"mode"`
+	UserHeader    string `mapstructure:"user_header"`
+	SecretHeader  string `mapstructure:"secret_header"`
+	GatewaySecret string `mapstructure:"gateway_secret"`
+	DevUserID     string `mapstructure:"dev_user_id"`
+}
+
+type HealthConfig struct {
+	CheckTimeout time.Duration `mapstructure:"check_timeout"`
+}
+
+type LoggingConfig struct {
+	Level              string        `mapstructure:"level"`
+	Format             string        `mapstructure:"format"`
+	Redact             bool          `mapstructure:"redact"`
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string      `mapstructure:"allowed_origins"`
+	AllowedMethods   []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string      `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string      `mapstructure:"exposed_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// RateLimitConfig sets a token bucket per user, or per client IP for anonymous requests
+type RateLimitConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	RequestsPerSecond float64       `mapstructure:"requests_per_second"`
+	Burst             int           `mapstructure:"burst"`
+	IdleTTL           time.Duration `mapstructure:"idle_ttl"`
+}
+
+// IdempotencyConfig sets how long the response to a request with an Idempotency-Key is kept
+type IdempotencyConfig struct {
+	TTL           time.Duration `mapstructure:"ttl"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// ConcurrencyConfig sets whether writes to a single resource must send If-Match with its ETag
+type ConcurrencyConfig struct {
+	RequireIfMatch bool `mapstructure:"require_if_match"`
+}
+
+// TrashConfig sets how long deleted transactions and categories can be restored before they
+// are purged for good
+type TrashConfig struct {
+	Retention     time.Duration `mapstructure:"retention"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// BatchConfig caps how many operations a single batch request may carry
+type BatchConfig struct {
+	MaxOperations int `mapstructure:"max_operations"`
+}
+
+// ImportConfig caps the size of the bank statements that can be imported at once
+type ImportConfig struct {
+	MaxFileBytes int64 `mapstructure:"max_file_bytes"`
+	MaxRows      int   `mapstructure:"max_rows"`
+}
+
+// ExportConfig sets how long a transaction export may take to download, replacing the server
+// write timeout, which is sized for regular responses, and the IANA time zone exported dates are
+// written in when the request does not name one
+type ExportConfig struct {
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	TimeZone     string        `mapstructure:"time_zone"`
+}
+
+// JobsConfig sizes the worker pool that runs background imports and exports, and sets where
+// and for how long their files are kept
+type JobsConfig struct {
+	Workers           int           `mapstructure:"workers"`
+	PollInterval      time.Duration `mapstructure:"poll_interval"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	Lease             time.Duration `mapstructure:"lease"`
+	MaxAttempts       int           `mapstructure:"max_attempts"`
+	RetryBackoff      time.Duration `mapstructure:"retry_backoff"`
+	Retention         time.Duration `mapstructure:"retention"`
+	PurgeInterval     time.Duration `mapstructure:"purge_interval"`
+	StorageDir        string        `mapstructure:"storage_dir"`
+}
+
+// RecurringConfig sets how often the occurrences of recurring transactions that fell due are
+// recorded as transactions
+type RecurringConfig struct {
+	MaterializeInterval time.Duration `mapstructure:"materialize_interval"`
+}
+
+type MetricsConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Path      string `mapstructure:"path"`
+	AdminPort int    `mapstructure:"admin_port"`
+}
+
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	ServiceName string  `mapstructure:"service_name"`
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// LoadConfig reads the YAML file at path, or ./config.yaml when path is empty, applies
+// FLUX_* environment overrides and secret files, and validates the result
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName("config")
+		v.SetConfigType("yaml")
+		v.AddConfigPath(".")
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if path != "" || !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+	}
+
+	if err := readSecretFiles(v); err != nil {
 		return nil, err
 	}
 
-	return viper.GetViper(), nil
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// setDefaults registers every key, which also lets AutomaticEnv override keys absent from the file
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.port", 8081)
+	v.SetDefault("server.read_timeout", "15s")
+	v.SetDefault("server.read_header_timeout", "5s")
+	v.SetDefault("server.write_timeout", "30s")
+	v.SetDefault("server.idle_timeout", "60s")
+	v.SetDefault("server.drain_delay", "5s")
+	v.SetDefault("server.shutdown_timeout", "20s")
+	v.SetDefault("server.max_body_bytes", 1<<20)
+	v.SetDefault("server.trusted_proxies", []string{})
+	v.SetDefault("db.connection_string", "")
+	v.SetDefault("db.replicas", []string{})
+	v.SetDefault("db.auto_migrate", true)
+	v.SetDefault("db.max_open_conns", 25)
+	v.SetDefault("db.max_idle_conns", 10)
+	v.SetDefault("db.conn_max_lifetime", "30m")
+	v.SetDefault("db.conn_max_idle_time", "5m")
+	v.SetDefault("auth.mode", AuthModeHeader)
+	v.SetDefault("auth.user_header", "X-User-Id")
+	v.SetDefault("auth.secret_header", "X-Gateway-Secret")
+	v.SetDefault("auth.gateway_secret", "")
+	v.SetDefault("auth.dev_user_id", "")
+	v.SetDefault("health.check_timeout", "2s")
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.redact", true)
+	v.SetDefault("logging.slow_query_threshold", "200ms")
+	v.SetDefault("cors.allowed_origins", []string{})
+	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	v.SetDefault("cors.allowed_headers", []string{"Accept", "Accept-Language", "Content-Type", "Authorization", "Idempotency-Key", "If-Match", "If-None-Match", "Prefer", "X-Request-Id"})
+	v.SetDefault("cors.exposed_headers", []string{"ETag", "Location", "Preference-Applied", "Retry-After", "X-Request-Id"})
+	v.SetDefault("cors.allow_credentials", false)
+	v.SetDefault("cors.max_age", "10m")
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.requests_per_second", 10)
+	v.SetDefault("rate_limit.burst", 20)
+	v.SetDefault("rate_limit.idle_ttl", "10m")
+	v.SetDefault("idempotency.ttl", "24h")
+	v.SetDefault("idempotency.purge_interval", "1h")
+	v.SetDefault("concurrency.require_if_match", true)
+	v.SetDefault("trash.retention", "720h")
+	v.SetDefault("trash.purge_interval", "1h")
+	v.SetDefault("batch.max_operations", 500)
+	v.SetDefault("import.max_file_bytes", 5<<20)
+	v.SetDefault("import.max_rows", 5000)
+	v.SetDefault("export.write_timeout", "10m")
+	v.SetDefault("export.time_zone", "America/Sao_Paulo")
+	v.SetDefault("jobs.workers", 2)
+	v.SetDefault("jobs.poll_interval", "2s")
+	v.SetDefault("jobs.heartbeat_interval", "10s")
+	v.SetDefault("jobs.lease", "1m")
+	v.SetDefault("jobs.max_attempts", 3)
+	v.SetDefault("jobs.retry_backoff", "30s")
+	v.SetDefault("jobs.retention", "168h")
+	v.SetDefault("jobs.purge_interval", "1h")
+	v.SetDefault("jobs.storage_dir", "./data/jobs")
+	v.SetDefault("recurring.materialize_interval", "1m")
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.path", "/metrics")
+	v.SetDefault("metrics.admin_port", 0)
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.service_name", "flux-control")
+	v.SetDefault("tracing.exporter", "otlp")
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	for _, key := range append(secretKeys, secretListKeys...) {
+		v.SetDefault(key+"_file", "")
+	}
+}
+
+func readSecretFiles(v *viper.Viper) error {
+	for _, key := range secretKeys {
+		content, err := readSecretFile(v, key)
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			continue
+		}
+		v.Set(key, strings.TrimSpace(string(content)))
+	}
+	for _, key := range secretListKeys {
+		content, err := readSecretFile(v, key)
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			continue
+		}
+		var values []string
+		for _, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				values = append(values, line)
+			}
+		}
+		v.Set(key, values)
+	}
+	return nil
+}
+
+// readSecretFile returns the content of the file named by "<key>_file", or nil when unset
+func readSecretFile(v *viper.Viper, key string) ([]byte, error) {
+	file := v.GetString(key + "_file")
+	if file == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read secret %s from file: %w", key, err)
+	}
+	return content, nil
+}
+
+// Validate reports every missing or invalid value at once
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.DrainDelay < 0 {
+		fail("server.drain_delay must not be negative")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		fail("server.shutdown_timeout must be positive")
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		fail("server.max_body_bytes must be positive")
+	}
+
+	if c.DB.ConnectionString == "" {
+		fail("db.connection_string is required (set it in the config file, %s_DB_CONNECTION_STRING or %s_DB_CONNECTION_STRING_FILE)", EnvPrefix, EnvPrefix)
+	}
+	if slices.Contains(c.DB.Replicas, "") {
+		fail("db.replicas must not contain empty connection strings")
+	}
+	if c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0 {
+		fail("db.max_open_conns and db.max_idle_conns must not be negative")
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		fail("db.max_idle_conns (%d) must not exceed db.max_open_conns (%d)", c.DB.MaxIdleConns, c.DB.MaxOpenConns)
+	}
+
+	switch c.Auth.Mode {
+	case AuthModeHeader:
+		if c.Auth.UserHeader == "" || c.Auth.SecretHeader == "" {
+			fail("auth.user_header and auth.secret_header are required when auth.mode is header")
+		}
+		if c.Auth.GatewaySecret == "" {
+			fail("auth.gateway_secret is required when auth.mode is header (set it in the config file, %s_AUTH_GATEWAY_SECRET or %s_AUTH_GATEWAY_SECRET_FILE)", EnvPrefix, EnvPrefix)
+		}
+	case AuthModeDev:
+		if _, err := uuid.Parse(c.Auth.DevUserID); err != nil {
+			fail("auth.dev_user_id must be a UUID when auth.mode is dev")
+		}
+	default:
+		fail("auth.mode must be header or dev, got %q", c.Auth.Mode)
+	}
+
+	if c.Health.CheckTimeout <= 0 {
+		fail("health.check_timeout must be positive")
+	}
+
+	if !slices.Contains([]string{"debug", "info", "warn", "error"}, strings.ToLower(c.Logging.Level)) {
+		fail("logging.level must be debug, info, warn or error, got %q", c.Logging.Level)
+	}
+	if !slices.Contains([]string{"json", "text"}, c.Logging.Format) {
+		fail("logging.format must be json or text, got %q", c.Logging.Format)
+	}
+
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowedOrigins, "*") {
+		fail("cors.allowed_origins cannot contain * when cors.allow_credentials is true")
+	}
+
+	if c.RateLimit.Enabled && (c.RateLimit.RequestsPerSecond <= 0 || c.RateLimit.Burst < 1) {
+		fail("rate_limit.requests_per_second must be positive and rate_limit.burst at least 1")
+	}
+
+	if c.Idempotency.TTL <= 0 || c.Idempotency.PurgeInterval <= 0 {
+		fail("idempotency.ttl and idempotency.purge_interval must be positive")
+	}
+
+	if c.Trash.Retention <= 0 || c.Trash.PurgeInterval <= 0 {
+		fail("trash.retention and trash.purge_interval must be positive")
+	}
+
+	if c.Batch.MaxOperations < 1 {
+		fail("batch.max_operations must be at least 1, got %d", c.Batch.MaxOperations)
+	}
+
+	if c.Import.MaxFileBytes <= 0 || c.Import.MaxRows < 1 {
+		fail("import.max_file_bytes must be positive and import.max_rows at least 1")
+	}
+
+	if c.Export.WriteTimeout <= 0 {
+		fail("export.write_timeout must be positive")
+	}
+	if _, err := time.LoadLocation(c.Export.TimeZone); err != nil || c.Export.TimeZone == "" {
+		fail("export.time_zone must be an IANA time zone, got %q", c.Export.TimeZone)
+	}
+
+	if c.Jobs.Workers < 1 || c.Jobs.MaxAttempts < 1 {
+		fail("jobs.workers and jobs.max_attempts must be at least 1")
+	}
+	if c.Jobs.PollInterval <= 0 || c.Jobs.RetryBackoff <= 0 || c.Jobs.Retention <= 0 || c.Jobs.PurgeInterval <= 0 {
+		fail("jobs.poll_interval, jobs.retry_backoff, jobs.retention and jobs.purge_interval must be positive")
+	}
+	if c.Jobs.HeartbeatInterval <= 0 || c.Jobs.HeartbeatInterval >= c.Jobs.Lease {
+		fail("jobs.heartbeat_interval must be positive and shorter than jobs.lease")
+	}
+	if c.Jobs.StorageDir == "" {
+		fail("jobs.storage_dir is required")
+	}
+
+	if c.Recurring.MaterializeInterval <= 0 {
+		fail("recurring.materialize_interval must be positive")
+	}
+
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		fail("metrics.path must start with /")
+	}
+	if c.Metrics.AdminPort < 0 || c.Metrics.AdminPort > 65535 {
+		fail("metrics.admin_port must be between 0 and 65535, got %d", c.Metrics.AdminPort)
+	}
+
+	if c.Tracing.Enabled && !slices.Contains([]string{"otlp", "stdout"}, c.Tracing.Exporter) {
+		fail("tracing.exporter must be otlp or stdout, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	return errors.Join(errs...)
 }
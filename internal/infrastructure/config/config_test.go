@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("FLUX_AUTH_GATEWAY_SECRET", "gateway-secret")
+
+	t.Run("should read the file regardless of key casing", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "DB:\n  connection_string: dsn\n  max_open_conns: 50\nServer:\n  port: 9090\n  write_timeout: 45s\n")
+
+		config, err := LoadConfig(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dsn", config.DB.ConnectionString)
+		assert.Equal(t, 50, config.DB.MaxOpenConns)
+		assert.Equal(t, 9090, config.Server.Port)
+		assert.Equal(t, 45*time.Second, config.Server.WriteTimeout)
+		assert.Equal(t, "info", config.Logging.Level)
+	})
+
+	t.Run("should let environment variables override the file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "DB:\n  connection_string: dsn\n")
+		t.Setenv("FLUX_DB_CONNECTION_STRING", "from-env")
+		t.Setenv("FLUX_SERVER_PORT", "7070")
+		t.Setenv("FLUX_CORS_ALLOWED_ORIGINS", "https://app.example.com,https://admin.example.com")
+
+		config, err := LoadConfig(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", config.DB.ConnectionString)
+		assert.Equal(t, 7070, config.Server.Port)
+		assert.Equal(t, []string{"https://app.example.com", "https://admin.example.com"}, config.CORS.AllowedOrigins)
+	})
+
+	t.Run("should read secrets from files", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "Server:\n  port: 8081\n")
+		t.Setenv("FLUX_DB_CONNECTION_STRING_FILE", writeFile(t, "db-secret", "secret-dsn\n"))
+
+		config, err := LoadConfig(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "secret-dsn", config.DB.ConnectionString)
+	})
+
+	t.Run("should read one replica per line from a secret file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "db:\n  connection_string: dsn\n")
+		t.Setenv("FLUX_DB_REPLICAS_FILE", writeFile(t, "replicas", "replica-1\n\nreplica-2\n"))
+
+		config, err := LoadConfig(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"replica-1", "replica-2"}, config.DB.Replicas)
+	})
+
+	t.Run("should reject more idle than open connections", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "db:\n  connection_string: dsn\n  max_open_conns: 5\n  max_idle_conns: 10\n")
+
+		_, err := LoadConfig(path)
+
+		assert.ErrorContains(t, err, "db.max_idle_conns (10) must not exceed db.max_open_conns (5)")
+	})
+
+	t.Run("should reject job heartbeats that do not renew the lease in time", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "db:\n  connection_string: dsn\njobs:\n  heartbeat_interval: 1m\n  lease: 30s\n")
+
+		_, err := LoadConfig(path)
+
+		assert.ErrorContains(t, err, "jobs.heartbeat_interval must be positive and shorter than jobs.lease")
+	})
+
+	t.Run("should require the gateway secret in header mode", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "db:\n  connection_string: dsn\nauth:\n  mode: header\n")
+		t.Setenv("FLUX_AUTH_GATEWAY_SECRET", "")
+
+		_, err := LoadConfig(path)
+
+		assert.ErrorContains(t, err, "auth.gateway_secret is required when auth.mode is header")
+	})
+
+	t.Run("should fail when the given file does not exist", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		assert.ErrorContains(t, err, "read config file")
+	})
+
+	t.Run("should report every invalid value", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "server:\n  port: 0\nlogging:\n  level: verbose\nauth:\n  mode: dev\n")
+
+		_, err := LoadConfig(path)
+
+		assert.ErrorContains(t, err, "server.port must be between 1 and 65535")
+		assert.ErrorContains(t, err, "db.connection_string is required")
+		assert.ErrorContains(t, err, "logging.level must be debug, info, warn or error")
+		assert.ErrorContains(t, err, "auth.dev_user_id must be a UUID")
+	})
+}
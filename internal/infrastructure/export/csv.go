@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+)
+
+// utf8BOM makes spreadsheet programs read the file as UTF-8 instead of the system code page
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// csvWriter writes a CSV file with a header row, separated by semicolons where the comma is the
+// decimal separator, as spreadsheet programs expect
+type csvWriter struct {
+	out    *csv.Writer
+	locale locale
+}
+
+func newCSVWriter(out io.Writer, locale locale) (*csvWriter, error) {
+	if _, err := out.Write(utf8BOM); err != nil {
+		return nil, err
+	}
+
+	writer := &csvWriter{out: csv.NewWriter(out), locale: locale}
+	writer.out.Comma = locale.delimiter
+	if err := writer.out.Write(locale.columns()); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) Write(exported dto.ExportedTransactionDTO) error {
+	return w.out.Write([]string{
+		w.locale.datetime(exported.Transaction.Datetime()).Format(w.locale.dateLayout),
+		escapeFormula(exported.Transaction.Description()),
+		escapeFormula(exported.CategoryName),
+		w.locale.categoryType(exported.CategoryType),
+		w.locale.amount(signedAmount(exported)),
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.out.Flush()
+	return w.out.Error()
+}
+
+// escapeFormula quotes text that spreadsheet programs would run as a formula, such as a
+// description starting with "=", by prefixing it with an apostrophe. Only text typed by users
+// goes through it; amounts start with "-" and must stay numbers.
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
@@ -0,0 +1,131 @@
+package export
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"golang.org/x/text/language"
+)
+
+// Format is a file format transactions can be exported to
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatOFX  Format = "ofx"
+)
+
+var contentTypes = map[Format]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatOFX:  "application/x-ofx",
+}
+
+// ContentType is the media type of files in the format
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// FileName is the name of the file of transactions exported at generatedAt
+func (f Format) FileName(generatedAt time.Time) string {
+	return "transactions-" + generatedAt.Format(time.DateOnly) + "." + string(f)
+}
+
+// Writer writes exported transactions to a file as they come, so exports of any size are
+// written without holding them in memory. Close completes the file; it must be called even
+// when no transaction was written.
+type Writer interface {
+	Write(exported dto.ExportedTransactionDTO) error
+	Close() error
+}
+
+// NewWriter starts a file in format on out. Column names, numbers and dates are written for
+// lang where the format allows it, with dates in location; generatedAt is the time the file is
+// stamped with.
+func NewWriter(format Format, out io.Writer, lang language.Tag, location *time.Location, generatedAt time.Time) (Writer, error) {
+	locale := localeFor(lang)
+	locale.location = location
+
+	switch format {
+	case FormatXLSX:
+		return newXLSXWriter(out, locale)
+	case FormatOFX:
+		return newOFXWriter(out, locale, generatedAt), nil
+	default:
+		return newCSVWriter(out, locale)
+	}
+}
+
+// locale is how a language writes the values of an export
+type locale struct {
+	lang         language.Tag
+	location     *time.Location
+	decimalComma bool
+	delimiter    rune
+	dateLayout   string
+	// xlsxDateFormat is dateLayout as a spreadsheet number format
+	xlsxDateFormat string
+}
+
+func localeFor(lang language.Tag) locale {
+	if lang == language.BrazilianPortuguese {
+		return locale{
+			lang:           lang,
+			decimalComma:   true,
+			delimiter:      ';',
+			dateLayout:     "02/01/2006 15:04",
+			xlsxDateFormat: "dd/mm/yyyy hh:mm",
+		}
+	}
+	return locale{
+		lang:           lang,
+		delimiter:      ',',
+		dateLayout:     "2006-01-02 15:04",
+		xlsxDateFormat: "yyyy-mm-dd hh:mm",
+	}
+}
+
+// columns are the names of the columns of spreadsheet exports
+func (l locale) columns() []string {
+	return []string{
+		i18n.Translate(l.lang, "export_column_date", nil, "Date"),
+		i18n.Translate(l.lang, "export_column_description", nil, "Description"),
+		i18n.Translate(l.lang, "export_column_category", nil, "Category"),
+		i18n.Translate(l.lang, "export_column_type", nil, "Type"),
+		i18n.Translate(l.lang, "export_column_amount", nil, "Amount"),
+	}
+}
+
+func (l locale) categoryType(categoryType enum.CategoryType) string {
+	if categoryType == "" {
+		return ""
+	}
+	return i18n.Translate(l.lang, "export_type_"+string(categoryType), nil, string(categoryType))
+}
+
+// datetime is t on the wall clock of the export's time zone
+func (l locale) datetime(t time.Time) time.Time {
+	return t.In(l.location)
+}
+
+func (l locale) amount(amount float64) string {
+	formatted := strconv.FormatFloat(amount, 'f', 2, 64)
+	if l.decimalComma {
+		formatted = strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
+
+// signedAmount is the amount of a transaction as it affects the balance: negative for expenses
+func signedAmount(exported dto.ExportedTransactionDTO) float64 {
+	if exported.CategoryType == enum.CategoryTypeExpense {
+		return -exported.Transaction.Amount()
+	}
+	return exported.Transaction.Amount()
+}
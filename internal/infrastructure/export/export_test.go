@@ -0,0 +1,164 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/statement"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+var generatedAt = time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+func exportedTransactions(t *testing.T) []dto.ExportedTransactionDTO {
+	fixedClock := clock.NewFixed(generatedAt)
+	expense, err := entity.NewTransaction(fixedClock, identifier.NewV7(), uuid.New(), uuid.New(), 1234.5, time.Date(2025, 3, 5, 9, 15, 0, 0, time.UTC), `Mercado "Bom" & Cia`)
+	assert.Nil(t, err)
+	income, err := entity.NewTransaction(fixedClock, identifier.NewV7(), uuid.New(), uuid.New(), 5000, time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC), "Salário")
+	assert.Nil(t, err)
+
+	return []dto.ExportedTransactionDTO{
+		{Transaction: expense, CategoryName: "Alimentação", CategoryType: enum.CategoryTypeExpense},
+		{Transaction: income, CategoryName: "Trabalho", CategoryType: enum.CategoryTypeIncome},
+	}
+}
+
+func write(t *testing.T, format Format, lang language.Tag, transactions []dto.ExportedTransactionDTO) []byte {
+	return writeIn(t, format, lang, time.UTC, transactions)
+}
+
+func writeIn(t *testing.T, format Format, lang language.Tag, location *time.Location, transactions []dto.ExportedTransactionDTO) []byte {
+	var out bytes.Buffer
+	writer, err := NewWriter(format, &out, lang, location, generatedAt)
+	assert.Nil(t, err)
+	for _, exported := range transactions {
+		assert.Nil(t, writer.Write(exported))
+	}
+	assert.Nil(t, writer.Close())
+	return out.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	t.Run("should write Brazilian Portuguese conventions", func(t *testing.T) {
+		content := write(t, FormatCSV, language.BrazilianPortuguese, exportedTransactions(t))
+
+		assert.Equal(t, "\xEF\xBB\xBF"+
+			"Data;Descrição;Categoria;Tipo;Valor\n"+
+			`05/03/2025 09:15;"Mercado ""Bom"" & Cia";Alimentação;Despesa;-1234,50`+"\n"+
+			"06/03/2025 00:00;Salário;Trabalho;Receita;5000,00\n", string(content))
+	})
+
+	t.Run("should write English conventions", func(t *testing.T) {
+		content := write(t, FormatCSV, language.English, exportedTransactions(t)[1:])
+
+		assert.Equal(t, "\xEF\xBB\xBF"+
+			"Date,Description,Category,Type,Amount\n"+
+			"2025-03-06 00:00,Salário,Trabalho,Income,5000.00\n", string(content))
+	})
+
+	t.Run("should write dates in the time zone of the export", func(t *testing.T) {
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		assert.Nil(t, err)
+
+		content := writeIn(t, FormatCSV, language.BrazilianPortuguese, saoPaulo, exportedTransactions(t)[1:])
+
+		assert.Contains(t, string(content), "05/03/2025 21:00;Salário")
+	})
+
+	t.Run("should keep text cells from being read as formulas", func(t *testing.T) {
+		transaction, err := entity.NewTransaction(clock.NewFixed(generatedAt), identifier.NewV7(), uuid.New(), uuid.New(), 10, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), `=HYPERLINK("http://evil")`)
+		assert.Nil(t, err)
+
+		content := write(t, FormatCSV, language.English, []dto.ExportedTransactionDTO{
+			{Transaction: transaction, CategoryName: "@SUM(A1)", CategoryType: enum.CategoryTypeExpense},
+		})
+
+		assert.Equal(t, "\xEF\xBB\xBF"+
+			"Date,Description,Category,Type,Amount\n"+
+			`2025-03-05 00:00,"'=HYPERLINK(""http://evil"")",'@SUM(A1),Expense,-10.00`+"\n", string(content))
+	})
+}
+
+func TestXLSXWriter(t *testing.T) {
+	t.Run("should write a workbook with dates and amounts as numbers", func(t *testing.T) {
+		content := write(t, FormatXLSX, language.BrazilianPortuguese, exportedTransactions(t))
+
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		assert.Nil(t, err)
+		parts := make(map[string]string)
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			assert.Nil(t, err)
+			data, err := io.ReadAll(reader)
+			assert.Nil(t, err)
+			parts[file.Name] = string(data)
+		}
+
+		assert.Contains(t, parts, "[Content_Types].xml")
+		assert.Contains(t, parts["xl/workbook.xml"], `name="Transações"`)
+		assert.Contains(t, parts["xl/styles.xml"], `formatCode="dd/mm/yyyy hh:mm"`)
+		sheet := parts["xl/worksheets/sheet1.xml"]
+		assert.Contains(t, sheet, "<t xml:space=\"preserve\">Descrição</t>")
+		assert.Contains(t, sheet, `<c s="2"><v>45721.385416666664</v></c>`)
+		assert.Contains(t, sheet, "Mercado &#34;Bom&#34; &amp; Cia")
+		assert.Contains(t, sheet, `<c s="3"><v>-1234.5</v></c>`)
+		assert.Contains(t, sheet, "</sheetData></worksheet>")
+	})
+
+	t.Run("should write the wall clock time of the time zone of the export", func(t *testing.T) {
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		assert.Nil(t, err)
+
+		content := writeIn(t, FormatXLSX, language.BrazilianPortuguese, saoPaulo, exportedTransactions(t)[:1])
+
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		assert.Nil(t, err)
+		for _, file := range archive.File {
+			if file.Name != "xl/worksheets/sheet1.xml" {
+				continue
+			}
+			reader, err := file.Open()
+			assert.Nil(t, err)
+			sheet, err := io.ReadAll(reader)
+			assert.Nil(t, err)
+			// 2025-03-05 06:15 in São Paulo
+			assert.Contains(t, string(sheet), `<c s="2"><v>45721.260416666664</v></c>`)
+		}
+	})
+}
+
+func TestOFXWriter(t *testing.T) {
+	t.Run("should write a statement that imports back", func(t *testing.T) {
+		transactions := exportedTransactions(t)
+
+		content := write(t, FormatOFX, language.BrazilianPortuguese, transactions)
+
+		assert.Contains(t, string(content), "<DTSTART>20250305091500</DTSTART>")
+		assert.Contains(t, string(content), "<BALAMT>3765.50</BALAMT>")
+		lines, err := statement.ParseOFX(content, 10)
+		assert.Nil(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, -1234.5, lines[0].Amount)
+		assert.Equal(t, `Mercado "Bom" & Cia`, lines[0].Description)
+		assert.Equal(t, "FLUX-CONTROL/"+transactions[0].Transaction.ID().String(), lines[0].ExternalID)
+		assert.Equal(t, 5000.0, lines[1].Amount)
+	})
+
+	t.Run("should write an empty statement", func(t *testing.T) {
+		content := write(t, FormatOFX, language.English, nil)
+
+		assert.Contains(t, string(content), "<LANGUAGE>ENG</LANGUAGE>")
+		assert.Contains(t, string(content), "<DTSTART>20250310143000</DTSTART>")
+		assert.Contains(t, string(content), "</BANKTRANLIST>")
+	})
+}
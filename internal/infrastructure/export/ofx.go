@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"golang.org/x/text/language"
+)
+
+const (
+	// ofxAccountID names the single account the exported transactions are listed under
+	ofxAccountID = "FLUX-CONTROL"
+	// ofxNameLength is the longest NAME an OFX entry may have
+	ofxNameLength = 32
+	ofxDateLayout = "20060102150405"
+)
+
+// ofxEscapes are the only character references OFX defines, in both versions
+var ofxEscapes = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ofxWriter writes an OFX 2.2 bank statement in Brazilian reais. The entries keep the
+// transaction ids as FITID, so importing the file back recognizes them. The statement period
+// starts at the first transaction, so the header is only written along with it.
+type ofxWriter struct {
+	out         *bufio.Writer
+	locale      locale
+	generatedAt time.Time
+	started     bool
+	balance     float64
+}
+
+func newOFXWriter(out io.Writer, locale locale, generatedAt time.Time) *ofxWriter {
+	return &ofxWriter{out: bufio.NewWriter(out), locale: locale, generatedAt: generatedAt.UTC()}
+}
+
+func (w *ofxWriter) Write(exported dto.ExportedTransactionDTO) error {
+	transaction := exported.Transaction
+	w.start(transaction.Datetime())
+
+	transactionType := "CREDIT"
+	if exported.CategoryType == enum.CategoryTypeExpense {
+		transactionType = "DEBIT"
+	}
+	amount := signedAmount(exported)
+	w.balance += amount
+
+	w.out.WriteString("<STMTTRN>")
+	w.element("TRNTYPE", transactionType)
+	w.element("DTPOSTED", transaction.Datetime().UTC().Format(ofxDateLayout))
+	w.element("TRNAMT", strconv.FormatFloat(amount, 'f', 2, 64))
+	w.element("FITID", transaction.ID().String())
+	if exported.CategoryName != "" {
+		w.element("NAME", truncate(exported.CategoryName, ofxNameLength))
+	}
+	if transaction.Description() != "" {
+		w.element("MEMO", transaction.Description())
+	}
+	_, err := w.out.WriteString("</STMTTRN>\n")
+	return err
+}
+
+func (w *ofxWriter) Close() error {
+	w.start(w.generatedAt)
+
+	w.out.WriteString("</BANKTRANLIST><LEDGERBAL>")
+	w.element("BALAMT", strconv.FormatFloat(w.balance, 'f', 2, 64))
+	w.element("DTASOF", w.generatedAt.Format(ofxDateLayout))
+	w.out.WriteString("</LEDGERBAL></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n")
+	return w.out.Flush()
+}
+
+// start writes everything up to the transaction list, once, for a period starting at from
+func (w *ofxWriter) start(from time.Time) {
+	if w.started {
+		return
+	}
+	w.started = true
+
+	ofxLanguage := "ENG"
+	if w.locale.lang == language.BrazilianPortuguese {
+		ofxLanguage = "POR"
+	}
+
+	w.out.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	w.out.WriteString(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	w.out.WriteString("<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	w.element("DTSERVER", w.generatedAt.Format(ofxDateLayout))
+	w.element("LANGUAGE", ofxLanguage)
+	w.out.WriteString("</SONRS></SIGNONMSGSRSV1>\n")
+	w.out.WriteString("<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><STMTRS><CURDEF>BRL</CURDEF>")
+	w.out.WriteString("<BANKACCTFROM><BANKID>0</BANKID>")
+	w.element("ACCTID", ofxAccountID)
+	w.out.WriteString("<ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n<BANKTRANLIST>")
+	w.element("DTSTART", from.UTC().Format(ofxDateLayout))
+	w.element("DTEND", w.generatedAt.Format(ofxDateLayout))
+	w.out.WriteString("\n")
+}
+
+func (w *ofxWriter) element(name, value string) {
+	w.out.WriteString("<" + name + ">" + ofxEscapes.Replace(value) + "</" + name + ">")
+}
+
+// truncate cuts value to at most length characters
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length])
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+)
+
+// Cell styles, by their position in the cellXfs of xl/styles.xml
+const (
+	xlsxStyleHeader = 1
+	xlsxStyleDate   = 2
+	xlsxStyleAmount = 3
+)
+
+// xlsxEpoch is day zero of spreadsheet dates
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxParts are the parts of the workbook written before its only sheet
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="{sheet}" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="{dateFormat}"/></numFmts><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs></styleSheet>`},
+}
+
+const xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><cols><col min="1" max="1" width="18" customWidth="1"/><col min="2" max="2" width="40" customWidth="1"/><col min="3" max="3" width="20" customWidth="1"/><col min="4" max="4" width="12" customWidth="1"/><col min="5" max="5" width="14" customWidth="1"/></cols><sheetData>`
+
+const xlsxSheetEnd = `</sheetData></worksheet>`
+
+// xlsxWriter writes a workbook with a single sheet. Amounts are stored as numbers, which
+// spreadsheet programs show with the separators of the user's system, and dates as spreadsheet
+// dates shown in the date format of the language of the export.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	locale  locale
+}
+
+func newXLSXWriter(out io.Writer, locale locale) (*xlsxWriter, error) {
+	archive := zip.NewWriter(out)
+	values := strings.NewReplacer(
+		"{sheet}", escapeXML(i18n.Translate(locale.lang, "export_sheet_name", nil, "Transactions")),
+		"{dateFormat}", escapeXML(locale.xlsxDateFormat),
+	)
+	for _, part := range xlsxParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, values.Replace(part.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer := &xlsxWriter{archive: archive, sheet: bufio.NewWriter(file), locale: locale}
+	writer.sheet.WriteString(xlsxSheetStart)
+
+	writer.sheet.WriteString("<row>")
+	for _, column := range locale.columns() {
+		writer.text(column, xlsxStyleHeader)
+	}
+	writer.sheet.WriteString("</row>")
+
+	return writer, nil
+}
+
+func (w *xlsxWriter) Write(exported dto.ExportedTransactionDTO) error {
+	w.sheet.WriteString("<row>")
+	w.number(xlsxDate(w.locale.datetime(exported.Transaction.Datetime())), xlsxStyleDate)
+	w.text(exported.Transaction.Description(), 0)
+	w.text(exported.CategoryName, 0)
+	w.text(w.locale.categoryType(exported.CategoryType), 0)
+	w.number(signedAmount(exported), xlsxStyleAmount)
+	_, err := w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	w.sheet.WriteString(xlsxSheetEnd)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+func (w *xlsxWriter) text(value string, style int) {
+	w.sheet.WriteString(`<c t="inlineStr"` + xlsxStyle(style) + `><is><t xml:space="preserve">`)
+	w.sheet.WriteString(escapeXML(value))
+	w.sheet.WriteString(`</t></is></c>`)
+}
+
+func (w *xlsxWriter) number(value float64, style int) {
+	w.sheet.WriteString(`<c` + xlsxStyle(style) + `><v>`)
+	w.sheet.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	w.sheet.WriteString(`</v></c>`)
+}
+
+// xlsxDate is the spreadsheet serial date of the wall clock time of t, as spreadsheet dates
+// carry no time zone
+func xlsxDate(t time.Time) float64 {
+	_, offset := t.Zone()
+	return float64(t.Sub(xlsxEpoch)+time.Duration(offset)*time.Second) / float64(24*time.Hour)
+}
+
+func xlsxStyle(style int) string {
+	if style == 0 {
+		return ""
+	}
+	return ` s="` + strconv.Itoa(style) + `"`
+}
+
+func escapeXML(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+)
+
+// Check verifies that one dependency is usable
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the outcome of every readiness check
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker runs readiness checks and tracks whether the process is draining
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewChecker creates a Checker that gives each check at most timeout to answer
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Drain makes every later readiness report fail, so load balancers stop routing traffic
+// here while in-flight requests finish
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs every check and reports whether the process can serve traffic. The report is
+// public, so a failed check only says whether it failed or timed out; the error itself is logged.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	report := Report{Status: StatusUp, Checks: make([]Result, 0, len(c.checks)+1)}
+
+	if c.draining.Load() {
+		report.Status = StatusDown
+		report.Checks = append(report.Checks, Result{Name: "shutdown", Status: StatusDown, Error: "server is shutting down"})
+	}
+
+	for _, check := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		start := time.Now()
+		err := check.Check(checkCtx)
+		cancel()
+
+		result := Result{Name: check.Name, Status: StatusUp, Duration: time.Since(start).String()}
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = "check failed"
+			if errors.Is(err, context.DeadlineExceeded) {
+				result.Error = "timed out"
+			}
+			logger.FromContext(ctx).WarnContext(ctx, "readiness check failed", "check", check.Name, "error", err)
+			report.Status = StatusDown
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report, report.Status == StatusUp
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker(t *testing.T) {
+	up := Check{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	down := Check{Name: "migrations", Check: func(ctx context.Context) error { return errors.New("pending migrations") }}
+
+	t.Run("should be ready when every check passes", func(t *testing.T) {
+		report, ready := NewChecker(time.Second, up).Ready(context.Background())
+
+		assert.True(t, ready)
+		assert.Equal(t, StatusUp, report.Status)
+		assert.Len(t, report.Checks, 1)
+		assert.Equal(t, StatusUp, report.Checks[0].Status)
+	})
+
+	t.Run("should not be ready when a check fails", func(t *testing.T) {
+		report, ready := NewChecker(time.Second, up, down).Ready(context.Background())
+
+		assert.False(t, ready)
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, "check failed", report.Checks[1].Error)
+	})
+
+	t.Run("should give each check at most the timeout", func(t *testing.T) {
+		slow := Check{Name: "slow", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+
+		report, ready := NewChecker(10*time.Millisecond, slow).Ready(context.Background())
+
+		assert.False(t, ready)
+		assert.Equal(t, "timed out", report.Checks[0].Error)
+	})
+
+	t.Run("should not be ready after draining", func(t *testing.T) {
+		checker := NewChecker(time.Second, up)
+		checker.Drain()
+
+		report, ready := checker.Ready(context.Background())
+
+		assert.False(t, ready)
+		assert.Equal(t, "shutdown", report.Checks[0].Name)
+	})
+}
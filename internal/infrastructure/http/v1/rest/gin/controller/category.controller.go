@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request/category"
+	categoryResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/category"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryController struct {
+	categoryService interfaces.CategoryServiceInterface
+	requireIfMatch  bool
+}
+
+// NewCategoryController creates the category handlers. When requireIfMatch is set, writes to a
+// single category without If-Match are refused instead of overwriting whatever is stored.
+func NewCategoryController(categoryService interfaces.CategoryServiceInterface, requireIfMatch bool) *CategoryController {
+	return &CategoryController{
+		categoryService: categoryService,
+		requireIfMatch:  requireIfMatch,
+	}
+}
+
+func (c *CategoryController) GetCategories(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	categories, pagination, err := c.categoryService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := categoryResponse.BuildCategoriesResponse(
+		ctx,
+		categories,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *CategoryController) GetCategory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "category")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	category, err := c.categoryService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	etag := request.ETag(category.Version())
+	ctx.Header("ETag", etag)
+	if request.NotModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	c.respond(ctx, category, http.StatusOK)
+}
+
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	var createCategoryRequest category.CreateCategoryRequest
+	if err := request.BindJSON(ctx, &createCategoryRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	category, err := c.categoryService.Create(ctx.Request.Context(), createCategoryRequest.ToCreateCategoryDTO(userId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", "/v1/categories/"+category.ID().String())
+	c.respond(ctx, category, http.StatusCreated)
+}
+
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "category")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var updateCategoryRequest category.UpdateCategoryRequest
+	if err := request.BindJSON(ctx, &updateCategoryRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	category, err := c.categoryService.Update(ctx.Request.Context(), updateCategoryRequest.ToUpdateCategoryDTO(userId, id, version))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, category, http.StatusOK)
+}
+
+func (c *CategoryController) PatchCategory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "category")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var patchCategoryRequest category.PatchCategoryRequest
+	if err := request.BindJSON(ctx, &patchCategoryRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	category, err := c.categoryService.Patch(ctx.Request.Context(), patchCategoryRequest.ToPatchCategoryDTO(userId, id, version))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, category, http.StatusOK)
+}
+
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "category")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	if err := c.categoryService.Delete(ctx.Request.Context(), userId, id, version); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CategoryController) RestoreCategory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "category")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, false)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	category, err := c.categoryService.Restore(ctx.Request.Context(), userId, id, version)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, category, http.StatusOK)
+}
+
+// respond writes a single category along with the ETag of its version
+func (c *CategoryController) respond(ctx *gin.Context, category *entity.Category, statusCode int) {
+	ctx.Header("ETag", request.ETag(category.Version()))
+	ctx.JSON(statusCode, categoryResponse.BuildCategoryResponse(ctx, *category, statusCode))
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/buildinfo"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{
+		checker: checker,
+	}
+}
+
+// Liveness answers as long as the process can serve HTTP, without touching dependencies
+func (c *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Readiness reports whether every dependency is usable and the server is not draining
+func (c *HealthController) Readiness(ctx *gin.Context) {
+	report, ready := c.checker.Ready(ctx.Request.Context())
+	if !ready {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Version reports the build metadata of the running binary
+func (c *HealthController) Version(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, buildinfo.Get())
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/middleware"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request/imports"
+	importResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/imports"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/jobs"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/report"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/gin-gonic/gin"
+)
+
+type ImportController struct {
+	importService        interfaces.ImportServiceInterface
+	importProfileService interfaces.ImportProfileServiceInterface
+	jobService           interfaces.JobServiceInterface
+	maxFileBytes         int64
+}
+
+// NewImportController creates the statement import handlers. Uploaded statements larger than
+// maxFileBytes are refused; imports asked for with Prefer: respond-async run as jobs.
+func NewImportController(importService interfaces.ImportServiceInterface, importProfileService interfaces.ImportProfileServiceInterface, jobService interfaces.JobServiceInterface, maxFileBytes int64) *ImportController {
+	return &ImportController{
+		importService:        importService,
+		importProfileService: importProfileService,
+		jobService:           jobService,
+		maxFileBytes:         maxFileBytes,
+	}
+}
+
+func (c *ImportController) ImportCSV(ctx *gin.Context) {
+	var importFileRequest imports.ImportFileRequest
+	if err := request.BindMultipartForm(ctx, &importFileRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	importCSVDTO, err := importFileRequest.ToImportCSVDTO(userId, c.maxFileBytes)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if request.PrefersAsync(ctx) {
+		job, err := c.jobService.Enqueue(ctx.Request.Context(), jobs.ImportCSVJob(importCSVDTO, i18n.LanguageFromContext(ctx.Request.Context())))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		acceptJob(ctx, job)
+		return
+	}
+
+	result, err := c.importService.ImportCSV(ctx.Request.Context(), importCSVDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := report.BuildImportResponse(result, func(err error) *problem.Problem {
+		return middleware.Problem(ctx, err)
+	})
+
+	ctx.JSON(response.Meta.StatusCode, response)
+}
+
+func (c *ImportController) ImportOFX(ctx *gin.Context) {
+	var importOFXRequest imports.ImportOFXRequest
+	if err := request.BindMultipartForm(ctx, &importOFXRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	importOFXDTO, err := importOFXRequest.ToImportOFXDTO(userId, c.maxFileBytes)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if request.PrefersAsync(ctx) {
+		job, err := c.jobService.Enqueue(ctx.Request.Context(), jobs.ImportOFXJob(importOFXDTO, i18n.LanguageFromContext(ctx.Request.Context())))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		acceptJob(ctx, job)
+		return
+	}
+
+	result, err := c.importService.ImportOFX(ctx.Request.Context(), importOFXDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := report.BuildImportResponse(result, func(err error) *problem.Problem {
+		return middleware.Problem(ctx, err)
+	})
+
+	ctx.JSON(response.Meta.StatusCode, response)
+}
+
+func (c *ImportController) GetImportProfiles(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	profiles, pagination, err := c.importProfileService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := importResponse.BuildImportProfilesResponse(
+		ctx,
+		profiles,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *ImportController) GetImportProfile(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "import_profile")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	profile, err := c.importProfileService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importResponse.BuildImportProfileResponse(ctx, *profile, http.StatusOK))
+}
+
+func (c *ImportController) CreateImportProfile(ctx *gin.Context) {
+	var importProfileRequest imports.ImportProfileRequest
+	if err := request.BindJSON(ctx, &importProfileRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	profile, err := c.importProfileService.Create(ctx.Request.Context(), importProfileRequest.ToCreateImportProfileDTO(userId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", "/v1/import-profiles/"+profile.ID().String())
+	ctx.JSON(http.StatusCreated, importResponse.BuildImportProfileResponse(ctx, *profile, http.StatusCreated))
+}
+
+func (c *ImportController) UpdateImportProfile(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "import_profile")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var importProfileRequest imports.ImportProfileRequest
+	if err := request.BindJSON(ctx, &importProfileRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	profile, err := c.importProfileService.Update(ctx.Request.Context(), importProfileRequest.ToUpdateImportProfileDTO(userId, id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importResponse.BuildImportProfileResponse(ctx, *profile, http.StatusOK))
+}
+
+func (c *ImportController) DeleteImportProfile(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "import_profile")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	if err := c.importProfileService.Delete(ctx.Request.Context(), userId, id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
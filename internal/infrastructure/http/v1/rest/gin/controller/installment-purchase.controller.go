@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request/installment"
+	installmentResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/installment"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+type InstallmentPurchaseController struct {
+	installmentPurchaseService interfaces.InstallmentPurchaseServiceInterface
+	requireIfMatch             bool
+}
+
+// NewInstallmentPurchaseController creates the installment purchase handlers. When requireIfMatch
+// is set, changes to the remaining installments without If-Match are refused instead of
+// overwriting whatever is stored.
+func NewInstallmentPurchaseController(installmentPurchaseService interfaces.InstallmentPurchaseServiceInterface, requireIfMatch bool) *InstallmentPurchaseController {
+	return &InstallmentPurchaseController{
+		installmentPurchaseService: installmentPurchaseService,
+		requireIfMatch:             requireIfMatch,
+	}
+}
+
+func (c *InstallmentPurchaseController) GetInstallmentPurchases(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	purchases, pagination, err := c.installmentPurchaseService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := installmentResponse.BuildInstallmentPurchasesResponse(
+		ctx,
+		purchases,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *InstallmentPurchaseController) GetInstallmentPurchase(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "installment_purchase")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	purchase, err := c.installmentPurchaseService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	etag := request.ETag(purchase.Version())
+	ctx.Header("ETag", etag)
+	if request.NotModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	c.respond(ctx, purchase, http.StatusOK)
+}
+
+func (c *InstallmentPurchaseController) CreateInstallmentPurchase(ctx *gin.Context) {
+	var createRequest installment.CreateInstallmentPurchaseRequest
+	if err := request.BindJSON(ctx, &createRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	created, err := c.installmentPurchaseService.Create(ctx.Request.Context(), createRequest.ToCreateInstallmentPurchaseDTO(userId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", "/v1/installment-purchases/"+created.ID().String())
+	c.respond(ctx, created, http.StatusCreated)
+}
+
+// EditRemainingInstallments changes the installments not due yet, and their transactions
+func (c *InstallmentPurchaseController) EditRemainingInstallments(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "installment_purchase")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var editRequest installment.EditRemainingInstallmentsRequest
+	if err := request.BindJSON(ctx, &editRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	edited, err := c.installmentPurchaseService.EditRemaining(ctx.Request.Context(), editRequest.ToEditRemainingInstallmentsDTO(userId, id, version))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, edited, http.StatusOK)
+}
+
+// CancelInstallmentPurchase cancels the installments not due yet and moves their transactions to
+// the trash
+func (c *InstallmentPurchaseController) CancelInstallmentPurchase(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "installment_purchase")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	cancelled, err := c.installmentPurchaseService.CancelRemaining(ctx.Request.Context(), userId, id, version)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, cancelled, http.StatusOK)
+}
+
+// respond writes a single purchase along with the ETag of its version
+func (c *InstallmentPurchaseController) respond(ctx *gin.Context, purchase *entity.InstallmentPurchase, statusCode int) {
+	ctx.Header("ETag", request.ETag(purchase.Version()))
+	ctx.JSON(statusCode, installmentResponse.BuildInstallmentPurchaseResponse(ctx, *purchase, statusCode))
+}
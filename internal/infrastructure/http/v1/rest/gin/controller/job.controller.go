@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	jobResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/job"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	jobService      interfaces.JobServiceInterface
+	downloadTimeout time.Duration
+}
+
+// NewJobController creates the background job handlers. Results may take up to downloadTimeout
+// to download, as exports do when streamed directly.
+func NewJobController(jobService interfaces.JobServiceInterface, downloadTimeout time.Duration) *JobController {
+	return &JobController{
+		jobService:      jobService,
+		downloadTimeout: downloadTimeout,
+	}
+}
+
+func (c *JobController) GetJob(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "job")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	job, err := c.jobService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobResponse.BuildJobResponse(ctx, *job, http.StatusOK))
+}
+
+// GetJobResult downloads the file a succeeded job produced. As with exports, a failure once the
+// file has started drops the connection rather than leaving a download that looks complete.
+func (c *JobController) GetJobResult(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "job")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	job, result, err := c.jobService.OpenResult(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer result.Close()
+
+	http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(c.downloadTimeout))
+	ctx.Header("Content-Type", job.Result().ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.Result().Name))
+	ctx.Status(http.StatusOK)
+
+	if _, err := io.Copy(ctx.Writer, result); err != nil {
+		logger.FromContext(ctx.Request.Context()).ErrorContext(ctx.Request.Context(), "job result download interrupted",
+			"job_id", job.ID(),
+			"error", err,
+		)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (c *JobController) CancelJob(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "job")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	job, err := c.jobService.Cancel(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobResponse.BuildJobResponse(ctx, *job, http.StatusOK))
+}
+
+// acceptJob answers a request that asked with Prefer: respond-async for the job enqueued to carry
+// it out, to be followed at its Location
+func acceptJob(ctx *gin.Context, job *entity.Job) {
+	ctx.Header("Location", "/v1/jobs/"+job.ID().String())
+	ctx.Header(request.PreferenceAppliedHeader, request.RespondAsync)
+	ctx.JSON(http.StatusAccepted, jobResponse.BuildJobResponse(ctx, *job, http.StatusAccepted))
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+type OpenAPIController struct{}
+
+func NewOpenAPIController() *OpenAPIController {
+	return &OpenAPIController{}
+}
+
+// Spec serves the OpenAPI document
+func (c *OpenAPIController) Spec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", openapi.JSON())
+}
+
+// Docs serves the page that renders the OpenAPI document
+func (c *OpenAPIController) Docs(ctx *gin.Context) {
+	ctx.Header("Content-Security-Policy", openapi.DocsContentSecurityPolicy)
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsHTML())
+}
+
+// Viewer serves the script the docs page renders the document with
+func (c *OpenAPIController) Viewer(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Data(http.StatusOK, "text/javascript; charset=utf-8", openapi.ViewerJS())
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageParams reads the page and page_size query parameters, falling back to the first page of
+// 10 items when they are missing or out of range
+func pageParams(ctx *gin.Context) (page, pageSize int) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err = strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return page, pageSize
+}
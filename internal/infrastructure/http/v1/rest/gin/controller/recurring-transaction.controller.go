@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request/recurring"
+	recurringResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/recurring"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringTransactionController struct {
+	recurringTransactionService interfaces.RecurringTransactionServiceInterface
+	requireIfMatch              bool
+}
+
+// NewRecurringTransactionController creates the recurring transaction handlers. When
+// requireIfMatch is set, writes to a single series without If-Match are refused instead of
+// overwriting whatever is stored.
+func NewRecurringTransactionController(recurringTransactionService interfaces.RecurringTransactionServiceInterface, requireIfMatch bool) *RecurringTransactionController {
+	return &RecurringTransactionController{
+		recurringTransactionService: recurringTransactionService,
+		requireIfMatch:              requireIfMatch,
+	}
+}
+
+func (c *RecurringTransactionController) GetRecurringTransactions(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	recurrings, pagination, err := c.recurringTransactionService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := recurringResponse.BuildRecurringTransactionsResponse(
+		ctx,
+		recurrings,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *RecurringTransactionController) GetRecurringTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	recurring, err := c.recurringTransactionService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	etag := request.ETag(recurring.Version())
+	ctx.Header("ETag", etag)
+	if request.NotModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	c.respond(ctx, recurring, http.StatusOK)
+}
+
+func (c *RecurringTransactionController) CreateRecurringTransaction(ctx *gin.Context) {
+	var createRequest recurring.CreateRecurringTransactionRequest
+	if err := request.BindJSON(ctx, &createRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	created, err := c.recurringTransactionService.Create(ctx.Request.Context(), createRequest.ToCreateRecurringTransactionDTO(userId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", "/v1/recurring-transactions/"+created.ID().String())
+	c.respond(ctx, created, http.StatusCreated)
+}
+
+func (c *RecurringTransactionController) DeleteRecurringTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	if err := c.recurringTransactionService.Delete(ctx.Request.Context(), userId, id, version); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetOccurrences lists the upcoming occurrences of a series, skipped ones included
+func (c *RecurringTransactionController) GetOccurrences(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var upcomingRequest recurring.UpcomingOccurrencesRequest
+	if err := request.BindQuery(ctx, &upcomingRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	found, err := c.recurringTransactionService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("ETag", request.ETag(found.Version()))
+	ctx.JSON(http.StatusOK, recurringResponse.BuildOccurrencesResponse(found.Upcoming(upcomingRequest.ToLimit()), http.StatusOK))
+}
+
+func (c *RecurringTransactionController) SkipOccurrence(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	date, err := occurrenceDate(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	skipped, err := c.recurringTransactionService.Skip(ctx.Request.Context(), &dto.SkipOccurrenceDTO{
+		ID:      id,
+		UserID:  userId,
+		Version: version,
+		Date:    date,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, skipped, http.StatusOK)
+}
+
+// ResumeRecurringTransaction records the occurrences of a series paused after they were refused
+func (c *RecurringTransactionController) ResumeRecurringTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	resumed, err := c.recurringTransactionService.Resume(ctx.Request.Context(), &dto.ResumeRecurringTransactionDTO{
+		ID:      id,
+		UserID:  userId,
+		Version: version,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, resumed, http.StatusOK)
+}
+
+// EditOccurrences changes an occurrence and every one after it. When that splits the series,
+// the answer is the new series, created at its own Location.
+func (c *RecurringTransactionController) EditOccurrences(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "recurring_transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	date, err := occurrenceDate(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var editRequest recurring.EditOccurrencesRequest
+	if err := request.BindJSON(ctx, &editRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	edited, err := c.recurringTransactionService.EditFollowing(ctx.Request.Context(), editRequest.ToEditFollowingOccurrencesDTO(userId, id, version, date))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if edited.ID() != id {
+		ctx.Header("Location", "/v1/recurring-transactions/"+edited.ID().String())
+		c.respond(ctx, edited, http.StatusCreated)
+		return
+	}
+	c.respond(ctx, edited, http.StatusOK)
+}
+
+// respond writes a single series along with the ETag of its version
+func (c *RecurringTransactionController) respond(ctx *gin.Context, recurring *entity.RecurringTransaction, statusCode int) {
+	ctx.Header("ETag", request.ETag(recurring.Version()))
+	ctx.JSON(statusCode, recurringResponse.BuildRecurringTransactionResponse(ctx, *recurring, statusCode))
+}
+
+// occurrenceDate reads the date path parameter, reporting a malformed one as a missing
+// occurrence since no occurrence can fall on it
+func occurrenceDate(ctx *gin.Context) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, ctx.Param("date"))
+	if err != nil {
+		return time.Time{}, domainerror.NewNotFound("occurrence", ctx.Param("date"))
+	}
+	return date, nil
+}
@@ -1,43 +1,64 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/export"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/middleware"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request/transaction"
+	auditResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/audit"
 	transactionResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/transaction"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/jobs"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type TransactionController struct {
 	transactionService interfaces.TransactionServiceInterface
+	jobService         interfaces.JobServiceInterface
+	requireIfMatch     bool
+	maxBatchOperations int
+	exportWriteTimeout time.Duration
+	exportLocation     *time.Location
+	clock              clock.Clock
 }
 
-func NewTransactionController(transactionService interfaces.TransactionServiceInterface) *TransactionController {
+// NewTransactionController creates the transaction handlers. When requireIfMatch is set, writes to
+// a single transaction without If-Match, or batch operations without a version, are refused
+// instead of overwriting whatever is stored. Batches may carry up to maxBatchOperations operations,
+// and exports are given exportWriteTimeout to download, or run as jobs on Prefer: respond-async.
+// Exported dates are written in exportLocation unless the request names another time zone.
+func NewTransactionController(transactionService interfaces.TransactionServiceInterface, jobService interfaces.JobServiceInterface, requireIfMatch bool, maxBatchOperations int, exportWriteTimeout time.Duration, exportLocation *time.Location, clock clock.Clock) *TransactionController {
 	return &TransactionController{
 		transactionService: transactionService,
+		jobService:         jobService,
+		requireIfMatch:     requireIfMatch,
+		maxBatchOperations: maxBatchOperations,
+		exportWriteTimeout: exportWriteTimeout,
+		exportLocation:     exportLocation,
+		clock:              clock,
 	}
 }
 
 func (c *TransactionController) GetTransactions(ctx *gin.Context) {
-	pageStr := ctx.DefaultQuery("page", "1")
-	pageSizeStr := ctx.DefaultQuery("page_size", "10")
+	page, pageSize := pageParams(ctx)
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	pageSize, err := strconv.Atoi(pageSizeStr)
-	if err != nil || pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	transactions, pagination, err := c.transactionService.FindAllPaginated(page, pageSize)
+	userId, _ := principal.UserID(ctx.Request.Context())
+	transactions, pagination, err := c.transactionService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -57,23 +78,286 @@ func (c *TransactionController) GetTransactions(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// ExportTransactions streams every transaction of the user as a file. Once the file has started,
+// a failure can no longer be reported with a status, so the connection is dropped instead and
+// the client sees an incomplete download rather than a file that looks complete.
+func (c *TransactionController) ExportTransactions(ctx *gin.Context) {
+	var exportRequest transaction.ExportTransactionsRequest
+	if err := request.BindQuery(ctx, &exportRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	format := exportRequest.ToFormat()
+	location := exportRequest.ToLocation(c.exportLocation)
+	generatedAt := c.clock.Now()
+	lang := i18n.LanguageFromContext(ctx.Request.Context())
+	userId, _ := principal.UserID(ctx.Request.Context())
+
+	if request.PrefersAsync(ctx) {
+		job, err := c.jobService.Enqueue(ctx.Request.Context(), jobs.ExportTransactionsJob(userId, format, lang, location))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		acceptJob(ctx, job)
+		return
+	}
+
+	var writer export.Writer
+	start := func() (err error) {
+		if writer != nil {
+			return nil
+		}
+		// The deadline is enforced by the network stack, so it is set on the real time
+		http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(c.exportWriteTimeout))
+		ctx.Header("Content-Type", format.ContentType())
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, format.FileName(generatedAt.In(location))))
+		ctx.Status(http.StatusOK)
+		writer, err = export.NewWriter(format, ctx.Writer, lang, location, generatedAt)
+		return err
+	}
+
+	err := c.transactionService.Export(ctx.Request.Context(), userId, func(exported dto.ExportedTransactionDTO) error {
+		if err := start(); err != nil {
+			return err
+		}
+		return writer.Write(exported)
+	})
+	if err == nil {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if writer == nil {
+		ctx.Error(err)
+		return
+	}
+	logger.FromContext(ctx.Request.Context()).ErrorContext(ctx.Request.Context(), "transaction export interrupted",
+		"user_id", userId,
+		"format", format,
+		"error", err,
+	)
+	panic(http.ErrAbortHandler)
+}
+
+func (c *TransactionController) GetTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	transaction, err := c.transactionService.FindByID(ctx.Request.Context(), userId, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	etag := request.ETag(transaction.Version())
+	ctx.Header("ETag", etag)
+	if request.NotModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	c.respond(ctx, transaction, http.StatusOK)
+}
+
 func (c *TransactionController) CreateTransaction(ctx *gin.Context) {
 	var createTransactionRequest transaction.CreateTransactionRequest
-	if err := ctx.ShouldBindJSON(&createTransactionRequest); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := request.BindJSON(ctx, &createTransactionRequest); err != nil {
+		ctx.Error(err)
 		return
 	}
 
-	// TODO: get user id from auth
-	userId := uuid.New()
+	userId, _ := principal.UserID(ctx.Request.Context())
 	createTransactionDTO := createTransactionRequest.ToCreateTransactionDTO(userId)
 
-	transaction, err := c.transactionService.Create(createTransactionDTO)
+	transaction, err := c.transactionService.Create(ctx.Request.Context(), createTransactionDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", "/v1/transactions/"+transaction.ID().String())
+	c.respond(ctx, transaction, http.StatusCreated)
+}
+
+func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var updateTransactionRequest transaction.UpdateTransactionRequest
+	if err := request.BindJSON(ctx, &updateTransactionRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	updateTransactionDTO := updateTransactionRequest.ToUpdateTransactionDTO(userId, id, version)
+
+	transaction, err := c.transactionService.Update(ctx.Request.Context(), updateTransactionDTO)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	response := transactionResponse.BuildTransactionResponse(ctx, *transaction, http.StatusCreated)
-	ctx.JSON(http.StatusCreated, response)
+	c.respond(ctx, transaction, http.StatusOK)
+}
+
+func (c *TransactionController) PatchTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var patchTransactionRequest transaction.PatchTransactionRequest
+	if err := request.BindJSON(ctx, &patchTransactionRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	transaction, err := c.transactionService.Patch(ctx.Request.Context(), patchTransactionRequest.ToPatchTransactionDTO(userId, id, version))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, transaction, http.StatusOK)
+}
+
+func (c *TransactionController) DeleteTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, c.requireIfMatch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	if err := c.transactionService.Delete(ctx.Request.Context(), userId, id, version); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *TransactionController) RestoreTransaction(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := request.IfMatch(ctx, false)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	transaction, err := c.transactionService.Restore(ctx.Request.Context(), userId, id, version)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	c.respond(ctx, transaction, http.StatusOK)
+}
+
+func (c *TransactionController) BatchTransactions(ctx *gin.Context) {
+	var batchRequest transaction.BatchTransactionRequest
+	if err := request.BindJSON(ctx, &batchRequest); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if len(batchRequest.Operations) > c.maxBatchOperations {
+		ctx.Error(domainerror.NewValidation("operations", "too_many_operations", fmt.Sprintf("a batch may have at most %d operations", c.maxBatchOperations)).
+			WithParams(map[string]string{"max": strconv.Itoa(c.maxBatchOperations)}))
+		return
+	}
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	batchDTO := batchRequest.ToBatchTransactionDTO(userId, c.requireIfMatch)
+
+	results, err := c.transactionService.Batch(ctx.Request.Context(), batchDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := transactionResponse.BuildBatchResponse(batchDTO.Operations, results, func(err error) *problem.Problem {
+		return middleware.Problem(ctx, err)
+	})
+
+	ctx.JSON(response.Meta.StatusCode, response)
+}
+
+func (c *TransactionController) GetTransactionHistory(ctx *gin.Context) {
+	id, err := request.PathID(ctx, "transaction")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	entries, pagination, err := c.transactionService.History(ctx.Request.Context(), userId, id, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := auditResponse.BuildHistoryResponse(
+		ctx,
+		entries,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// respond writes a single transaction along with the ETag of its version
+func (c *TransactionController) respond(ctx *gin.Context, transaction *entity.Transaction, statusCode int) {
+	ctx.Header("ETag", request.ETag(transaction.Version()))
+	ctx.JSON(statusCode, transactionResponse.BuildTransactionResponse(ctx, *transaction, statusCode))
 }
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	trashResponse "github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/response/trash"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController struct {
+	trashService interfaces.TrashServiceInterface
+}
+
+func NewTrashController(trashService interfaces.TrashServiceInterface) *TrashController {
+	return &TrashController{
+		trashService: trashService,
+	}
+}
+
+func (c *TrashController) GetTrash(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+
+	userId, _ := principal.UserID(ctx.Request.Context())
+	items, pagination, err := c.trashService.FindAllPaginated(ctx.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := trashResponse.BuildTrashResponse(
+		ctx,
+		items,
+		c.trashService.PurgeAt,
+		pagination.Page,
+		pagination.PageSize,
+		http.StatusOK,
+	)
+
+	if response.PageInfo != nil {
+		response.PageInfo.TotalItems = int(pagination.TotalItems)
+		response.PageInfo.TotalPages = pagination.TotalPages
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveAuthenticated(cfg config.AuthConfig, userHeader, secretHeader string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(), Authentication(cfg))
+
+	var userID string
+	router.GET("/v1/transactions", func(ctx *gin.Context) {
+		id, _ := principal.UserID(ctx.Request.Context())
+		userID = id.String()
+		ctx.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/transactions", nil)
+	if userHeader != "" {
+		request.Header.Set("X-User-Id", userHeader)
+	}
+	if secretHeader != "" {
+		request.Header.Set("X-Gateway-Secret", secretHeader)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder, userID
+}
+
+func TestAuthentication(t *testing.T) {
+	headerMode := config.AuthConfig{Mode: config.AuthModeHeader, UserHeader: "X-User-Id", SecretHeader: "X-Gateway-Secret", GatewaySecret: "gateway-secret"}
+
+	t.Run("should read the user from the gateway header", func(t *testing.T) {
+		recorder, userID := serveAuthenticated(headerMode, "0195a1b2-0000-7000-8000-000000000001", "gateway-secret")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "0195a1b2-0000-7000-8000-000000000001", userID)
+	})
+
+	t.Run("should answer 401 without the header", func(t *testing.T) {
+		recorder, _ := serveAuthenticated(headerMode, "", "gateway-secret")
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should answer 401 when the user header does not come from the gateway", func(t *testing.T) {
+		for _, secret := range []string{"", "guessed-secret"} {
+			recorder, userID := serveAuthenticated(headerMode, "0195a1b2-0000-7000-8000-000000000001", secret)
+
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+			assert.Empty(t, userID)
+		}
+	})
+
+	t.Run("should answer 401 when the header is not a user id", func(t *testing.T) {
+		recorder, _ := serveAuthenticated(headerMode, "admin", "gateway-secret")
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should use the development user in dev mode", func(t *testing.T) {
+		recorder, userID := serveAuthenticated(config.AuthConfig{Mode: config.AuthModeDev, DevUserID: "0195a1b2-0000-7000-8000-00000000000d"}, "", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "0195a1b2-0000-7000-8000-00000000000d", userID)
+	})
+}
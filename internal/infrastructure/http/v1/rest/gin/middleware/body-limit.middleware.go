@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit refuses request bodies larger than maxBytes, or than the limit routeLimits sets
+// for the matched route, such as a file upload. Bodies announced as too large are refused
+// upfront; others are cut off while being read, which request.BindJSON reports as 413
+func BodyLimit(maxBytes int64, routeLimits map[string]int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit := maxBytes
+		if routeLimit, ok := routeLimits[ctx.FullPath()]; ok {
+			limit = routeLimit
+		}
+
+		if ctx.Request.ContentLength > limit {
+			ctx.Error(request.BodyTooLarge(limit))
+			ctx.Abort()
+			return
+		}
+
+		if ctx.Request.Body != nil {
+			ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS lets the configured origins call the API from a browser. Preflight requests are
+// answered here; preflights from other origins are refused with 403
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAnyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		if origin == "" {
+			ctx.Next()
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Add("Vary", "Origin")
+		preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+
+		if !allowAnyOrigin && !slices.Contains(cfg.AllowedOrigins, origin) {
+			if preflight {
+				ctx.Error(domainerror.NewForbidden("origin_not_allowed", fmt.Sprintf("origin %s is not allowed", origin)).
+					WithParams(map[string]string{"origin": origin}))
+				ctx.Abort()
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		if allowAnyOrigin && !cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			header.Set("Access-Control-Allow-Methods", allowedMethods)
+			header.Set("Access-Control-Allow-Headers", allowedHeaders)
+			header.Set("Access-Control-Max-Age", maxAge)
+			ctx.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposedHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposedHeaders)
+		}
+
+		ctx.Next()
+	}
+}
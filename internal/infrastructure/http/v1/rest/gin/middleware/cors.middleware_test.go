@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveCORS(cfg config.CORSConfig, method, origin string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(), CORS(cfg))
+	router.GET("/v1/transactions", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(method, "/v1/transactions", nil)
+	request.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCORS(t *testing.T) {
+	cfg := config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Idempotency-Key"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	t.Run("should answer preflight requests from allowed origins", func(t *testing.T) {
+		recorder := serveCORS(cfg, http.MethodOptions, "https://app.example.com")
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST", recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Idempotency-Key", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("should expose headers on actual requests", func(t *testing.T) {
+		recorder := serveCORS(cfg, http.MethodGet, "https://app.example.com")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "ETag", recorder.Header().Get("Access-Control-Expose-Headers"))
+		assert.Contains(t, recorder.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("should refuse preflight requests from other origins", func(t *testing.T) {
+		recorder := serveCORS(cfg, http.MethodOptions, "https://evil.example.com")
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("should not add CORS headers for other origins", func(t *testing.T) {
+		recorder := serveCORS(cfg, http.MethodGet, "https://evil.example.com")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
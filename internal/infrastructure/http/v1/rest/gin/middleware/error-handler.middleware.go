@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/report"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorHandler turns the last error attached with ctx.Error, or a panic, into an
+// application/problem+json response. Domain errors are mapped to their HTTP status;
+// anything else is logged and reported as a generic 500 so database and driver
+// details never reach the client.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				writeProblem(ctx, Problem(ctx, fmt.Errorf("panic: %v", recovered)))
+			}
+		}()
+
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		writeProblem(ctx, Problem(ctx, ctx.Errors.Last().Err))
+	}
+}
+
+// Problem is report.DescribeError in the language of the request, logging internal errors with
+// the method and path they were met on
+func Problem(ctx *gin.Context, err error) *problem.Problem {
+	requestLogger := logger.FromContext(ctx.Request.Context()).With("method", ctx.Request.Method, "path", ctx.Request.URL.Path)
+	return report.DescribeError(logger.WithLogger(ctx.Request.Context(), requestLogger), err)
+}
+
+func writeProblem(ctx *gin.Context, p *problem.Problem) {
+	p.WithInstance(ctx.Request.URL.Path).
+		WithRequestID(requestid.FromContext(ctx.Request.Context()))
+
+	if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+		p.WithTraceID(spanContext.TraceID().String())
+	}
+
+	ctx.Header("Content-Type", problem.ContentType)
+	ctx.AbortWithStatusJSON(p.Status, p)
+}
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	return serveIn("en", handler)
+}
+
+func serveIn(acceptLanguage string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), Language(), ErrorHandler())
+	router.GET("/transactions", handler)
+
+	request := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	request.Header.Set("X-Request-ID", "req-123")
+	request.Header.Set("Accept-Language", acceptLanguage)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func serveError(err error) *httptest.ResponseRecorder {
+	return serve(func(ctx *gin.Context) {
+		ctx.Error(err)
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("should map each domain error kind to its status code", func(t *testing.T) {
+		cases := map[int]error{
+			http.StatusBadRequest:            domainerror.NewInvalidInput("", "invalid_request_body", "invalid body"),
+			http.StatusUnprocessableEntity:   domainerror.NewValidation("amount", "amount_must_be_positive", "amount must be greater than 0"),
+			http.StatusNotFound:              domainerror.NewNotFound("transaction", "42"),
+			http.StatusConflict:              domainerror.NewConflict("transaction_already_exists", "transaction already exists", nil),
+			http.StatusForbidden:             domainerror.NewForbidden("not_owner", "not allowed"),
+			http.StatusUnauthorized:          domainerror.NewUnauthenticated("authentication_required", "authentication is required"),
+			http.StatusRequestEntityTooLarge: domainerror.NewTooLarge("request_body_too_large", "request body is too large"),
+			http.StatusTooManyRequests:       domainerror.NewRateLimited("rate_limit_exceeded", "too many requests"),
+			http.StatusPreconditionFailed:    domainerror.NewPreconditionFailed("version_mismatch", "resource was modified"),
+			http.StatusPreconditionRequired:  domainerror.NewPreconditionRequired("precondition_required", "If-Match is required"),
+		}
+
+		for status, err := range cases {
+			recorder := serveError(err)
+			assert.Equal(t, status, recorder.Code)
+		}
+	})
+
+	t.Run("should render validation errors as problem details", func(t *testing.T) {
+		recorder := serveError(fmt.Errorf("create: %w", domainerror.NewValidation("amount", "amount_must_be_positive", "amount must be greater than 0")))
+
+		var body problem.Problem
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, problem.ContentType, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "urn:flux-control:problem:validation", body.Type)
+		assert.Equal(t, "Unprocessable Entity", body.Title)
+		assert.Equal(t, http.StatusUnprocessableEntity, body.Status)
+		assert.Equal(t, "amount must be greater than 0", body.Detail)
+		assert.Equal(t, "/transactions", body.Instance)
+		assert.Equal(t, "req-123", body.RequestID)
+		assert.Equal(t, []problem.FieldError{
+			{Field: "amount", Code: "amount_must_be_positive", Message: "amount must be greater than 0"},
+		}, body.Errors)
+	})
+
+	t.Run("should localize messages to the negotiated language", func(t *testing.T) {
+		err := domainerror.NewInvalidInput("", "invalid_request_body", "request body is invalid").WithDetails(
+			domainerror.NewInvalidInput("amount", "too_small", "amount must be greater than 0").
+				WithParams(map[string]string{"field": "amount", "param": "0"}),
+		)
+		recorder := serveIn("pt-BR,pt;q=0.9,en;q=0.8", func(ctx *gin.Context) {
+			ctx.Error(err)
+		})
+
+		var body problem.Problem
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "pt-BR", recorder.Header().Get("Content-Language"))
+		assert.Equal(t, "o corpo da requisição é inválido", body.Detail)
+		assert.Equal(t, "amount deve ser maior que 0", body.Errors[0].Message)
+	})
+
+	t.Run("should list every detail of an invalid input error", func(t *testing.T) {
+		err := domainerror.NewInvalidInput("", "invalid_request_body", "request body is invalid").WithDetails(
+			domainerror.NewInvalidInput("categoryId", "required", "categoryId is required"),
+			domainerror.NewInvalidInput("amount", "too_small", "amount must be greater than 0"),
+		)
+		recorder := serveError(err)
+
+		var body problem.Problem
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Len(t, body.Errors, 2)
+		assert.Equal(t, "categoryId", body.Errors[0].Field)
+		assert.Equal(t, "amount", body.Errors[1].Field)
+	})
+
+	t.Run("should hide internal errors behind a generic 500", func(t *testing.T) {
+		recorder := serveError(errors.New("Error 1045: Access denied for user 'root'@'localhost'"))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), "Access denied")
+		assert.Contains(t, recorder.Body.String(), "req-123")
+	})
+
+	t.Run("should recover from panics with a problem response", func(t *testing.T) {
+		recorder := serve(func(ctx *gin.Context) {
+			panic("boom")
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, problem.ContentType, recorder.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a request without repeating its effect
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the headers handlers set to describe their response, which a replay must
+// repeat; headers set by other middlewares, such as the rate limit, are computed afresh
+var replayedHeaders = []string{"Location", "ETag", request.PreferenceAppliedHeader}
+
+// Idempotency makes requests carrying an Idempotency-Key safe to retry. The first request
+// with a key reserves it for the user for ttl and its response is stored; retries with the
+// same key and body get the stored response back, while reusing the key for a different
+// body is refused with 422. Requests that fail with an error response release the key, so
+// a retry runs again instead of replaying the failure.
+func Idempotency(records repository.IdempotencyRepositoryInterface, clock clock.Clock, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		userID, authenticated := principal.UserID(ctx.Request.Context())
+		if key == "" || !authenticated {
+			ctx.Next()
+			return
+		}
+
+		if !validIdempotencyKey(key) {
+			abortWithError(ctx, domainerror.NewInvalidInput(IdempotencyKeyHeader, "idempotency_key_invalid", "Idempotency-Key must have between 1 and 255 printable characters"))
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			var maxBytesError *http.MaxBytesError
+			if errors.As(err, &maxBytesError) {
+				err = request.BodyTooLarge(maxBytesError.Limit)
+			}
+			abortWithError(ctx, err)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := entity.NewIdempotencyRecord(clock, userID, key, requestHash(ctx.Request, body), ttl)
+		if err != nil {
+			abortWithError(ctx, err)
+			return
+		}
+
+		existing, err := records.Reserve(ctx.Request.Context(), record)
+		if err != nil {
+			abortWithError(ctx, err)
+			return
+		}
+		if existing != nil {
+			replay(ctx, existing, record.RequestHash())
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		ctx.Next()
+
+		// The request may have been cancelled by the client; the reservation must still be settled
+		settleCtx := context.WithoutCancel(ctx.Request.Context())
+		status := ctx.Writer.Status()
+
+		if len(ctx.Errors) > 0 || !ctx.Writer.Written() || status >= http.StatusInternalServerError {
+			if err := records.Release(settleCtx, userID, key); err != nil {
+				logger.FromContext(settleCtx).ErrorContext(settleCtx, "failed to release idempotency key", "error", err)
+			}
+			return
+		}
+
+		record.Complete(status, ctx.Writer.Header().Get("Content-Type"), responseHeaders(ctx.Writer.Header()), recorder.body.Bytes())
+		if err := records.Complete(settleCtx, record); err != nil {
+			logger.FromContext(settleCtx).ErrorContext(settleCtx, "failed to store idempotent response", "error", err)
+		}
+	}
+}
+
+func replay(ctx *gin.Context, existing *entity.IdempotencyRecord, hash string) {
+	switch {
+	case !existing.Matches(hash):
+		abortWithError(ctx, domainerror.NewValidation(IdempotencyKeyHeader, "idempotency_key_reused", "Idempotency-Key was already used with a different request"))
+	case !existing.Completed():
+		abortWithError(ctx, domainerror.NewConflict("idempotency_key_in_use", "a request with this Idempotency-Key is still being processed", nil))
+	default:
+		for name, value := range existing.Headers() {
+			ctx.Header(name, value)
+		}
+		ctx.Header(IdempotentReplayedHeader, "true")
+		ctx.Data(existing.StatusCode(), existing.ContentType(), existing.Body())
+		ctx.Abort()
+	}
+}
+
+// responseHeaders picks the replayed headers the handler set
+func responseHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string)
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// requestHash identifies a request by its method, path and body. A multipart body is identified
+// by its parts rather than its bytes, as clients pick a new boundary on every attempt.
+func requestHash(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, req.Method+" "+req.URL.Path+"\n")
+	if parts, ok := multipartHash(req, body); ok {
+		hash.Write(parts)
+	} else {
+		hash.Write(body)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// multipartHash hashes the fields and files of a multipart/form-data body by field name, in
+// name order. A body that is not multipart or cannot be read as such reports false.
+func multipartHash(req *http.Request, body []byte) ([]byte, bool) {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, false
+	}
+
+	type field struct {
+		name    string
+		content []byte
+	}
+	var fields []field
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+		content := sha256.New()
+		if _, err := io.Copy(content, part); err != nil {
+			return nil, false
+		}
+		fields = append(fields, field{name: part.FormName(), content: content.Sum(nil)})
+	}
+	slices.SortStableFunc(fields, func(a, b field) int { return strings.Compare(a.name, b.name) })
+
+	hash := sha256.New()
+	for _, field := range fields {
+		io.WriteString(hash, strconv.Quote(field.name))
+		hash.Write(field.content)
+	}
+	return hash.Sum(nil), true
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func abortWithError(ctx *gin.Context, err error) {
+	ctx.Error(err)
+	ctx.Abort()
+}
+
+// responseRecorder keeps a copy of the response body while it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
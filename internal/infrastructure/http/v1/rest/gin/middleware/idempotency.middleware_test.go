@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency(t *testing.T) {
+	now := clock.NewFixed(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	calls := 0
+	fail := false
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(
+		ErrorHandler(),
+		Authentication(config.AuthConfig{Mode: config.AuthModeHeader, UserHeader: "X-User-Id", SecretHeader: "X-Gateway-Secret", GatewaySecret: "gateway-secret"}),
+		Idempotency(memory.NewIdempotencyRepository(memory.NewStore()), now, time.Hour),
+	)
+	router.POST("/v1/transactions", func(ctx *gin.Context) {
+		calls++
+		if fail {
+			ctx.Error(domainerror.NewValidation("categoryId", "category_not_found", "category does not exist"))
+			return
+		}
+		ctx.Header("Location", "/v1/transactions/"+strconv.Itoa(calls))
+		ctx.Header("ETag", `"`+strconv.Itoa(calls)+`"`)
+		ctx.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	post := func(userID, key, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(body))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set(IdempotencyKeyHeader, key)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// postFile uploads content as a new multipart body, so each call has a boundary of its own
+	postFile := func(userID, key, content string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		form.WriteField("commit", "true")
+		file, _ := form.CreateFormFile("file", "statement.csv")
+		file.Write([]byte(content))
+		form.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/v1/transactions", &body)
+		request.Header.Set("Content-Type", form.FormDataContentType())
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set(IdempotencyKeyHeader, key)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	const user = "0195a1b2-0000-7000-8000-000000000001"
+
+	t.Run("should replay the first response to a retry", func(t *testing.T) {
+		first := post(user, "key-1", `{"amount":10}`)
+		retry := post(user, "key-1", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "/v1/transactions/1", retry.Header().Get("Location"))
+		assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should refuse the same key with a different body", func(t *testing.T) {
+		recorder := post(user, "key-1", `{"amount":20}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "idempotency_key_reused")
+	})
+
+	t.Run("should scope keys to the user", func(t *testing.T) {
+		recorder := post("0195a1b2-0000-7000-8000-000000000002", "key-1", `{"amount":20}`)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Empty(t, recorder.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("should run the request again once the key expired", func(t *testing.T) {
+		now.Advance(time.Hour)
+		before := calls
+
+		recorder := post(user, "key-1", `{"amount":20}`)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, before+1, calls)
+	})
+
+	t.Run("should not store error responses", func(t *testing.T) {
+		fail = true
+		post(user, "key-2", `{"amount":10}`)
+		fail = false
+
+		recorder := post(user, "key-2", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Empty(t, recorder.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("should replay a multipart retry sent with another boundary", func(t *testing.T) {
+		before := calls
+
+		first := postFile(user, "key-3", "date,amount\n2025-03-01,10\n")
+		retry := postFile(user, "key-3", "date,amount\n2025-03-01,10\n")
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, before+1, calls)
+	})
+
+	t.Run("should refuse the same key with a different file", func(t *testing.T) {
+		recorder := postFile(user, "key-3", "date,amount\n2025-03-01,20\n")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "idempotency_key_reused")
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		recorder := post(user, "key with spaces", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
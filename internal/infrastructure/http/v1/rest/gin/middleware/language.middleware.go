@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Language negotiates the response language from Accept-Language and stores it in the
+// request context, defaulting to Brazilian Portuguese
+func Language() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lang := i18n.Negotiate(ctx.GetHeader("Accept-Language"))
+
+		ctx.Request = ctx.Request.WithContext(i18n.WithLanguage(ctx.Request.Context(), lang))
+		ctx.Header("Content-Language", lang.String())
+
+		ctx.Next()
+	}
+}
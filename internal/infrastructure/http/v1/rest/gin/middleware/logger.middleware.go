@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger binds a logger tagged with the request and trace IDs to the request context, so
+// services and repositories log with it, and writes one access log entry per request
+func Logger(base *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestLogger := base.With("request_id", requestid.FromContext(ctx.Request.Context()))
+		if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+			requestLogger = requestLogger.With("trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String())
+		}
+		ctx.Request = ctx.Request.WithContext(logger.WithLogger(ctx.Request.Context(), requestLogger))
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		requestLogger.LogAttrs(ctx.Request.Context(), level, "http request",
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", ctx.FullPath()),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+			slog.Int("bytes", ctx.Writer.Size()),
+		)
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPObserver receives the duration of each HTTP request
+type HTTPObserver interface {
+	ObserveHTTPRequest(method, route, status string, seconds float64)
+}
+
+// Metrics reports request durations labelled by route template rather than raw path,
+// which keeps label cardinality bounded
+func Metrics(observer HTTPObserver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		observer.ObserveHTTPRequest(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status()), time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/ratelimit"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/principal"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit takes one token per request from the bucket of the authenticated user, or of the
+// client IP for requests whose user Identify could not verify, and answers 429 with Retry-After once it is empty. The
+// RateLimit-* headers tell clients how much of the quota is left. When the store fails the
+// request is let through rather than turning a limiter outage into an API outage
+func RateLimit(store ratelimit.Store, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := "ip:" + ctx.ClientIP()
+		if userID, ok := principal.UserID(ctx.Request.Context()); ok {
+			key = "user:" + userID.String()
+		}
+
+		decision, err := store.Take(ctx.Request.Context(), key, limit)
+		if err != nil {
+			logger.FromContext(ctx.Request.Context()).WarnContext(ctx.Request.Context(), "rate limiter unavailable", "error", err)
+			ctx.Next()
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", wholeSeconds(decision.ResetAfter))
+
+		if !decision.Allowed {
+			retryAfter := wholeSeconds(decision.RetryAfter)
+			header.Set("Retry-After", retryAfter)
+			ctx.Error(domainerror.NewRateLimited("rate_limit_exceeded", fmt.Sprintf("too many requests, try again in %s seconds", retryAfter)).
+				WithParams(map[string]string{"retryAfter": retryAfter}))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// wholeSeconds rounds d up, so clients never retry before a token is available
+func wholeSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := config.AuthConfig{Mode: config.AuthModeHeader, UserHeader: "X-User-Id", SecretHeader: "X-Gateway-Secret", GatewaySecret: "gateway-secret"}
+	router := gin.New()
+	router.Use(
+		ErrorHandler(),
+		Identify(auth),
+		RateLimit(ratelimit.NewMemoryStore(clock.NewFixed(time.Now()), time.Minute), ratelimit.Limit{Rate: 0.5, Burst: 1}),
+		Authentication(auth),
+	)
+	router.GET("/v1/transactions", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	send := func(userID, secret string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/v1/transactions", nil)
+		if userID != "" {
+			request.Header.Set("X-User-Id", userID)
+		}
+		if secret != "" {
+			request.Header.Set("X-Gateway-Secret", secret)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	call := func(userID string) *httptest.ResponseRecorder {
+		if userID == "" {
+			return send("", "")
+		}
+		return send(userID, "gateway-secret")
+	}
+
+	t.Run("should report the remaining quota", func(t *testing.T) {
+		recorder := call("0195a1b2-0000-7000-8000-000000000001")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "2", recorder.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("should answer 429 with Retry-After once the quota is used", func(t *testing.T) {
+		recorder := call("0195a1b2-0000-7000-8000-000000000001")
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("should keep a separate quota per user", func(t *testing.T) {
+		recorder := call("0195a1b2-0000-7000-8000-000000000002")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("should limit requests without credentials by client IP", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, call("").Code)
+
+		recorder := call("")
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+
+	t.Run("should limit forged user headers by client IP", func(t *testing.T) {
+		recorder := send("0195a1b2-0000-7000-8000-000000000003", "")
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+}
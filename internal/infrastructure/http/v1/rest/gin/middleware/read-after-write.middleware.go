@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/readafterwrite"
+	"github.com/gin-gonic/gin"
+)
+
+// ReadAfterWrite makes reads that follow a write in the same request go to the primary
+// database instead of a read replica
+func ReadAfterWrite() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(readafterwrite.WithTracking(ctx.Request.Context()))
+		ctx.Next()
+	}
+}
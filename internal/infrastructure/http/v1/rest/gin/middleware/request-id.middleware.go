@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestID reuses the caller's X-Request-ID when it is well formed, or generates a new one,
+// and makes it available to the handlers and in the response headers
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(requestid.Header)
+		if !requestid.IsValid(id) {
+			id = uuid.NewString()
+		}
+
+		ctx.Request = ctx.Request.WithContext(requestid.WithRequestID(ctx.Request.Context(), id))
+		ctx.Header(requestid.Header, id)
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), Tracing(), ErrorHandler())
+	router.GET("/v1/transactions/:id", func(ctx *gin.Context) {
+		ctx.Error(errors.New("database is down"))
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/transactions/42", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	t.Run("should name the server span after the route template", func(t *testing.T) {
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "GET /v1/transactions/:id", spans[0].Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	})
+
+	t.Run("should echo the trace id in problem responses", func(t *testing.T) {
+		var body problem.Problem
+		assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", body.TraceID)
+	})
+}
@@ -0,0 +1,238 @@
+package request
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// jsonFieldName makes validator report fields by the name clients send them with
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// BindJSON decodes and validates the request body into obj. Keys must match the json tags
+// of obj exactly, so unknown or differently cased keys are rejected instead of silently
+// ignored or matched. Failures are reported as an invalid input domain error holding one
+// detail per offending field.
+func BindJSON(ctx *gin.Context, obj any) error {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return BodyTooLarge(maxBytesError.Limit)
+		}
+		return translateBindingError(err)
+	}
+
+	return DecodeJSON(body, obj)
+}
+
+// DecodeJSON decodes and validates a JSON document carried inside a request rather than as its
+// body, such as a multipart form field, with the same rules and error reporting as BindJSON
+func DecodeJSON(data []byte, obj any) error {
+	if unknown := unknownFields(data, obj); unknown != nil {
+		return unknown
+	}
+
+	if err := binding.JSON.BindBody(data, obj); err != nil {
+		return translateBindingError(err)
+	}
+	return nil
+}
+
+// BindMultipartForm decodes and validates a multipart/form-data body into obj, whose fields
+// are matched by their form tags. Failures are reported as BindJSON does.
+func BindMultipartForm(ctx *gin.Context, obj any) error {
+	if err := ctx.ShouldBindWith(obj, binding.FormMultipart); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return BodyTooLarge(maxBytesError.Limit)
+		}
+		if errors.Is(err, http.ErrNotMultipart) || errors.Is(err, http.ErrMissingBoundary) {
+			return domainerror.NewInvalidInput("", "multipart_required", "request body must be multipart/form-data")
+		}
+		return translateBindingError(err)
+	}
+	return nil
+}
+
+// BindQuery decodes and validates the query string into obj, whose fields are matched by their
+// form tags. Failures are reported as BindJSON does, under the invalid_query code.
+func BindQuery(ctx *gin.Context, obj any) error {
+	if err := ctx.ShouldBindQuery(obj); err != nil {
+		invalid, _ := domainerror.As(translateBindingError(err))
+		invalid.Code = "invalid_query"
+		invalid.Message = "query string is invalid"
+		return invalid
+	}
+	return nil
+}
+
+// Validate checks obj against its binding rules, reporting failures as BindJSON does. It serves
+// values that were decoded as part of a larger body but must be validated on their own.
+func Validate(obj any) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return translateBindingError(err)
+	}
+	return nil
+}
+
+// BodyTooLarge reports a request body longer than limit bytes
+func BodyTooLarge(limit int64) error {
+	return domainerror.NewTooLarge("request_body_too_large", fmt.Sprintf("request body must be at most %d bytes", limit)).
+		WithParams(map[string]string{"limit": strconv.FormatInt(limit, 10)})
+}
+
+// unknownFields reports top-level keys of body that are not json field names of obj
+func unknownFields(body []byte, obj any) error {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		// Not an object; the decoder will report the real problem
+		return nil
+	}
+
+	known := make(map[string]bool)
+	objType := reflect.TypeOf(obj)
+	for objType.Kind() == reflect.Pointer {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < objType.NumField(); i++ {
+		if name := jsonFieldName(objType.Field(i)); name != "" {
+			known[name] = true
+		}
+	}
+
+	invalid := domainerror.NewInvalidInput("", "invalid_request_body", "request body is invalid")
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if !known[key] {
+			invalid.WithDetails(domainerror.NewInvalidInput(key, "unknown_field", fmt.Sprintf("%s is not a known field", key)).
+				WithParams(map[string]string{"field": key}))
+		}
+	}
+
+	if len(invalid.Details) == 0 {
+		return nil
+	}
+	return invalid
+}
+
+func translateBindingError(err error) error {
+	invalid := domainerror.NewInvalidInput("", "invalid_request_body", "request body is invalid")
+	invalid.Err = err
+
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			invalid.WithDetails(describeFieldError(fieldError))
+		}
+	case errors.As(err, &typeError):
+		expected := jsonTypeName(typeError.Type)
+		invalid.WithDetails(domainerror.NewInvalidInput(
+			typeError.Field,
+			"invalid_type",
+			fmt.Sprintf("%s must be of type %s", typeError.Field, expected),
+		).WithParams(map[string]string{"field": typeError.Field, "type": expected}))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		invalid.WithDetails(domainerror.NewInvalidInput(field, "unknown_field", fmt.Sprintf("%s is not a known field", field)).
+			WithParams(map[string]string{"field": field}))
+	case errors.As(err, &syntaxError), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		invalid.Code = "malformed_json"
+		invalid.Message = "request body is not valid JSON"
+	}
+
+	return invalid
+}
+
+// jsonTypeName names the JSON type a Go type is decoded from, as clients know it
+func jsonTypeName(goType reflect.Type) string {
+	if goType == nil {
+		return "value"
+	}
+	if goType.Implements(textUnmarshaler) || reflect.PointerTo(goType).Implements(textUnmarshaler) {
+		// uuids, times and the like are sent as strings
+		return "string"
+	}
+
+	switch goType.Kind() {
+	case reflect.Pointer:
+		return jsonTypeName(goType.Elem())
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return "value"
+	}
+}
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// describeFieldError turns a failed validation rule into a field-level domain error
+func describeFieldError(fieldError validator.FieldError) *domainerror.Error {
+	field := fieldError.Namespace()
+	if _, path, found := strings.Cut(field, "."); found {
+		field = path
+	}
+
+	var code, message string
+	tag, param := fieldError.Tag(), fieldError.Param()
+
+	switch {
+	case tag == "required":
+		code, message = "required", fmt.Sprintf("%s is required", field)
+	case fieldError.Kind() == reflect.String && (tag == "min" || tag == "gte"):
+		code, message = "too_short", fmt.Sprintf("%s must have at least %s characters", field, param)
+	case fieldError.Kind() == reflect.String && (tag == "max" || tag == "lte"):
+		code, message = "too_long", fmt.Sprintf("%s must have at most %s characters", field, param)
+	case tag == "notfarfuture":
+		param = strconv.Itoa(MaxYearsAhead)
+		code, message = "too_far_in_future", fmt.Sprintf("%s must be at most %s years in the future", field, param)
+	case tag == "gt":
+		code, message = "too_small", fmt.Sprintf("%s must be greater than %s", field, param)
+	case tag == "gte" || tag == "min":
+		code, message = "below_minimum", fmt.Sprintf("%s must be at least %s", field, param)
+	case tag == "lt":
+		code, message = "too_large", fmt.Sprintf("%s must be less than %s", field, param)
+	case tag == "lte" || tag == "max":
+		code, message = "above_maximum", fmt.Sprintf("%s must be at most %s", field, param)
+	default:
+		code, message = "invalid", fmt.Sprintf("%s is invalid", field)
+	}
+
+	return domainerror.NewInvalidInput(field, code, message).
+		WithParams(map[string]string{"field": field, "param": param})
+}
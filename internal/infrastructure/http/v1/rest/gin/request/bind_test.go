@@ -0,0 +1,82 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleRequest struct {
+	Name   string  `json:"name" binding:"required"`
+	Amount float64 `json:"amount" binding:"gt=0"`
+	Note   string  `json:"note" binding:"max=5"`
+}
+
+func bind(body string) error {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	var request sampleRequest
+	return BindJSON(ctx, &request)
+}
+
+func TestBindJSON(t *testing.T) {
+	t.Run("should accept a valid body", func(t *testing.T) {
+		assert.Nil(t, bind(`{"name":"rent","amount":10,"note":"ok"}`))
+	})
+
+	t.Run("should report each failed rule with the json field name", func(t *testing.T) {
+		err := bind(`{"amount":0,"note":"too long"}`)
+
+		domainErr, ok := domainerror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, domainerror.KindInvalidInput, domainErr.Kind)
+		assert.Len(t, domainErr.Details, 3)
+		assert.Equal(t, "name", domainErr.Details[0].Field)
+		assert.Equal(t, "required", domainErr.Details[0].Code)
+		assert.Equal(t, "amount", domainErr.Details[1].Field)
+		assert.Equal(t, "too_small", domainErr.Details[1].Code)
+		assert.Equal(t, "note", domainErr.Details[2].Field)
+		assert.Equal(t, "too_long", domainErr.Details[2].Code)
+		assert.NotContains(t, domainErr.Message, "Key:")
+	})
+
+	t.Run("should report fields sent with the wrong type", func(t *testing.T) {
+		err := bind(`{"name":"rent","amount":"ten"}`)
+
+		domainErr, _ := domainerror.As(err)
+		assert.Len(t, domainErr.Details, 1)
+		assert.Equal(t, "amount", domainErr.Details[0].Field)
+		assert.Equal(t, "invalid_type", domainErr.Details[0].Code)
+		assert.Equal(t, "amount must be of type number", domainErr.Details[0].Message)
+		assert.Equal(t, "number", domainErr.Details[0].Params["type"])
+	})
+
+	t.Run("should report malformed json", func(t *testing.T) {
+		err := bind(`{"name":`)
+
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, "malformed_json", domainErr.Code)
+	})
+
+	t.Run("should report bodies cut off by the size limit", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"a very long rent"}`))
+		ctx.Request.Body = http.MaxBytesReader(recorder, ctx.Request.Body, 8)
+
+		err := BindJSON(ctx, &sampleRequest{})
+
+		domainErr, _ := domainerror.As(err)
+		assert.Equal(t, domainerror.KindTooLarge, domainErr.Kind)
+		assert.Equal(t, "8", domainErr.Params["limit"])
+	})
+}
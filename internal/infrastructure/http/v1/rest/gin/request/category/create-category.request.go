@@ -0,0 +1,22 @@
+package category
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+type CreateCategoryRequest struct {
+	Name string            `json:"name" binding:"required,max=100"`
+	Type enum.CategoryType `json:"type" binding:"required"`
+	Icon string            `json:"icon" binding:"max=50"`
+}
+
+func (r *CreateCategoryRequest) ToCreateCategoryDTO(userId uuid.UUID) *dto.CreateCategoryDTO {
+	return &dto.CreateCategoryDTO{
+		UserID: userId,
+		Name:   r.Name,
+		Type:   r.Type,
+		Icon:   r.Icon,
+	}
+}
@@ -0,0 +1,44 @@
+package category
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+// UpdateCategoryRequest replaces every editable field of a category (PUT)
+type UpdateCategoryRequest struct {
+	Name string            `json:"name" binding:"required,max=100"`
+	Type enum.CategoryType `json:"type" binding:"required"`
+	Icon string            `json:"icon" binding:"max=50"`
+}
+
+func (r *UpdateCategoryRequest) ToUpdateCategoryDTO(userId, id uuid.UUID, version int64) *dto.UpdateCategoryDTO {
+	return &dto.UpdateCategoryDTO{
+		ID:      id,
+		UserID:  userId,
+		Version: version,
+		Name:    r.Name,
+		Type:    r.Type,
+		Icon:    r.Icon,
+	}
+}
+
+// PatchCategoryRequest changes only the fields it carries (PATCH)
+type PatchCategoryRequest struct {
+	Name *string            `json:"name" binding:"omitempty,max=100"`
+	Type *enum.CategoryType `json:"type"`
+	Icon *string            `json:"icon" binding:"omitempty,max=50"`
+}
+
+// ToPatchCategoryDTO keeps the fields missing from the request nil, so they are left as stored
+func (r *PatchCategoryRequest) ToPatchCategoryDTO(userId uuid.UUID, id uuid.UUID, version int64) *dto.PatchCategoryDTO {
+	return &dto.PatchCategoryDTO{
+		ID:      id,
+		UserID:  userId,
+		Version: version,
+		Name:    r.Name,
+		Type:    r.Type,
+		Icon:    r.Icon,
+	}
+}
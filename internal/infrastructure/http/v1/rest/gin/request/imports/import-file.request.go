@@ -0,0 +1,82 @@
+package imports
+
+import (
+	"io"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/google/uuid"
+)
+
+const (
+	ImportModePreview = "preview"
+	ImportModeCommit  = "commit"
+)
+
+// ImportFileRequest is a statement upload. Its rows are read with the settings of the profile
+// named by profileId or with the settings sent as JSON, and only previewed unless mode is commit.
+type ImportFileRequest struct {
+	File      *multipart.FileHeader `form:"file" json:"file" binding:"required"`
+	Mode      string                `form:"mode" json:"mode" binding:"omitempty,oneof=preview commit"`
+	ProfileID string                `form:"profileId" json:"profileId" binding:"omitempty,uuid"`
+	Settings  string                `form:"settings" json:"settings"`
+}
+
+func (r *ImportFileRequest) ToImportCSVDTO(userId uuid.UUID, maxFileBytes int64) (*dto.ImportCSVDTO, error) {
+	importCSVDTO := &dto.ImportCSVDTO{
+		UserID: userId,
+		Commit: r.Mode == ImportModeCommit,
+	}
+
+	switch {
+	case r.ProfileID != "" && r.Settings != "":
+		return nil, domainerror.NewInvalidInput("settings", "import_settings_conflict", "send either profileId or settings, not both")
+	case r.ProfileID != "":
+		importCSVDTO.ProfileID = uuid.MustParse(r.ProfileID)
+	case r.Settings != "":
+		var settingsRequest ImportSettingsRequest
+		if err := request.DecodeJSON([]byte(r.Settings), &settingsRequest); err != nil {
+			if domainErr, ok := domainerror.As(err); ok {
+				return nil, domainErr.Within("settings")
+			}
+			return nil, err
+		}
+		settings := settingsRequest.ToImportSettings()
+		importCSVDTO.Settings = &settings
+	}
+
+	content, err := readFile(r.File, maxFileBytes)
+	if err != nil {
+		return nil, err
+	}
+	importCSVDTO.Content = content
+
+	return importCSVDTO, nil
+}
+
+// readFile returns the content of an uploaded file, refusing files larger than maxBytes
+func readFile(header *multipart.FileHeader, maxBytes int64) ([]byte, error) {
+	tooLarge := domainerror.NewTooLarge("file_too_large", "the file must be at most "+strconv.FormatInt(maxBytes, 10)+" bytes").
+		WithParams(map[string]string{"limit": strconv.FormatInt(maxBytes, 10)})
+	if header.Size > maxBytes {
+		return nil, tooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, tooLarge
+	}
+	return content, nil
+}
@@ -0,0 +1,38 @@
+package imports
+
+import (
+	"mime/multipart"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/google/uuid"
+)
+
+// ImportOFXRequest is an OFX or QFX statement upload. Its entries go to the given categories by
+// the sign of their amount, and are only previewed unless mode is commit.
+type ImportOFXRequest struct {
+	File              *multipart.FileHeader `form:"file" json:"file" binding:"required"`
+	Mode              string                `form:"mode" json:"mode" binding:"omitempty,oneof=preview commit"`
+	ExpenseCategoryID string                `form:"expenseCategoryId" json:"expenseCategoryId" binding:"omitempty,uuid"`
+	IncomeCategoryID  string                `form:"incomeCategoryId" json:"incomeCategoryId" binding:"omitempty,uuid"`
+}
+
+func (r *ImportOFXRequest) ToImportOFXDTO(userId uuid.UUID, maxFileBytes int64) (*dto.ImportOFXDTO, error) {
+	content, err := readFile(r.File, maxFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	importOFXDTO := &dto.ImportOFXDTO{
+		UserID:  userId,
+		Content: content,
+		Commit:  r.Mode == ImportModeCommit,
+	}
+	if r.ExpenseCategoryID != "" {
+		importOFXDTO.ExpenseCategoryID = uuid.MustParse(r.ExpenseCategoryID)
+	}
+	if r.IncomeCategoryID != "" {
+		importOFXDTO.IncomeCategoryID = uuid.MustParse(r.IncomeCategoryID)
+	}
+
+	return importOFXDTO, nil
+}
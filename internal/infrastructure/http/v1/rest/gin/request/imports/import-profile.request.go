@@ -0,0 +1,28 @@
+package imports
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/google/uuid"
+)
+
+type ImportProfileRequest struct {
+	Name     string                `json:"name" binding:"required,max=100"`
+	Settings ImportSettingsRequest `json:"settings"`
+}
+
+func (r *ImportProfileRequest) ToCreateImportProfileDTO(userId uuid.UUID) *dto.CreateImportProfileDTO {
+	return &dto.CreateImportProfileDTO{
+		UserID:   userId,
+		Name:     r.Name,
+		Settings: r.Settings.ToImportSettings(),
+	}
+}
+
+func (r *ImportProfileRequest) ToUpdateImportProfileDTO(userId, id uuid.UUID) *dto.UpdateImportProfileDTO {
+	return &dto.UpdateImportProfileDTO{
+		ID:       id,
+		UserID:   userId,
+		Name:     r.Name,
+		Settings: r.Settings.ToImportSettings(),
+	}
+}
@@ -0,0 +1,52 @@
+package imports
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ImportSettingsRequest describes how to read a statement. The delimiter defaults to a comma
+// and files are expected to start with a header row unless hasHeader is false.
+type ImportSettingsRequest struct {
+	Delimiter         string               `json:"delimiter" binding:"max=4"`
+	DecimalComma      bool                 `json:"decimalComma"`
+	DateFormat        string               `json:"dateFormat" binding:"required,max=32"`
+	HasHeader         *bool                `json:"hasHeader"`
+	NegateAmounts     bool                 `json:"negateAmounts"`
+	Columns           ImportColumnsRequest `json:"columns"`
+	ExpenseCategoryID *uuid.UUID           `json:"expenseCategoryId"`
+	IncomeCategoryID  *uuid.UUID           `json:"incomeCategoryId"`
+}
+
+type ImportColumnsRequest struct {
+	Date        string `json:"date" binding:"required,max=100"`
+	Amount      string `json:"amount" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=100"`
+	Category    string `json:"category" binding:"max=100"`
+}
+
+func (r *ImportSettingsRequest) ToImportSettings() entity.ImportSettings {
+	settings := entity.ImportSettings{
+		Delimiter:     r.Delimiter,
+		DecimalComma:  r.DecimalComma,
+		DateFormat:    r.DateFormat,
+		HasHeader:     r.HasHeader == nil || *r.HasHeader,
+		NegateAmounts: r.NegateAmounts,
+		Columns: entity.ImportColumns{
+			Date:        r.Columns.Date,
+			Amount:      r.Columns.Amount,
+			Description: r.Columns.Description,
+			Category:    r.Columns.Category,
+		},
+	}
+	if settings.Delimiter == "" {
+		settings.Delimiter = ","
+	}
+	if r.ExpenseCategoryID != nil {
+		settings.ExpenseCategoryID = *r.ExpenseCategoryID
+	}
+	if r.IncomeCategoryID != nil {
+		settings.IncomeCategoryID = *r.IncomeCategoryID
+	}
+	return settings
+}
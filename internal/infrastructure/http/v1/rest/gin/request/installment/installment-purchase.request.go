@@ -0,0 +1,57 @@
+package installment
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+// CreateInstallmentPurchaseRequest splits a purchase into monthly installments. The description
+// leaves room for the "(3/12)" added to the transaction of each installment, and the remainder
+// defaults to the first installment.
+type CreateInstallmentPurchaseRequest struct {
+	CategoryID   uuid.UUID `json:"categoryId" binding:"required"`
+	Description  string    `json:"description" binding:"max=240"`
+	TotalAmount  float64   `json:"totalAmount" binding:"gt=0"`
+	Installments int       `json:"installments"`
+	FirstDueAt   time.Time `json:"firstDueAt" binding:"required,notfarfuture"`
+	Remainder    string    `json:"remainder"`
+}
+
+// EditRemainingInstallmentsRequest replaces what the installments not due yet record.
+// TotalAmount is the new total of the purchase, installments already due included.
+type EditRemainingInstallmentsRequest struct {
+	CategoryID  uuid.UUID `json:"categoryId" binding:"required"`
+	Description string    `json:"description" binding:"max=240"`
+	TotalAmount float64   `json:"totalAmount" binding:"gt=0"`
+}
+
+func (r *CreateInstallmentPurchaseRequest) ToCreateInstallmentPurchaseDTO(userId uuid.UUID) *dto.CreateInstallmentPurchaseDTO {
+	remainder := enum.InstallmentRemainder(r.Remainder)
+	if remainder == "" {
+		remainder = enum.InstallmentRemainderFirst
+	}
+
+	return &dto.CreateInstallmentPurchaseDTO{
+		UserID:       userId,
+		CategoryID:   r.CategoryID,
+		Description:  r.Description,
+		TotalAmount:  r.TotalAmount,
+		Installments: r.Installments,
+		FirstDueAt:   r.FirstDueAt,
+		Remainder:    remainder,
+	}
+}
+
+func (r *EditRemainingInstallmentsRequest) ToEditRemainingInstallmentsDTO(userId, id uuid.UUID, version int64) *dto.EditRemainingInstallmentsDTO {
+	return &dto.EditRemainingInstallmentsDTO{
+		ID:          id,
+		UserID:      userId,
+		Version:     version,
+		CategoryID:  r.CategoryID,
+		Description: r.Description,
+		TotalAmount: r.TotalAmount,
+	}
+}
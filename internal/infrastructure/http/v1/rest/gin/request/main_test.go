@@ -0,0 +1,17 @@
+package request
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+)
+
+// testNow is the current time the binding rules read in these tests
+var testNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	ConfigureBinding(clock.NewFixed(testNow))
+	os.Exit(m.Run())
+}
@@ -0,0 +1,17 @@
+package request
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PathID reads the id path parameter, reporting a malformed one as a missing resource since no
+// resource can have it
+func PathID(ctx *gin.Context, resource string) (uuid.UUID, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, domainerror.NewNotFound(resource, ctx.Param("id"))
+	}
+	return id, nil
+}
@@ -0,0 +1,67 @@
+package request
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// ETag formats a resource version as a strong entity tag
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch returns the version a write is based on, taken from the If-Match header. Only "*" or a
+// single strong entity tag issued by ETag are accepted. A missing header fails with 428 when
+// required; otherwise a missing header and "*" both yield 0, meaning any version.
+func IfMatch(ctx *gin.Context, required bool) (int64, error) {
+	value := strings.TrimSpace(ctx.GetHeader(IfMatchHeader))
+	switch {
+	case value == "" && required:
+		return 0, domainerror.NewPreconditionRequired("precondition_required", "this request must send If-Match with the ETag of the resource")
+	case value == "" || value == "*":
+		return 0, nil
+	}
+
+	version, ok := parseETag(value)
+	if !ok {
+		return 0, invalidPrecondition(IfMatchHeader)
+	}
+	return version, nil
+}
+
+// NotModified reports whether the If-None-Match header names etag, in which case a GET should be
+// answered with 304. Entity tags are compared weakly, as RFC 9110 asks for this header.
+func NotModified(ctx *gin.Context, etag string) bool {
+	for _, candidate := range strings.Split(ctx.GetHeader(IfNoneMatchHeader), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseETag reads the version out of a strong entity tag issued by ETag
+func parseETag(value string) (int64, bool) {
+	if len(value) < 3 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+func invalidPrecondition(header string) error {
+	return domainerror.NewInvalidInput("", "invalid_precondition", header+" must be a single entity tag or *").
+		WithParams(map[string]string{"header": header})
+}
@@ -0,0 +1,67 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func contextWithHeader(name, value string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+	if value != "" {
+		ctx.Request.Header.Set(name, value)
+	}
+	return ctx
+}
+
+func TestIfMatch(t *testing.T) {
+	t.Run("should read the version out of an entity tag", func(t *testing.T) {
+		version, err := IfMatch(contextWithHeader(IfMatchHeader, ETag(3)), true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), version)
+	})
+
+	t.Run("should accept any version for a wildcard", func(t *testing.T) {
+		version, err := IfMatch(contextWithHeader(IfMatchHeader, "*"), true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), version)
+	})
+
+	t.Run("should require the header only when asked to", func(t *testing.T) {
+		_, err := IfMatch(contextWithHeader(IfMatchHeader, ""), true)
+		assert.True(t, domainerror.IsKind(err, domainerror.KindPreconditionRequired))
+
+		version, err := IfMatch(contextWithHeader(IfMatchHeader, ""), false)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), version)
+	})
+
+	t.Run("should reject weak, unquoted and listed entity tags", func(t *testing.T) {
+		for _, value := range []string{`W/"3"`, `3`, `"3", "4"`, `"abc"`} {
+			_, err := IfMatch(contextWithHeader(IfMatchHeader, value), true)
+
+			domainErr, _ := domainerror.As(err)
+			assert.Equal(t, "invalid_precondition", domainErr.Code, value)
+		}
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	t.Run("should match any listed entity tag, weak or strong", func(t *testing.T) {
+		assert.True(t, NotModified(contextWithHeader(IfNoneMatchHeader, `"1", W/"3"`), ETag(3)))
+		assert.True(t, NotModified(contextWithHeader(IfNoneMatchHeader, "*"), ETag(3)))
+	})
+
+	t.Run("should not match other versions or a missing header", func(t *testing.T) {
+		assert.False(t, NotModified(contextWithHeader(IfNoneMatchHeader, `"2"`), ETag(3)))
+		assert.False(t, NotModified(contextWithHeader(IfNoneMatchHeader, ""), ETag(3)))
+	})
+}
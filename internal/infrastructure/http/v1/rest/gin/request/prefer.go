@@ -0,0 +1,28 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PreferHeader            = "Prefer"
+	PreferenceAppliedHeader = "Preference-Applied"
+	RespondAsync            = "respond-async"
+)
+
+// PrefersAsync reports whether the Prefer header asks for the request to be handled in the
+// background (RFC 7240), answered with 202 and the job that will carry it out
+func PrefersAsync(ctx *gin.Context) bool {
+	for _, header := range ctx.Request.Header.Values(PreferHeader) {
+		for _, preference := range strings.Split(header, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			token, _, _ = strings.Cut(token, "=")
+			if strings.EqualFold(strings.TrimSpace(token), RespondAsync) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefersAsync(t *testing.T) {
+	t.Run("should find respond-async among other preferences", func(t *testing.T) {
+		assert.True(t, PrefersAsync(contextWithHeader(PreferHeader, "return=minimal, Respond-Async; wait=10")))
+	})
+
+	t.Run("should answer synchronously by default", func(t *testing.T) {
+		assert.False(t, PrefersAsync(contextWithHeader(PreferHeader, "")))
+		assert.False(t, PrefersAsync(contextWithHeader(PreferHeader, "wait=10")))
+	})
+}
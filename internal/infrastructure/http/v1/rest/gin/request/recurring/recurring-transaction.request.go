@@ -0,0 +1,92 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/google/uuid"
+)
+
+type CreateRecurringTransactionRequest struct {
+	CategoryID  uuid.UUID       `json:"categoryId" binding:"required"`
+	Amount      float64         `json:"amount" binding:"gt=0"`
+	Description string          `json:"description" binding:"max=255"`
+	StartAt     time.Time       `json:"startAt" binding:"required,notfarfuture"`
+	Schedule    ScheduleRequest `json:"schedule"`
+}
+
+// ScheduleRequest says when occurrences fall. The interval defaults to 1; until is a date, and a
+// schedule with neither until nor count runs until it is deleted.
+type ScheduleRequest struct {
+	Frequency       string `json:"frequency" binding:"required"`
+	Interval        int    `json:"interval"`
+	DayOfMonth      int    `json:"dayOfMonth"`
+	LastBusinessDay bool   `json:"lastBusinessDay"`
+	Until           string `json:"until" binding:"omitempty,datetime=2006-01-02"`
+	Count           int    `json:"count"`
+}
+
+// EditOccurrencesRequest replaces what an occurrence and the ones after it record, and when
+type EditOccurrencesRequest struct {
+	CategoryID  uuid.UUID       `json:"categoryId" binding:"required"`
+	Amount      float64         `json:"amount" binding:"gt=0"`
+	Description string          `json:"description" binding:"max=255"`
+	Schedule    ScheduleRequest `json:"schedule"`
+}
+
+// UpcomingOccurrencesRequest is the query of the upcoming occurrences of a series, 10 of them
+// unless limit says otherwise
+type UpcomingOccurrencesRequest struct {
+	Limit int `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (r *CreateRecurringTransactionRequest) ToCreateRecurringTransactionDTO(userId uuid.UUID) *dto.CreateRecurringTransactionDTO {
+	return &dto.CreateRecurringTransactionDTO{
+		UserID:      userId,
+		CategoryID:  r.CategoryID,
+		Amount:      r.Amount,
+		Description: r.Description,
+		StartAt:     r.StartAt,
+		Rule:        r.Schedule.ToRecurrenceRule(),
+	}
+}
+
+func (r *EditOccurrencesRequest) ToEditFollowingOccurrencesDTO(userId, id uuid.UUID, version int64, date time.Time) *dto.EditFollowingOccurrencesDTO {
+	return &dto.EditFollowingOccurrencesDTO{
+		ID:          id,
+		UserID:      userId,
+		Version:     version,
+		Date:        date,
+		CategoryID:  r.CategoryID,
+		Amount:      r.Amount,
+		Description: r.Description,
+		Rule:        r.Schedule.ToRecurrenceRule(),
+	}
+}
+
+func (r *ScheduleRequest) ToRecurrenceRule() entity.RecurrenceRule {
+	rule := entity.RecurrenceRule{
+		Frequency:       enum.RecurrenceFrequency(r.Frequency),
+		Interval:        r.Interval,
+		DayOfMonth:      r.DayOfMonth,
+		LastBusinessDay: r.LastBusinessDay,
+		Count:           r.Count,
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if r.Until != "" {
+		// already checked by the datetime binding rule
+		rule.Until, _ = time.Parse(time.DateOnly, r.Until)
+	}
+	return rule
+}
+
+func (r *UpcomingOccurrencesRequest) ToLimit() int {
+	if r.Limit == 0 {
+		return 10
+	}
+	return r.Limit
+}
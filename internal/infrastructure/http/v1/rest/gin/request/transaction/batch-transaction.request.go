@@ -0,0 +1,113 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/google/uuid"
+)
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// BatchTransactionRequest is a list of operations on transactions, applied all or nothing
+// (atomic, the default) or each on its own (best_effort)
+type BatchTransactionRequest struct {
+	Mode       string                  `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []BatchOperationRequest `json:"operations" binding:"required,min=1"`
+}
+
+// BatchOperationRequest is one operation of a batch. Its fields are checked when the batch
+// runs rather than when it is bound, so a bad operation of a best-effort batch fails alone.
+type BatchOperationRequest struct {
+	Op          string     `json:"op"`
+	ID          *uuid.UUID `json:"id"`
+	Version     *int64     `json:"version"`
+	CategoryID  uuid.UUID  `json:"categoryId"`
+	Amount      *float64   `json:"amount"`
+	Datetime    time.Time  `json:"datetime"`
+	Description string     `json:"description"`
+}
+
+// ToBatchTransactionDTO converts every operation, recording why one is invalid in its Err.
+// When requireVersion is set, updates and deletions must name the version they are based on.
+func (r *BatchTransactionRequest) ToBatchTransactionDTO(userId uuid.UUID, requireVersion bool) *dto.BatchTransactionDTO {
+	operations := make([]dto.BatchOperationDTO, len(r.Operations))
+	for i := range r.Operations {
+		operations[i] = r.Operations[i].toBatchOperationDTO(userId, requireVersion)
+	}
+
+	return &dto.BatchTransactionDTO{
+		UserID:     userId,
+		Atomic:     r.Mode != BatchModeBestEffort,
+		Operations: operations,
+	}
+}
+
+func (r *BatchOperationRequest) toBatchOperationDTO(userId uuid.UUID, requireVersion bool) dto.BatchOperationDTO {
+	switch r.Op {
+	case BatchOpCreate:
+		fields := CreateTransactionRequest{CategoryID: r.CategoryID, Amount: r.Amount, Datetime: r.Datetime, Description: r.Description}
+		if err := request.Validate(&fields); err != nil {
+			return dto.BatchOperationDTO{Err: err}
+		}
+		return dto.BatchOperationDTO{Create: fields.ToCreateTransactionDTO(userId)}
+
+	case BatchOpUpdate:
+		id, version, err := r.target(requireVersion)
+		if err != nil {
+			return dto.BatchOperationDTO{Err: err}
+		}
+		fields := UpdateTransactionRequest{CategoryID: r.CategoryID, Datetime: r.Datetime, Description: r.Description}
+		if r.Amount != nil {
+			fields.Amount = *r.Amount
+		}
+		if err := request.Validate(&fields); err != nil {
+			return dto.BatchOperationDTO{Err: err}
+		}
+		return dto.BatchOperationDTO{Update: fields.ToUpdateTransactionDTO(userId, id, version)}
+
+	case BatchOpDelete:
+		id, version, err := r.target(requireVersion)
+		if err != nil {
+			return dto.BatchOperationDTO{Err: err}
+		}
+		return dto.BatchOperationDTO{Delete: &dto.DeleteTransactionDTO{ID: id, UserID: userId, Version: version}}
+
+	default:
+		return dto.BatchOperationDTO{Err: domainerror.NewInvalidInput("op", "unknown_operation", "op must be create, update or delete")}
+	}
+}
+
+// target reads the transaction an update or deletion is about, and the version it is based
+// on, 0 meaning any
+func (r *BatchOperationRequest) target(requireVersion bool) (uuid.UUID, int64, error) {
+	if r.ID == nil {
+		return uuid.Nil, 0, domainerror.NewInvalidInput("id", "required", "id is required").
+			WithParams(map[string]string{"field": "id"})
+	}
+
+	if r.Version == nil {
+		if requireVersion {
+			return uuid.Nil, 0, domainerror.NewPreconditionRequired("version_required", "version is required to change an existing transaction")
+		}
+		return *r.ID, 0, nil
+	}
+
+	if *r.Version < 1 {
+		return uuid.Nil, 0, domainerror.NewInvalidInput("version", "below_minimum", "version must be at least 1").
+			WithParams(map[string]string{"field": "version", "param": "1"})
+	}
+
+	return *r.ID, *r.Version, nil
+}
@@ -0,0 +1,58 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchTransactionRequest_ToBatchTransactionDTO(t *testing.T) {
+	userID := uuid.New()
+	id := uuid.New()
+	version := int64(3)
+	amount, otherAmount := 10.0, 20.0
+
+	t.Run("should convert each kind of operation", func(t *testing.T) {
+		batch := (&BatchTransactionRequest{Operations: []BatchOperationRequest{
+			{Op: BatchOpCreate, CategoryID: uuid.New(), Amount: &amount, Datetime: time.Now()},
+			{Op: BatchOpUpdate, ID: &id, Version: &version, CategoryID: uuid.New(), Amount: &otherAmount, Datetime: time.Now()},
+			{Op: BatchOpDelete, ID: &id},
+		}}).ToBatchTransactionDTO(userID, false)
+
+		assert.True(t, batch.Atomic)
+		assert.Equal(t, 10.0, batch.Operations[0].Create.Amount)
+		assert.Equal(t, version, batch.Operations[1].Update.Version)
+		assert.Equal(t, id, batch.Operations[2].Delete.ID)
+		assert.Equal(t, int64(0), batch.Operations[2].Delete.Version)
+		for _, operation := range batch.Operations {
+			assert.Nil(t, operation.Err)
+		}
+	})
+
+	t.Run("should record why an operation is invalid without failing the others", func(t *testing.T) {
+		batch := (&BatchTransactionRequest{Mode: BatchModeBestEffort, Operations: []BatchOperationRequest{
+			{Op: "upsert"},
+			{Op: BatchOpCreate, Amount: &amount},
+			{Op: BatchOpDelete},
+			{Op: BatchOpDelete, ID: &id},
+			{Op: BatchOpDelete, ID: &id, Version: &version},
+		}}).ToBatchTransactionDTO(userID, true)
+
+		assert.False(t, batch.Atomic)
+
+		unknown, _ := domainerror.As(batch.Operations[0].Err)
+		assert.Equal(t, "unknown_operation", unknown.Code)
+
+		invalid, _ := domainerror.As(batch.Operations[1].Err)
+		assert.Equal(t, domainerror.KindInvalidInput, invalid.Kind)
+
+		missingID, _ := domainerror.As(batch.Operations[2].Err)
+		assert.Equal(t, "id", missingID.Field)
+
+		assert.True(t, domainerror.IsKind(batch.Operations[3].Err, domainerror.KindPreconditionRequired))
+		assert.Nil(t, batch.Operations[4].Err)
+	})
+}
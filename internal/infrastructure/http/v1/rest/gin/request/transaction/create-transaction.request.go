@@ -8,17 +8,17 @@ import (
 )
 
 type CreateTransactionRequest struct {
-	CategoryID  uuid.UUID
-	Amount      float64
-	Datetime    time.Time
-	Description string
+	CategoryID  uuid.UUID `json:"categoryId" binding:"required"`
+	Amount      *float64  `json:"amount" binding:"required,gt=0"`
+	Datetime    time.Time `json:"datetime" binding:"required,notfarfuture"`
+	Description string    `json:"description" binding:"max=255"`
 }
 
 func (r *CreateTransactionRequest) ToCreateTransactionDTO(userId uuid.UUID) *dto.CreateTransactionDTO {
 	return &dto.CreateTransactionDTO{
 		UserID:      userId,
 		CategoryID:  r.CategoryID,
-		Amount:      r.Amount,
+		Amount:      *r.Amount,
 		Datetime:    r.Datetime,
 		Description: r.Description,
 	}
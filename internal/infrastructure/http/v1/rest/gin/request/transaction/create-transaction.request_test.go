@@ -0,0 +1,128 @@
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/middleware"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func postCreateTransaction(t *testing.T, body string) (*httptest.ResponseRecorder, CreateTransactionRequest) {
+	gin.SetMode(gin.TestMode)
+
+	var bound CreateTransactionRequest
+	router := gin.New()
+	router.Use(middleware.Language(), middleware.ErrorHandler())
+	router.POST("/v1/transactions", func(ctx *gin.Context) {
+		if err := request.BindJSON(ctx, &bound); err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder, bound
+}
+
+func fieldErrors(t *testing.T, recorder *httptest.ResponseRecorder) map[string]string {
+	var body problem.Problem
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+	codes := make(map[string]string)
+	for _, fieldError := range body.Errors {
+		codes[fieldError.Field] = fieldError.Code
+	}
+	return codes
+}
+
+func TestCreateTransactionRequest(t *testing.T) {
+	categoryID := uuid.New()
+
+	t.Run("should bind a valid camelCase body", func(t *testing.T) {
+		recorder, bound := postCreateTransaction(t, `{
+			"categoryId": "`+categoryID.String()+`",
+			"amount": 42.5,
+			"datetime": "2025-03-10T14:30:00Z",
+			"description": "Groceries"
+		}`)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, categoryID, bound.CategoryID)
+		assert.Equal(t, 42.5, *bound.Amount)
+		assert.Equal(t, time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC), bound.Datetime)
+		assert.Equal(t, "Groceries", bound.Description)
+	})
+
+	t.Run("should report every missing required field", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{
+			"categoryId": "required",
+			"amount":     "required",
+			"datetime":   "required",
+		}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should report a missing amount as required", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{"categoryId":"`+categoryID.String()+`","datetime":"2025-03-10T14:30:00Z"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{"amount": "required"}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should reject a zero amount", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{"categoryId":"`+categoryID.String()+`","amount":0,"datetime":"2025-03-10T14:30:00Z"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{"amount": "too_small"}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should reject a non-positive amount", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{"categoryId":"`+categoryID.String()+`","amount":-10,"datetime":"2025-03-10T14:30:00Z"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{"amount": "too_small"}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should reject a description longer than 255 characters", func(t *testing.T) {
+		description := strings.Repeat("a", 256)
+		recorder, _ := postCreateTransaction(t, `{"categoryId":"`+categoryID.String()+`","amount":10,"datetime":"2025-03-10T14:30:00Z","description":"`+description+`"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{"description": "too_long"}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should reject a datetime absurdly far in the future", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{"categoryId":"`+categoryID.String()+`","amount":10,"datetime":"2205-03-10T14:30:00Z"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{"datetime": "too_far_in_future"}, fieldErrors(t, recorder))
+	})
+
+	t.Run("should reject unknown and PascalCase keys", func(t *testing.T) {
+		recorder, _ := postCreateTransaction(t, `{"CategoryID":"`+categoryID.String()+`","Amount":10,"datetime":"2025-03-10T14:30:00Z","notes":"x"}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, map[string]string{
+			"Amount":     "unknown_field",
+			"CategoryID": "unknown_field",
+			"notes":      "unknown_field",
+		}, fieldErrors(t, recorder))
+	})
+}
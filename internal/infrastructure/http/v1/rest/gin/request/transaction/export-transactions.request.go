@@ -0,0 +1,33 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/export"
+)
+
+// ExportTransactionsRequest is the query of a transaction export, in CSV unless format says
+// otherwise, with dates in the IANA time zone timeZone names
+type ExportTransactionsRequest struct {
+	Format   string `form:"format" json:"format" binding:"omitempty,oneof=csv xlsx ofx"`
+	TimeZone string `form:"timeZone" json:"timeZone" binding:"omitempty,timezone"`
+}
+
+func (r *ExportTransactionsRequest) ToFormat() export.Format {
+	if r.Format == "" {
+		return export.FormatCSV
+	}
+	return export.Format(r.Format)
+}
+
+// ToLocation is the time zone the request named, or fallback. TimeZone was validated on binding.
+func (r *ExportTransactionsRequest) ToLocation(fallback *time.Location) *time.Location {
+	if r.TimeZone == "" {
+		return fallback
+	}
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return fallback
+	}
+	return location
+}
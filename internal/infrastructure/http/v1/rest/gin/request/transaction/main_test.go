@@ -0,0 +1,15 @@
+package transaction
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+)
+
+func TestMain(m *testing.M) {
+	request.ConfigureBinding(clock.NewFixed(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)))
+	os.Exit(m.Run())
+}
@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/google/uuid"
+)
+
+// UpdateTransactionRequest replaces every editable field of a transaction (PUT)
+type UpdateTransactionRequest struct {
+	CategoryID  uuid.UUID `json:"categoryId" binding:"required"`
+	Amount      float64   `json:"amount" binding:"gt=0"`
+	Datetime    time.Time `json:"datetime" binding:"required,notfarfuture"`
+	Description string    `json:"description" binding:"max=255"`
+}
+
+func (r *UpdateTransactionRequest) ToUpdateTransactionDTO(userId, id uuid.UUID, version int64) *dto.UpdateTransactionDTO {
+	return &dto.UpdateTransactionDTO{
+		ID:          id,
+		UserID:      userId,
+		Version:     version,
+		CategoryID:  r.CategoryID,
+		Amount:      r.Amount,
+		Datetime:    r.Datetime,
+		Description: r.Description,
+	}
+}
+
+// PatchTransactionRequest changes only the fields it carries (PATCH)
+type PatchTransactionRequest struct {
+	CategoryID  *uuid.UUID `json:"categoryId"`
+	Amount      *float64   `json:"amount" binding:"omitempty,gt=0"`
+	Datetime    *time.Time `json:"datetime" binding:"omitempty,notfarfuture"`
+	Description *string    `json:"description" binding:"omitempty,max=255"`
+}
+
+// ToPatchTransactionDTO keeps the fields missing from the request nil, so they are left as stored
+func (r *PatchTransactionRequest) ToPatchTransactionDTO(userId uuid.UUID, id uuid.UUID, version int64) *dto.PatchTransactionDTO {
+	return &dto.PatchTransactionDTO{
+		ID:          id,
+		UserID:      userId,
+		Version:     version,
+		CategoryID:  r.CategoryID,
+		Amount:      r.Amount,
+		Datetime:    r.Datetime,
+		Description: r.Description,
+	}
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxYearsAhead bounds how far in the future a date may be before it is treated as a typo
+const MaxYearsAhead = 10
+
+// ConfigureBinding sets gin binding up the way requests are bound: unknown JSON keys are
+// rejected, fields are reported by their json names and the custom rules read the current time
+// from clock. The settings are process-wide, so SetupRoutes configures them once at startup.
+func ConfigureBinding(clock clock.Clock) {
+	binding.EnableDecoderDisallowUnknownFields = true
+
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(jsonFieldName)
+		validate.RegisterValidation("notfarfuture", notFarFuture(clock))
+	}
+}
+
+// notFarFuture rejects dates more than MaxYearsAhead years from now
+func notFarFuture(clock clock.Clock) validator.Func {
+	return func(field validator.FieldLevel) bool {
+		datetime, ok := field.Field().Interface().(time.Time)
+		if !ok {
+			return false
+		}
+		return !datetime.After(clock.Now().AddDate(MaxYearsAhead, 0, 0))
+	}
+}
@@ -0,0 +1,30 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFarFuture(t *testing.T) {
+	now := testNow
+
+	type dated struct {
+		At time.Time `json:"at" binding:"notfarfuture"`
+	}
+
+	t.Run("should accept dates up to the limit", func(t *testing.T) {
+		assert.Nil(t, Validate(&dated{At: now.AddDate(MaxYearsAhead, 0, 0)}))
+	})
+
+	t.Run("should reject dates past the limit", func(t *testing.T) {
+		err := Validate(&dated{At: now.AddDate(MaxYearsAhead, 0, 1)})
+
+		domainErr, _ := domainerror.As(err)
+		if assert.Len(t, domainErr.Details, 1) {
+			assert.Equal(t, "too_far_in_future", domainErr.Details[0].Code)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuditChangeResponse struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type AuditEntryResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	Action     enum.AuditAction      `json:"action"`
+	ActorID    uuid.UUID             `json:"actorId"`
+	RequestID  string                `json:"requestId,omitempty"`
+	OccurredAt time.Time             `json:"occurredAt"`
+	Changes    []AuditChangeResponse `json:"changes"`
+}
+
+func FromEntity(e entity.AuditEntry) AuditEntryResponse {
+	changes := make([]AuditChangeResponse, len(e.Changes()))
+	for i, change := range e.Changes() {
+		changes[i] = AuditChangeResponse{Field: change.Field, Before: change.Before, After: change.After}
+	}
+
+	return AuditEntryResponse{
+		ID:         e.ID(),
+		Action:     e.Action(),
+		ActorID:    e.UserID(),
+		RequestID:  e.RequestID(),
+		OccurredAt: e.OccurredAt(),
+		Changes:    changes,
+	}
+}
+
+func BuildHistoryResponse(ctx *gin.Context, entries []entity.AuditEntry, page, pageSize int, statusCode int) *hateoas.Response {
+	entriesResponse := make([]AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		entriesResponse[i] = FromEntity(entry)
+	}
+
+	return hateoas.Collection("history", entriesResponse, ctx, page, pageSize, len(entries), statusCode)
+}
@@ -0,0 +1,57 @@
+package category
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategoryResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"userId"`
+	Name      string            `json:"name"`
+	Type      enum.CategoryType `json:"type"`
+	Default   bool              `json:"default"`
+	Icon      string            `json:"icon"`
+	Version   int64             `json:"version"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+func FromEntity(c entity.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:        c.ID(),
+		UserID:    c.UserID(),
+		Name:      c.Name(),
+		Type:      c.Type(),
+		Default:   c.Default(),
+		Icon:      c.Icon(),
+		Version:   c.Version(),
+		CreatedAt: c.CreatedAt(),
+		UpdatedAt: c.UpdatedAt(),
+	}
+}
+
+func FromEntities(categories []entity.Category) []CategoryResponse {
+	result := make([]CategoryResponse, len(categories))
+	for i, category := range categories {
+		result[i] = FromEntity(category)
+	}
+	return result
+}
+
+func BuildCategoryResponse(ctx *gin.Context, category entity.Category, statusCode int) *hateoas.Response {
+	categoryResponse := FromEntity(category)
+
+	return hateoas.Single("category", categoryResponse, ctx, statusCode)
+}
+
+func BuildCategoriesResponse(ctx *gin.Context, categories []entity.Category, page, pageSize int, statusCode int) *hateoas.Response {
+	categoriesResponse := FromEntities(categories)
+
+	return hateoas.Collection("category", categoriesResponse, ctx, page, pageSize, len(categories), statusCode)
+}
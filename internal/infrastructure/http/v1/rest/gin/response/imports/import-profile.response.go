@@ -0,0 +1,89 @@
+package imports
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportProfileResponse struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	Settings  ImportSettingsResponse `json:"settings"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+type ImportSettingsResponse struct {
+	Delimiter         string                `json:"delimiter"`
+	DecimalComma      bool                  `json:"decimalComma"`
+	DateFormat        string                `json:"dateFormat"`
+	HasHeader         bool                  `json:"hasHeader"`
+	NegateAmounts     bool                  `json:"negateAmounts"`
+	Columns           ImportColumnsResponse `json:"columns"`
+	ExpenseCategoryID *uuid.UUID            `json:"expenseCategoryId"`
+	IncomeCategoryID  *uuid.UUID            `json:"incomeCategoryId"`
+}
+
+type ImportColumnsResponse struct {
+	Date        string `json:"date"`
+	Amount      string `json:"amount"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+func FromEntity(p entity.ImportProfile) ImportProfileResponse {
+	settings := p.Settings()
+	return ImportProfileResponse{
+		ID:   p.ID(),
+		Name: p.Name(),
+		Settings: ImportSettingsResponse{
+			Delimiter:     settings.Delimiter,
+			DecimalComma:  settings.DecimalComma,
+			DateFormat:    settings.DateFormat,
+			HasHeader:     settings.HasHeader,
+			NegateAmounts: settings.NegateAmounts,
+			Columns: ImportColumnsResponse{
+				Date:        settings.Columns.Date,
+				Amount:      settings.Columns.Amount,
+				Description: settings.Columns.Description,
+				Category:    settings.Columns.Category,
+			},
+			ExpenseCategoryID: optionalID(settings.ExpenseCategoryID),
+			IncomeCategoryID:  optionalID(settings.IncomeCategoryID),
+		},
+		CreatedAt: p.CreatedAt(),
+		UpdatedAt: p.UpdatedAt(),
+	}
+}
+
+func FromEntities(profiles []entity.ImportProfile) []ImportProfileResponse {
+	result := make([]ImportProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		result[i] = FromEntity(profile)
+	}
+	return result
+}
+
+func BuildImportProfileResponse(ctx *gin.Context, profile entity.ImportProfile, statusCode int) *hateoas.Response {
+	profileResponse := FromEntity(profile)
+
+	return hateoas.Single("import-profile", profileResponse, ctx, statusCode)
+}
+
+func BuildImportProfilesResponse(ctx *gin.Context, profiles []entity.ImportProfile, page, pageSize int, statusCode int) *hateoas.Response {
+	profilesResponse := FromEntities(profiles)
+
+	return hateoas.Collection("import-profile", profilesResponse, ctx, page, pageSize, len(profiles), statusCode)
+}
+
+// optionalID turns an unset id into null
+func optionalID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
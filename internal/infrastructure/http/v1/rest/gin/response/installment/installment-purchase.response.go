@@ -0,0 +1,85 @@
+package installment
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InstallmentPurchaseResponse struct {
+	ID           uuid.UUID                 `json:"id"`
+	UserID       uuid.UUID                 `json:"userId"`
+	CategoryID   uuid.UUID                 `json:"categoryId"`
+	Description  string                    `json:"description"`
+	TotalAmount  float64                   `json:"totalAmount"`
+	Remainder    enum.InstallmentRemainder `json:"remainder"`
+	Installments []InstallmentResponse     `json:"installments"`
+	Version      int64                     `json:"version"`
+	CreatedAt    time.Time                 `json:"createdAt"`
+	UpdatedAt    time.Time                 `json:"updatedAt"`
+	CancelledAt  *time.Time                `json:"cancelledAt"`
+}
+
+type InstallmentResponse struct {
+	Number        int       `json:"number"`
+	TransactionID uuid.UUID `json:"transactionId"`
+	DueAt         time.Time `json:"dueAt"`
+	Amount        float64   `json:"amount"`
+	Cancelled     bool      `json:"cancelled"`
+}
+
+func FromEntity(p entity.InstallmentPurchase) InstallmentPurchaseResponse {
+	installments := p.Installments()
+	response := InstallmentPurchaseResponse{
+		ID:           p.ID(),
+		UserID:       p.UserID(),
+		CategoryID:   p.CategoryID(),
+		Description:  p.Description(),
+		TotalAmount:  p.TotalAmount(),
+		Remainder:    p.Remainder(),
+		Installments: make([]InstallmentResponse, len(installments)),
+		Version:      p.Version(),
+		CreatedAt:    p.CreatedAt(),
+		UpdatedAt:    p.UpdatedAt(),
+	}
+
+	for i, installment := range installments {
+		response.Installments[i] = InstallmentResponse{
+			Number:        installment.Number,
+			TransactionID: installment.TransactionID,
+			DueAt:         installment.DueAt,
+			Amount:        installment.Amount,
+			Cancelled:     installment.Cancelled,
+		}
+	}
+	if p.Cancelled() {
+		cancelledAt := p.CancelledAt()
+		response.CancelledAt = &cancelledAt
+	}
+
+	return response
+}
+
+func FromEntities(purchases []entity.InstallmentPurchase) []InstallmentPurchaseResponse {
+	result := make([]InstallmentPurchaseResponse, len(purchases))
+	for i, purchase := range purchases {
+		result[i] = FromEntity(purchase)
+	}
+	return result
+}
+
+func BuildInstallmentPurchaseResponse(ctx *gin.Context, purchase entity.InstallmentPurchase, statusCode int) *hateoas.Response {
+	purchaseResponse := FromEntity(purchase)
+
+	return hateoas.Single("installment-purchase", purchaseResponse, ctx, statusCode)
+}
+
+func BuildInstallmentPurchasesResponse(ctx *gin.Context, purchases []entity.InstallmentPurchase, page, pageSize int, statusCode int) *hateoas.Response {
+	purchasesResponse := FromEntities(purchases)
+
+	return hateoas.Collection("installment-purchase", purchasesResponse, ctx, page, pageSize, len(purchases), statusCode)
+}
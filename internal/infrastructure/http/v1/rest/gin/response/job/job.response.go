@@ -0,0 +1,82 @@
+package job
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+type JobResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	Kind        enum.JobKind       `json:"kind"`
+	Status      enum.JobStatus     `json:"status"`
+	Progress    int                `json:"progress"`
+	Attempts    int                `json:"attempts"`
+	MaxAttempts int                `json:"maxAttempts"`
+	RunAt       time.Time          `json:"runAt"`
+	Result      *JobResultResponse `json:"result"`
+	Error       *JobErrorResponse  `json:"error"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	StartedAt   *time.Time         `json:"startedAt"`
+	FinishedAt  *time.Time         `json:"finishedAt"`
+}
+
+// JobResultResponse describes the file a succeeded job produced, downloaded from
+// GET /v1/jobs/{id}/result
+type JobResultResponse struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+}
+
+// JobErrorResponse is why the last attempt of the job failed
+type JobErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FromEntity describes job, translating its failure to lang
+func FromEntity(job entity.Job, lang language.Tag) JobResponse {
+	response := JobResponse{
+		ID:          job.ID(),
+		Kind:        job.Kind(),
+		Status:      job.Status(),
+		Progress:    job.Progress(),
+		Attempts:    job.Attempts(),
+		MaxAttempts: job.MaxAttempts(),
+		RunAt:       job.RunAt(),
+		CreatedAt:   job.CreatedAt(),
+		StartedAt:   optionalTime(job.StartedAt()),
+		FinishedAt:  optionalTime(job.FinishedAt()),
+	}
+
+	if result := job.Result(); result.Name != "" && job.Status() == enum.JobStatusSucceeded {
+		response.Result = &JobResultResponse{Name: result.Name, ContentType: result.ContentType}
+	}
+	if failure := job.Failure(); failure.Code != "" {
+		response.Error = &JobErrorResponse{
+			Code:    failure.Code,
+			Message: i18n.Translate(lang, failure.Code, failure.Params, failure.Message),
+		}
+	}
+
+	return response
+}
+
+func BuildJobResponse(ctx *gin.Context, job entity.Job, statusCode int) *hateoas.Response {
+	jobResponse := FromEntity(job, i18n.LanguageFromContext(ctx.Request.Context()))
+
+	return hateoas.Single("job", jobResponse, ctx, statusCode)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,118 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	UserID      uuid.UUID        `json:"userId"`
+	CategoryID  uuid.UUID        `json:"categoryId"`
+	Amount      float64          `json:"amount"`
+	Description string           `json:"description"`
+	StartAt     time.Time        `json:"startAt"`
+	Schedule    ScheduleResponse `json:"schedule"`
+	NextAt      *time.Time       `json:"nextAt"`
+	PausedAt    *time.Time       `json:"pausedAt"`
+	PauseReason *string          `json:"pauseReason"`
+	Version     int64            `json:"version"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+// ScheduleResponse mirrors the schedule a series was created with. Count is null for series
+// without a limit, and so is until.
+type ScheduleResponse struct {
+	Frequency       enum.RecurrenceFrequency `json:"frequency"`
+	Interval        int                      `json:"interval"`
+	DayOfMonth      int                      `json:"dayOfMonth"`
+	LastBusinessDay bool                     `json:"lastBusinessDay"`
+	Until           *string                  `json:"until"`
+	Count           *int                     `json:"count"`
+}
+
+// OccurrenceResponse is an occurrence not recorded yet, and whether it was skipped
+type OccurrenceResponse struct {
+	Date    string    `json:"date"`
+	At      time.Time `json:"at"`
+	Skipped bool      `json:"skipped"`
+}
+
+func FromEntity(r entity.RecurringTransaction) RecurringTransactionResponse {
+	rule := r.Rule()
+	response := RecurringTransactionResponse{
+		ID:          r.ID(),
+		UserID:      r.UserID(),
+		CategoryID:  r.CategoryID(),
+		Amount:      r.Amount(),
+		Description: r.Description(),
+		StartAt:     r.StartAt(),
+		Schedule: ScheduleResponse{
+			Frequency:       rule.Frequency,
+			Interval:        rule.Interval,
+			DayOfMonth:      rule.DayOfMonth,
+			LastBusinessDay: rule.LastBusinessDay,
+		},
+		Version:   r.Version(),
+		CreatedAt: r.CreatedAt(),
+		UpdatedAt: r.UpdatedAt(),
+	}
+
+	if !rule.Until.IsZero() {
+		until := rule.Until.Format(time.DateOnly)
+		response.Schedule.Until = &until
+	}
+	if rule.Count > 0 {
+		response.Schedule.Count = &rule.Count
+	}
+	if !r.Ended() {
+		nextAt := r.NextAt()
+		response.NextAt = &nextAt
+	}
+	if r.Paused() {
+		pausedAt, reason := r.PausedAt(), r.PauseReason()
+		response.PausedAt = &pausedAt
+		response.PauseReason = &reason
+	}
+
+	return response
+}
+
+func FromEntities(recurrings []entity.RecurringTransaction) []RecurringTransactionResponse {
+	result := make([]RecurringTransactionResponse, len(recurrings))
+	for i, recurring := range recurrings {
+		result[i] = FromEntity(recurring)
+	}
+	return result
+}
+
+func BuildRecurringTransactionResponse(ctx *gin.Context, recurring entity.RecurringTransaction, statusCode int) *hateoas.Response {
+	recurringResponse := FromEntity(recurring)
+
+	return hateoas.Single("recurring-transaction", recurringResponse, ctx, statusCode)
+}
+
+func BuildRecurringTransactionsResponse(ctx *gin.Context, recurrings []entity.RecurringTransaction, page, pageSize int, statusCode int) *hateoas.Response {
+	recurringsResponse := FromEntities(recurrings)
+
+	return hateoas.Collection("recurring-transaction", recurringsResponse, ctx, page, pageSize, len(recurrings), statusCode)
+}
+
+func BuildOccurrencesResponse(occurrences []entity.RecurringOccurrence, statusCode int) *hateoas.Response {
+	occurrencesResponse := make([]OccurrenceResponse, len(occurrences))
+	for i, occurrence := range occurrences {
+		occurrencesResponse[i] = OccurrenceResponse{
+			Date:    occurrence.At.Format(time.DateOnly),
+			At:      occurrence.At,
+			Skipped: occurrence.Skipped,
+		}
+	}
+
+	return hateoas.NewResponse(occurrencesResponse, statusCode)
+}
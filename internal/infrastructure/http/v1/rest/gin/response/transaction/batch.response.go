@@ -0,0 +1,64 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+)
+
+// BatchResultResponse is the outcome of one operation: the status it would have had as a request
+// of its own, with the transaction it created or updated, or the problem that made it fail
+type BatchResultResponse struct {
+	Index  int                  `json:"index"`
+	Status int                  `json:"status"`
+	Data   *TransactionResponse `json:"data,omitempty"`
+	Error  *problem.Problem     `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BatchResultResponse `json:"results"`
+}
+
+// BuildBatchResponse reports every result of a batch, using describe to turn errors into
+// problems. The response is 207 Multi-Status when some operations failed, 200 otherwise.
+func BuildBatchResponse(operations []dto.BatchOperationDTO, results []dto.BatchResultDTO, describe func(error) *problem.Problem) *hateoas.Response {
+	batchResponse := BatchResponse{Results: make([]BatchResultResponse, len(results))}
+
+	for i, result := range results {
+		resultResponse := BatchResultResponse{Index: i}
+
+		switch {
+		case result.Err != nil:
+			resultResponse.Error = describe(result.Err)
+			resultResponse.Status = resultResponse.Error.Status
+			batchResponse.Failed++
+		case operations[i].Create != nil:
+			resultResponse.Status = http.StatusCreated
+		case operations[i].Update != nil:
+			resultResponse.Status = http.StatusOK
+		default:
+			resultResponse.Status = http.StatusNoContent
+		}
+
+		if result.Transaction != nil {
+			transactionResponse := FromEntity(*result.Transaction)
+			resultResponse.Data = &transactionResponse
+		}
+		if result.Err == nil {
+			batchResponse.Succeeded++
+		}
+
+		batchResponse.Results[i] = resultResponse
+	}
+
+	statusCode := http.StatusOK
+	if batchResponse.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	return hateoas.NewResponse(batchResponse, statusCode)
+}
@@ -16,6 +16,8 @@ type TransactionResponse struct {
 	Amount      float64   `json:"amount"`
 	Datetime    time.Time `json:"datetime"`
 	Description string    `json:"description"`
+	ExternalID  string    `json:"externalId,omitempty"`
+	Version     int64     `json:"version"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -28,6 +30,8 @@ func FromEntity(t entity.Transaction) TransactionResponse {
 		Amount:      t.Amount(),
 		Datetime:    t.Datetime(),
 		Description: t.Description(),
+		ExternalID:  t.ExternalID(),
+		Version:     t.Version(),
 		CreatedAt:   t.CreatedAt(),
 		UpdatedAt:   t.UpdatedAt(),
 	}
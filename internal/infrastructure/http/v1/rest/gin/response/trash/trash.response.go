@@ -0,0 +1,39 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashItemResponse struct {
+	Kind      enum.TrashItemKind `json:"kind"`
+	ID        uuid.UUID          `json:"id"`
+	Name      string             `json:"name"`
+	DeletedAt time.Time          `json:"deletedAt"`
+	PurgeAt   time.Time          `json:"purgeAt"`
+}
+
+func FromEntity(item entity.TrashItem, purgeAt time.Time) TrashItemResponse {
+	return TrashItemResponse{
+		Kind:      item.Kind(),
+		ID:        item.ID(),
+		Name:      item.Name(),
+		DeletedAt: item.DeletedAt(),
+		PurgeAt:   purgeAt,
+	}
+}
+
+// BuildTrashResponse lists items, each with the time purgeAt says it will be permanently removed
+func BuildTrashResponse(ctx *gin.Context, items []entity.TrashItem, purgeAt func(*entity.TrashItem) time.Time, page, pageSize int, statusCode int) *hateoas.Response {
+	itemsResponse := make([]TrashItemResponse, len(items))
+	for i := range items {
+		itemsResponse[i] = FromEntity(items[i], purgeAt(&items[i]))
+	}
+
+	return hateoas.Collection("trash", itemsResponse, ctx, page, pageSize, len(items), statusCode)
+}
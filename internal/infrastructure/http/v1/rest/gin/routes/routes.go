@@ -1,14 +1,144 @@
 package routes
 
 import (
+	"log/slog"
+	"strings"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/controller"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/middleware"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/request"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/ratelimit"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, transactionController *controller.TransactionController) {
-	v1 := router.Group("/v1")
+// Dependencies holds everything SetupRoutes wires into the router
+type Dependencies struct {
+	Config                *config.Config
+	Logger                *slog.Logger
+	Clock                 clock.Clock
+	RateLimiter           ratelimit.Store // nil disables rate limiting
+	IdempotencyRepository repository.IdempotencyRepositoryInterface
+
+	HealthController               *controller.HealthController
+	OpenAPIController              *controller.OpenAPIController
+	TransactionController          *controller.TransactionController
+	CategoryController             *controller.CategoryController
+	TrashController                *controller.TrashController
+	ImportController               *controller.ImportController
+	JobController                  *controller.JobController
+	RecurringTransactionController *controller.RecurringTransactionController
+	InstallmentPurchaseController  *controller.InstallmentPurchaseController
+}
+
+// multipartOverhead leaves room for the form fields sent along with an uploaded file
+const multipartOverhead = 64 << 10
+
+func SetupRoutes(router *gin.Engine, deps Dependencies) {
+	cfg := deps.Config
+	request.ConfigureBinding(deps.Clock)
+
+	router.Use(
+		middleware.RequestID(),
+		middleware.Tracing(),
+		middleware.Logger(deps.Logger),
+		middleware.Language(),
+		middleware.ReadAfterWrite(),
+		middleware.ErrorHandler(),
+		middleware.SecurityHeaders(),
+		middleware.CORS(cfg.CORS),
+		middleware.BodyLimit(cfg.Server.MaxBodyBytes, map[string]int64{
+			"/v1/imports/csv": cfg.Import.MaxFileBytes + multipartOverhead,
+			"/v1/imports/ofx": cfg.Import.MaxFileBytes + multipartOverhead,
+		}),
+	)
+
+	router.NoRoute(func(ctx *gin.Context) {
+		ctx.Error(domainerror.NewNotFound("route", ctx.Request.URL.Path))
+	})
+
+	router.GET("/healthz", deps.HealthController.Liveness)
+	router.GET("/readyz", deps.HealthController.Readiness)
+	router.GET("/version", deps.HealthController.Version)
+	router.GET("/v1/openapi.json", deps.OpenAPIController.Spec)
+	router.GET("/v1/docs", deps.OpenAPIController.Docs)
+	router.GET("/v1/docs/redoc.standalone.js", deps.OpenAPIController.Viewer)
+
+	// the limiter runs before authentication, so requests without valid credentials are still
+	// limited by client IP before they are rejected
+	v1 := router.Group("/v1", middleware.Identify(cfg.Auth))
+	if deps.RateLimiter != nil {
+		v1.Use(middleware.RateLimit(deps.RateLimiter, ratelimit.Limit{Rate: cfg.RateLimit.RequestsPerSecond, Burst: cfg.RateLimit.Burst}))
+	}
+	v1.Use(middleware.Authentication(cfg.Auth))
+	idempotent := middleware.Idempotency(deps.IdempotencyRepository, deps.Clock, cfg.Idempotency.TTL)
 	{
-		v1.GET("/transactions", transactionController.GetTransactions)
-		v1.POST("/transactions", transactionController.CreateTransaction)
+		v1.GET("/transactions", deps.TransactionController.GetTransactions)
+		v1.POST("/transactions", idempotent, deps.TransactionController.CreateTransaction)
+		v1.POST("/transactions:method", idempotent, customMethods(map[string]gin.HandlerFunc{
+			"batch": deps.TransactionController.BatchTransactions,
+		}))
+		v1.GET("/transactions/export", deps.TransactionController.ExportTransactions)
+		v1.GET("/transactions/:id", deps.TransactionController.GetTransaction)
+		v1.PUT("/transactions/:id", deps.TransactionController.UpdateTransaction)
+		v1.PATCH("/transactions/:id", deps.TransactionController.PatchTransaction)
+		v1.DELETE("/transactions/:id", deps.TransactionController.DeleteTransaction)
+		v1.POST("/transactions/:id/restore", deps.TransactionController.RestoreTransaction)
+		v1.GET("/transactions/:id/history", deps.TransactionController.GetTransactionHistory)
+
+		v1.GET("/categories", deps.CategoryController.GetCategories)
+		v1.POST("/categories", idempotent, deps.CategoryController.CreateCategory)
+		v1.GET("/categories/:id", deps.CategoryController.GetCategory)
+		v1.PUT("/categories/:id", deps.CategoryController.UpdateCategory)
+		v1.PATCH("/categories/:id", deps.CategoryController.PatchCategory)
+		v1.DELETE("/categories/:id", deps.CategoryController.DeleteCategory)
+		v1.POST("/categories/:id/restore", deps.CategoryController.RestoreCategory)
+
+		v1.GET("/trash", deps.TrashController.GetTrash)
+
+		v1.GET("/import-profiles", deps.ImportController.GetImportProfiles)
+		v1.POST("/import-profiles", idempotent, deps.ImportController.CreateImportProfile)
+		v1.GET("/import-profiles/:id", deps.ImportController.GetImportProfile)
+		v1.PUT("/import-profiles/:id", deps.ImportController.UpdateImportProfile)
+		v1.DELETE("/import-profiles/:id", deps.ImportController.DeleteImportProfile)
+		v1.POST("/imports/csv", idempotent, deps.ImportController.ImportCSV)
+		v1.POST("/imports/ofx", idempotent, deps.ImportController.ImportOFX)
+
+		v1.GET("/recurring-transactions", deps.RecurringTransactionController.GetRecurringTransactions)
+		v1.POST("/recurring-transactions", idempotent, deps.RecurringTransactionController.CreateRecurringTransaction)
+		v1.GET("/recurring-transactions/:id", deps.RecurringTransactionController.GetRecurringTransaction)
+		v1.DELETE("/recurring-transactions/:id", deps.RecurringTransactionController.DeleteRecurringTransaction)
+		v1.POST("/recurring-transactions/:id/resume", deps.RecurringTransactionController.ResumeRecurringTransaction)
+		v1.GET("/recurring-transactions/:id/occurrences", deps.RecurringTransactionController.GetOccurrences)
+		v1.PUT("/recurring-transactions/:id/occurrences/:date", deps.RecurringTransactionController.EditOccurrences)
+		v1.POST("/recurring-transactions/:id/occurrences/:date/skip", deps.RecurringTransactionController.SkipOccurrence)
+
+		v1.GET("/installment-purchases", deps.InstallmentPurchaseController.GetInstallmentPurchases)
+		v1.POST("/installment-purchases", idempotent, deps.InstallmentPurchaseController.CreateInstallmentPurchase)
+		v1.GET("/installment-purchases/:id", deps.InstallmentPurchaseController.GetInstallmentPurchase)
+		v1.PUT("/installment-purchases/:id/remaining", deps.InstallmentPurchaseController.EditRemainingInstallments)
+		v1.POST("/installment-purchases/:id/cancel", deps.InstallmentPurchaseController.CancelInstallmentPurchase)
+
+		v1.GET("/jobs/:id", deps.JobController.GetJob)
+		v1.GET("/jobs/:id/result", deps.JobController.GetJobResult)
+		v1.POST("/jobs/:id/cancel", deps.JobController.CancelJob)
+	}
+}
+
+// customMethods serves POST /collection:method routes such as /transactions:batch. Gin reads
+// the colon as the start of a parameter, so they share one route that dispatches on the name
+// after the colon.
+func customMethods(handlers map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name, ok := strings.CutPrefix(ctx.Param("method"), ":")
+		handler, known := handlers[name]
+		if !ok || !known {
+			ctx.Error(domainerror.NewNotFound("route", ctx.Request.URL.Path))
+			return
+		}
+		handler(ctx)
 	}
 }
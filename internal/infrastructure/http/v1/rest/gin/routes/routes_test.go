@@ -0,0 +1,646 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/service"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/health"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/gin/controller"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/http/v1/rest/openapi"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRouter registers every route, backed by an in-memory store
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	cfg := &config.Config{
+		Server:      config.ServerConfig{MaxBodyBytes: 1 << 20},
+		Auth:        config.AuthConfig{Mode: config.AuthModeHeader, UserHeader: "X-User-Id", SecretHeader: "X-Gateway-Secret", GatewaySecret: "gateway-secret"},
+		Concurrency: config.ConcurrencyConfig{RequireIfMatch: true},
+		Import:      config.ImportConfig{MaxFileBytes: 1 << 20, MaxRows: 100},
+	}
+
+	store := memory.NewStore()
+	unitOfWork := memory.NewUnitOfWork(store)
+	transactions := memory.NewTransactionRepository(store)
+	categories := memory.NewCategoryRepository(store)
+	auditTrail := service.NewAuditTrailService(memory.NewAuditRepository(store), clock.System(), identifier.NewV7())
+	transactionService := service.NewTransactionService(unitOfWork, transactions, categories, auditTrail, noMetrics{}, clock.System(), identifier.NewV7())
+	categoryService := service.NewCategoryService(unitOfWork, categories, transactions, auditTrail, clock.System(), identifier.NewV7())
+	trashService := service.NewTrashService(unitOfWork, memory.NewTrashRepository(store), transactions, categories, clock.System(), 30*24*time.Hour)
+	importProfiles := memory.NewImportProfileRepository(store)
+	importProfileService := service.NewImportProfileService(importProfiles, categories, clock.System(), identifier.NewV7())
+	importService := service.NewImportService(importProfiles, categories, transactions, transactionService, cfg.Import.MaxRows)
+	jobService := service.NewJobService(memory.NewJobRepository(store), memory.NewFileStorage(), clock.System(), identifier.NewV7(), 3, time.Hour)
+	recurringTransactionService := service.NewRecurringTransactionService(unitOfWork, memory.NewRecurringTransactionRepository(store), categories, transactionService, clock.System(), identifier.NewV7())
+	installmentPurchaseService := service.NewInstallmentPurchaseService(unitOfWork, memory.NewInstallmentPurchaseRepository(store), categories, transactionService, clock.System(), identifier.NewV7())
+
+	SetupRoutes(router, Dependencies{
+		Config:                         cfg,
+		Logger:                         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Clock:                          clock.System(),
+		IdempotencyRepository:          memory.NewIdempotencyRepository(store),
+		HealthController:               controller.NewHealthController(health.NewChecker(0)),
+		OpenAPIController:              controller.NewOpenAPIController(),
+		TransactionController:          controller.NewTransactionController(transactionService, jobService, cfg.Concurrency.RequireIfMatch, 10, time.Minute, time.UTC, clock.System()),
+		CategoryController:             controller.NewCategoryController(categoryService, cfg.Concurrency.RequireIfMatch),
+		TrashController:                controller.NewTrashController(trashService),
+		ImportController:               controller.NewImportController(importService, importProfileService, jobService, cfg.Import.MaxFileBytes),
+		JobController:                  controller.NewJobController(jobService, time.Minute),
+		RecurringTransactionController: controller.NewRecurringTransactionController(recurringTransactionService, cfg.Concurrency.RequireIfMatch),
+		InstallmentPurchaseController:  controller.NewInstallmentPurchaseController(installmentPurchaseService, cfg.Concurrency.RequireIfMatch),
+	})
+	return router
+}
+
+type noMetrics struct{}
+
+func (noMetrics) TransactionCreated(enum.CategoryType) {}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	var document struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}
+	assert.Nil(t, json.Unmarshal(openapi.JSON(), &document))
+
+	for _, route := range newRouter().Routes() {
+		if strings.HasSuffix(route.Path, ":method") {
+			// Custom methods are checked by TestCustomMethods
+			continue
+		}
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		method := strings.ToLower(route.Method)
+
+		operations, ok := document.Paths[path]
+		if !assert.True(t, ok, "%s %s is not documented in openapi.yaml", route.Method, path) {
+			continue
+		}
+		assert.Contains(t, operations, method, "%s %s is not documented in openapi.yaml", route.Method, path)
+	}
+}
+
+func TestCustomMethods(t *testing.T) {
+	router := newRouter()
+	userID := uuid.New().String()
+
+	t.Run("should serve every documented custom method", func(t *testing.T) {
+		var document struct {
+			Paths map[string]map[string]any `json:"paths"`
+		}
+		assert.Nil(t, json.Unmarshal(openapi.JSON(), &document))
+
+		served := 0
+		for path := range document.Paths {
+			if !strings.Contains(path[strings.LastIndex(path, "/"):], ":") {
+				continue
+			}
+			served++
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+			request.Header.Set("X-User-Id", userID)
+			request.Header.Set("X-Gateway-Secret", "gateway-secret")
+			router.ServeHTTP(recorder, request)
+
+			assert.NotEqual(t, http.StatusNotFound, recorder.Code, "POST %s is not served", path)
+		}
+		assert.NotZero(t, served)
+	})
+
+	t.Run("should not serve an unknown custom method", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/v1/transactions:purge", strings.NewReader("{}"))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should answer 207 when a best-effort batch partly fails", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/v1/transactions:batch", strings.NewReader(`{
+			"mode": "best_effort",
+			"operations": [
+				{"op": "delete", "id": "`+uuid.NewString()+`", "version": 1},
+				{"op": "rename"}
+			]
+		}`))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(recorder, request)
+
+		var body struct {
+			Data struct {
+				Succeeded int `json:"succeeded"`
+				Failed    int `json:"failed"`
+				Results   []struct {
+					Status int `json:"status"`
+				} `json:"results"`
+			} `json:"data"`
+		}
+		assert.Equal(t, http.StatusMultiStatus, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, 2, body.Data.Failed)
+		assert.Equal(t, http.StatusNotFound, body.Data.Results[0].Status)
+		assert.Equal(t, http.StatusBadRequest, body.Data.Results[1].Status)
+	})
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	router := newRouter()
+
+	t.Run("should serve the document without authentication", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+
+		var document map[string]any
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+		assert.Equal(t, "3.1.0", document["openapi"])
+	})
+
+	t.Run("should serve the docs page", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/docs", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `spec-url="/v1/openapi.json"`)
+		assert.Equal(t, openapi.DocsContentSecurityPolicy, recorder.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("should serve the docs viewer from the binary", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/docs/redoc.standalone.js", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, openapi.ViewerJS(), recorder.Body.Bytes())
+		assert.NotContains(t, openapi.DocsContentSecurityPolicy, "https:")
+	})
+
+	t.Run("should require authentication on resource routes", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/transactions", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestConditionalRequests(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	send := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	created := send(http.MethodPost, "/v1/categories", `{"name":"Food","type":"expense"}`, nil)
+	assert.Equal(t, http.StatusCreated, created.Code)
+	assert.Equal(t, `"1"`, created.Header().Get("ETag"))
+	location := created.Header().Get("Location")
+
+	t.Run("should answer 304 when the client already has the current version", func(t *testing.T) {
+		recorder := send(http.MethodGet, location, "", map[string]string{"If-None-Match": `"1"`})
+
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("should require If-Match on writes", func(t *testing.T) {
+		recorder := send(http.MethodPatch, location, `{"icon":"cart"}`, nil)
+
+		assert.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+	})
+
+	t.Run("should apply a write based on the current version and return the new ETag", func(t *testing.T) {
+		recorder := send(http.MethodPatch, location, `{"icon":"cart"}`, map[string]string{"If-Match": `"1"`})
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `"2"`, recorder.Header().Get("ETag"))
+	})
+
+	t.Run("should refuse a write based on an outdated version", func(t *testing.T) {
+		recorder := send(http.MethodPut, location, `{"name":"Groceries","type":"expense"}`, map[string]string{"If-Match": `"1"`})
+
+		assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "version_mismatch")
+	})
+
+	t.Run("should delete with a wildcard If-Match", func(t *testing.T) {
+		recorder := send(http.MethodDelete, location, "", map[string]string{"If-Match": "*"})
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+}
+
+func TestImportCSV(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	upload := func(fields map[string]string, file []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for name, value := range fields {
+			assert.Nil(t, writer.WriteField(name, value))
+		}
+		part, err := writer.CreateFormFile("file", "statement.csv")
+		assert.Nil(t, err)
+		_, err = part.Write(file)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+
+		request := httptest.NewRequest(http.MethodPost, "/v1/imports/csv", &body)
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	settings := `{"delimiter":";","decimalComma":true,"dateFormat":"DD/MM/YYYY","columns":{"date":"Data","amount":"Valor"}}`
+
+	t.Run("should preview the rows of the statement", func(t *testing.T) {
+		recorder := upload(map[string]string{"settings": settings}, []byte("Data;Valor\n05/03/2025;-10,00\n"))
+
+		var body struct {
+			Data struct {
+				Committed bool `json:"committed"`
+				Summary   struct {
+					Rows    int `json:"rows"`
+					Invalid int `json:"invalid"`
+				} `json:"summary"`
+			} `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.False(t, body.Data.Committed)
+		assert.Equal(t, 1, body.Data.Summary.Rows)
+		assert.Equal(t, 1, body.Data.Summary.Invalid)
+		assert.Contains(t, recorder.Body.String(), "import_category_required")
+	})
+
+	t.Run("should require a profile or settings", func(t *testing.T) {
+		recorder := upload(nil, []byte("Data;Valor\n05/03/2025;-10,00\n"))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "import_settings_required")
+	})
+
+	t.Run("should refuse a file larger than the limit", func(t *testing.T) {
+		recorder := upload(map[string]string{"settings": settings}, bytes.Repeat([]byte("x"), 1<<20+1))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+}
+
+func TestExportTransactions(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept-Language", "en")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	created := send(http.MethodPost, "/v1/categories", `{"name":"Food","type":"expense"}`)
+	assert.Equal(t, http.StatusCreated, created.Code)
+	var category struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal(created.Body.Bytes(), &category))
+	transaction := send(http.MethodPost, "/v1/transactions",
+		`{"categoryId":"`+category.Data.ID+`","amount":12.5,"datetime":"2025-03-05T09:15:00Z","description":"Lunch"}`)
+	assert.Equal(t, http.StatusCreated, transaction.Code)
+
+	t.Run("should download the transactions as CSV by default", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/export", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), ".csv")
+		assert.Contains(t, recorder.Body.String(), "2025-03-05 09:15,Lunch,Food,Expense,-12.50")
+	})
+
+	t.Run("should download the transactions as OFX", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/export?format=ofx", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ofx", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "<TRNAMT>-12.50</TRNAMT>")
+	})
+
+	t.Run("should write dates in the requested time zone", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/export?timeZone=America/Sao_Paulo", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "2025-03-05 06:15,Lunch")
+	})
+
+	t.Run("should refuse an unknown time zone", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/export?timeZone=Mars/Olympus", "")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should refuse an unknown format", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/export?format=pdf", "")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "invalid_query")
+	})
+}
+
+func TestJobs(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	send := func(method, path, user string, headers ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		request.Header.Set("X-User-Id", user)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Accept-Language", "en")
+		for i := 0; i < len(headers); i += 2 {
+			request.Header.Set(headers[i], headers[i+1])
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	accepted := send(http.MethodGet, "/v1/transactions/export?format=xlsx", userID, "Prefer", "respond-async")
+	assert.Equal(t, http.StatusAccepted, accepted.Code)
+	assert.Equal(t, "respond-async", accepted.Header().Get("Preference-Applied"))
+	location := accepted.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, "/v1/jobs/"), location)
+
+	var job struct {
+		Data struct {
+			ID     string `json:"id"`
+			Kind   string `json:"kind"`
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal(accepted.Body.Bytes(), &job))
+	assert.Equal(t, "export_transactions", job.Data.Kind)
+	assert.Equal(t, "queued", job.Data.Status)
+
+	t.Run("should report the job to its user only", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, location, userID).Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, location, uuid.NewString()).Code)
+	})
+
+	t.Run("should have no result before the job succeeds", func(t *testing.T) {
+		recorder := send(http.MethodGet, location+"/result", userID)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "job_result_unavailable")
+	})
+
+	t.Run("should cancel the job once", func(t *testing.T) {
+		recorder := send(http.MethodPost, location+"/cancel", userID)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"cancelled"`)
+
+		recorder = send(http.MethodPost, location+"/cancel", userID)
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "job_finished")
+	})
+}
+
+func TestRecurringTransactions(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	send := func(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", "application/json")
+		for i := 0; i < len(headers); i += 2 {
+			request.Header.Set(headers[i], headers[i+1])
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	var category struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	recorder := send(http.MethodPost, "/v1/categories", `{"name":"Housing","type":"expense"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &category))
+
+	start := time.Now().UTC().AddDate(0, 0, 1).Truncate(time.Second)
+	created := send(http.MethodPost, "/v1/recurring-transactions", `{
+		"categoryId": "`+category.Data.ID+`",
+		"amount": 1200,
+		"description": "Rent",
+		"startAt": "`+start.Format(time.RFC3339)+`",
+		"schedule": {"frequency": "weekly", "count": 3}
+	}`)
+	assert.Equal(t, http.StatusCreated, created.Code)
+	location := created.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, "/v1/recurring-transactions/"), location)
+
+	second := start.AddDate(0, 0, 7).Format(time.DateOnly)
+
+	t.Run("should list the upcoming occurrences until the series ends", func(t *testing.T) {
+		recorder := send(http.MethodGet, location+"/occurrences?limit=10", "")
+
+		var occurrences struct {
+			Data []struct {
+				Date    string `json:"date"`
+				Skipped bool   `json:"skipped"`
+			} `json:"data"`
+		}
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &occurrences))
+		assert.Len(t, occurrences.Data, 3)
+		assert.Equal(t, second, occurrences.Data[1].Date)
+	})
+
+	t.Run("should skip an occurrence based on the current version", func(t *testing.T) {
+		recorder := send(http.MethodPost, location+"/occurrences/"+second+"/skip", "")
+		assert.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+
+		recorder = send(http.MethodPost, location+"/occurrences/"+second+"/skip", "", "If-Match", `"1"`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `"2"`, recorder.Header().Get("ETag"))
+
+		recorder = send(http.MethodGet, location+"/occurrences", "")
+		assert.Contains(t, recorder.Body.String(), `"date":"`+second+`","at":"`)
+		assert.Contains(t, recorder.Body.String(), `"skipped":true`)
+	})
+
+	t.Run("should answer 404 for a day without an occurrence", func(t *testing.T) {
+		recorder := send(http.MethodPost, location+"/occurrences/not-a-date/skip", "", "If-Match", `"2"`)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+		recorder = send(http.MethodPost, location+"/occurrences/"+start.AddDate(0, 0, 1).Format(time.DateOnly)+"/skip", "", "If-Match", `"2"`)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should start a new series when editing a later occurrence", func(t *testing.T) {
+		recorder := send(http.MethodPut, location+"/occurrences/"+second, `{
+			"categoryId": "`+category.Data.ID+`",
+			"amount": 1300,
+			"schedule": {"frequency": "weekly"}
+		}`, "If-Match", `"2"`)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.NotEqual(t, location, recorder.Header().Get("Location"))
+		assert.Contains(t, recorder.Body.String(), `"count":null`)
+	})
+
+	t.Run("should delete the series", func(t *testing.T) {
+		etag := send(http.MethodGet, location, "").Header().Get("ETag")
+
+		assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, location, "", "If-Match", etag).Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, location, "").Code)
+	})
+}
+
+func TestInstallmentPurchases(t *testing.T) {
+	router := newRouter()
+	userID := uuid.NewString()
+
+	send := func(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-User-Id", userID)
+		request.Header.Set("X-Gateway-Secret", "gateway-secret")
+		request.Header.Set("Content-Type", "application/json")
+		for i := 0; i < len(headers); i += 2 {
+			request.Header.Set(headers[i], headers[i+1])
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	var category struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	recorder := send(http.MethodPost, "/v1/categories", `{"name":"Electronics","type":"expense"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &category))
+
+	type purchase struct {
+		Data struct {
+			TotalAmount  float64 `json:"totalAmount"`
+			Installments []struct {
+				TransactionID string  `json:"transactionId"`
+				Amount        float64 `json:"amount"`
+				Cancelled     bool    `json:"cancelled"`
+			} `json:"installments"`
+		} `json:"data"`
+	}
+
+	firstDueAt := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Second)
+	created := send(http.MethodPost, "/v1/installment-purchases", `{
+		"categoryId": "`+category.Data.ID+`",
+		"description": "Notebook",
+		"totalAmount": 100,
+		"installments": 3,
+		"firstDueAt": "`+firstDueAt.Format(time.RFC3339)+`",
+		"remainder": "last"
+	}`)
+	assert.Equal(t, http.StatusCreated, created.Code)
+	location := created.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, "/v1/installment-purchases/"), location)
+
+	var body purchase
+	assert.Nil(t, json.Unmarshal(created.Body.Bytes(), &body))
+	assert.Len(t, body.Data.Installments, 3)
+	assert.Equal(t, 33.34, body.Data.Installments[2].Amount)
+
+	t.Run("should describe the transaction of each installment", func(t *testing.T) {
+		recorder := send(http.MethodGet, "/v1/transactions/"+body.Data.Installments[1].TransactionID, "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"description":"Notebook (2/3)"`)
+	})
+
+	t.Run("should reject a purchase in a single installment", func(t *testing.T) {
+		recorder := send(http.MethodPost, "/v1/installment-purchases", `{
+			"categoryId": "`+category.Data.ID+`",
+			"totalAmount": 100,
+			"installments": 1,
+			"firstDueAt": "`+firstDueAt.Format(time.RFC3339)+`"
+		}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "installments_invalid")
+	})
+
+	t.Run("should edit the remaining installments based on the current version", func(t *testing.T) {
+		edit := `{"categoryId": "` + category.Data.ID + `", "description": "Laptop", "totalAmount": 120}`
+		assert.Equal(t, http.StatusPreconditionRequired, send(http.MethodPut, location+"/remaining", edit).Code)
+
+		recorder := send(http.MethodPut, location+"/remaining", edit, "If-Match", `"1"`)
+
+		var edited purchase
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `"2"`, recorder.Header().Get("ETag"))
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &edited))
+		assert.Equal(t, 33.33, edited.Data.Installments[0].Amount)
+		assert.Equal(t, 43.34, edited.Data.Installments[2].Amount)
+	})
+
+	t.Run("should cancel the remaining installments once", func(t *testing.T) {
+		recorder := send(http.MethodPost, location+"/cancel", "", "If-Match", `"2"`)
+
+		var cancelled purchase
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &cancelled))
+		assert.Equal(t, 33.33, cancelled.Data.TotalAmount)
+		assert.True(t, cancelled.Data.Installments[1].Cancelled)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, "/v1/transactions/"+body.Data.Installments[1].TransactionID, "").Code)
+
+		recorder = send(http.MethodPost, location+"/cancel", "", "If-Match", `"3"`)
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "installment_purchase_settled")
+	})
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The document is maintained in YAML and served as JSON
+//
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+// The viewer is the pinned Redoc bundle, fetched with make redoc and served from the binary so
+// the docs page loads nothing from third parties
+//
+//go:embed redoc.standalone.js
+var viewerJS []byte
+
+// DocsContentSecurityPolicy lets the docs page run the viewer served next to it, which the API's
+// default policy forbids. The viewer injects its styles inline and runs its search in a blob worker.
+const DocsContentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data:; connect-src 'self'; worker-src 'self' blob:; frame-ancestors 'none'"
+
+var specJSON = mustConvert(specYAML)
+
+// JSON returns the OpenAPI document
+func JSON() []byte {
+	return specJSON
+}
+
+// DocsHTML returns the page rendering the document
+func DocsHTML() []byte {
+	return docsHTML
+}
+
+// ViewerJS returns the script the docs page renders the document with
+func ViewerJS() []byte {
+	return viewerJS
+}
+
+func mustConvert(source []byte) []byte {
+	var document map[string]any
+	if err := yaml.Unmarshal(source, &document); err != nil {
+		panic("openapi: invalid openapi.yaml: " + err.Error())
+	}
+	converted, err := json.Marshal(document)
+	if err != nil {
+		panic("openapi: cannot convert openapi.yaml to JSON: " + err.Error())
+	}
+	return converted
+}
@@ -0,0 +1,5 @@
+// Placeholder for the Redoc 2.4.0 standalone bundle, which is served from the binary. Replace it
+// with the pinned release before building for production:
+//
+//	make redoc
+document.body.textContent = "The API docs viewer is not bundled in this build; run `make redoc` and rebuild.";
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Supported lists the languages with a message catalog. The first one is used when the
+// client states no preference, or only languages we do not support.
+var Supported = []language.Tag{language.BrazilianPortuguese, language.English}
+
+// Default is the language used when negotiation finds no match
+var Default = Supported[0]
+
+// fallback is the language consulted when a message is missing from the negotiated catalog
+var fallback = language.English
+
+var matcher = language.NewMatcher(Supported)
+
+var catalogs = map[language.Tag]map[string]string{
+	language.BrazilianPortuguese: messagesPtBR,
+	language.English:             messagesEn,
+}
+
+type contextKey struct{}
+
+// Negotiate picks the supported language that best matches an Accept-Language header
+func Negotiate(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+	return Supported[index]
+}
+
+// WithLanguage returns a copy of ctx carrying the negotiated language
+func WithLanguage(ctx context.Context, lang language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// LanguageFromContext returns the language carried by ctx, or Default
+func LanguageFromContext(ctx context.Context) language.Tag {
+	if lang, ok := ctx.Value(contextKey{}).(language.Tag); ok {
+		return lang
+	}
+	return Default
+}
+
+// Translate returns the message for code in lang with its {placeholders} replaced by params.
+// Missing messages fall back to English, then to the given fallback text.
+func Translate(lang language.Tag, code string, params map[string]string, fallbackText string) string {
+	template, ok := catalogs[lang][code]
+	if !ok {
+		template, ok = catalogs[fallback][code]
+	}
+	if !ok {
+		return fallbackText
+	}
+
+	if len(params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Run("should default to brazilian portuguese", func(t *testing.T) {
+		assert.Equal(t, language.BrazilianPortuguese, Negotiate(""))
+		assert.Equal(t, language.BrazilianPortuguese, Negotiate("fr-FR"))
+		assert.Equal(t, language.BrazilianPortuguese, Negotiate("not a header;;"))
+	})
+
+	t.Run("should honour the client preference", func(t *testing.T) {
+		assert.Equal(t, language.English, Negotiate("en-US,en;q=0.9"))
+		assert.Equal(t, language.English, Negotiate("fr;q=0.9,en;q=0.5"))
+		assert.Equal(t, language.BrazilianPortuguese, Negotiate("pt-PT"))
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	t.Run("should translate entity validation codes", func(t *testing.T) {
+		assert.Equal(t, "o valor deve ser maior que 0", Translate(language.BrazilianPortuguese, "amount_must_be_positive", nil, ""))
+		assert.Equal(t, "amount must be greater than 0", Translate(language.English, "amount_must_be_positive", nil, ""))
+	})
+
+	t.Run("should interpolate params", func(t *testing.T) {
+		message := Translate(language.BrazilianPortuguese, "too_long", map[string]string{"field": "description", "param": "255"}, "")
+		assert.Equal(t, "description deve ter no máximo 255 caracteres", message)
+	})
+
+	t.Run("should fall back to the given text for unknown codes", func(t *testing.T) {
+		assert.Equal(t, "something odd", Translate(language.BrazilianPortuguese, "unknown_code", nil, "something odd"))
+	})
+
+	t.Run("should have a brazilian portuguese message for every english one", func(t *testing.T) {
+		for code := range messagesEn {
+			_, ok := messagesPtBR[code]
+			assert.True(t, ok, "missing pt-BR message for %s", code)
+		}
+	})
+}
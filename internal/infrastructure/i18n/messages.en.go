@@ -0,0 +1,134 @@
+package i18n
+
+var messagesEn = map[string]string{
+	// Transaction
+	"category_id_required":    "category id is required",
+	"user_id_required":        "user id is required",
+	"amount_must_be_positive": "amount must be greater than 0",
+	"datetime_required":       "datetime is required",
+	"category_not_found":      "category does not exist",
+
+	// Category
+	"name_required":         "name is required",
+	"category_type_invalid": "invalid type",
+
+	// User
+	"keycloak_id_required": "keycloak id is required",
+	"email_required":       "email is required",
+	"username_required":    "username is required",
+	"user_status_invalid":  "invalid status",
+
+	// Request binding
+	"invalid_request_body": "request body is invalid",
+	"invalid_query":        "query string is invalid",
+	"malformed_json":       "request body is not valid JSON",
+	"invalid_type":         "{field} must be of type {type}",
+	"required":             "{field} is required",
+	"too_small":            "{field} must be greater than {param}",
+	"too_large":            "{field} must be less than {param}",
+	"below_minimum":        "{field} must be at least {param}",
+	"above_maximum":        "{field} must be at most {param}",
+	"too_short":            "{field} must have at least {param} characters",
+	"too_long":             "{field} must have at most {param} characters",
+	"invalid":              "{field} is invalid",
+	"unknown_field":        "{field} is not a known field",
+	"too_far_in_future":    "{field} must be at most {param} years in the future",
+
+	// Resources
+	"route_not_found":            "route {id} not found",
+	"transaction_not_found":      "transaction {id} not found",
+	"transaction_already_exists": "transaction already exists",
+	"category_already_exists":    "category already exists",
+
+	// Access
+	"authentication_required": "authentication is required",
+	"invalid_credentials":     "the credentials provided are invalid",
+	"origin_not_allowed":      "origin {origin} is not allowed",
+	"rate_limit_exceeded":     "too many requests, try again in {retryAfter} seconds",
+	"request_body_too_large":  "request body must be at most {limit} bytes",
+
+	// Idempotency
+	"idempotency_key_invalid": "Idempotency-Key must have between 1 and 255 printable characters",
+	"idempotency_key_reused":  "Idempotency-Key was already used with a different request",
+	"idempotency_key_in_use":  "a request with this Idempotency-Key is still being processed",
+
+	// Concurrency
+	"precondition_required": "this request must send If-Match with the ETag of the resource",
+	"invalid_precondition":  "{header} must be a single entity tag or *",
+	"version_mismatch":      "the resource was modified since it was read",
+
+	// Batch
+	"too_many_operations": "a batch may have at most {max} operations",
+	"unknown_operation":   "op must be create, update or delete",
+	"version_required":    "version is required to change an existing transaction",
+
+	// Export
+	"export_sheet_name":         "Transactions",
+	"export_column_date":        "Date",
+	"export_column_description": "Description",
+	"export_column_category":    "Category",
+	"export_column_type":        "Type",
+	"export_column_amount":      "Amount",
+	"export_type_expense":       "Expense",
+	"export_type_income":        "Income",
+
+	// Import
+	"delimiter_invalid":         "delimiter must be a single character other than a quote or a line break",
+	"date_format_invalid":       "date format must contain YYYY, MM and DD",
+	"column_required":           "{field} is required",
+	"column_position_invalid":   "without a header row, {field} must be a column position, starting at 1",
+	"category_type_mismatch":    "category must be of type {type}",
+	"import_profile_not_found":  "import profile {id} not found",
+	"import_profile_name_taken": "an import profile with this name already exists",
+	"import_settings_required":  "either profileId or settings is required",
+	"import_settings_conflict":  "send either profileId or settings, not both",
+	"multipart_required":        "request body must be multipart/form-data",
+	"file_too_large":            "the file must be at most {limit} bytes",
+	"csv_malformed":             "file is not a valid CSV (line {line})",
+	"csv_empty":                 "file has no rows to import",
+	"ofx_malformed":             "file is not an OFX statement",
+	"ofx_empty":                 "statement has no transactions to import",
+	"too_many_rows":             "a file may have at most {max} rows",
+	"column_not_found":          "the file has no column named {column}",
+	"row_invalid":               "row cannot be imported",
+	"column_missing":            "row has no value for {field}",
+	"date_invalid":              "{value} does not match the date format {format}",
+	"amount_invalid":            "{value} is not an amount",
+	"import_category_unknown":   "no category is named {name}",
+	"import_category_required":  "row has no category and no fallback category is set for its sign",
+
+	// Trash
+	"category_trashed": "the category of the transaction is in the trash, restore it first",
+
+	// Jobs
+	"job_not_found":          "job {id} not found",
+	"job_finished":           "the job is already {status}",
+	"job_result_unavailable": "the job has no result to download while {status}",
+	"job_abandoned":          "the job stopped responding on its last attempt",
+	"job_kind_unknown":       "no worker can run jobs of this kind",
+	"max_attempts_invalid":   "a job must be attempted at least once",
+	"file_not_found":         "file {id} not found",
+
+	// Recurring transactions
+	"recurring_transaction_not_found":      "recurring transaction {id} not found",
+	"occurrence_not_found":                 "the series has no occurrence on {id}",
+	"occurrence_recorded":                  "the occurrence on {date} was already recorded as a transaction",
+	"start_at_required":                    "start is required",
+	"recurrence_frequency_invalid":         "frequency must be daily, weekly, monthly or yearly",
+	"recurrence_interval_invalid":          "interval must be at least 1",
+	"recurrence_day_of_month_invalid":      "day of month must be between 1 and 31, and only applies to monthly schedules",
+	"recurrence_last_business_day_invalid": "last business day only applies to monthly schedules without a day of month",
+	"recurrence_count_invalid":             "count must not be negative",
+	"recurrence_until_invalid":             "until must not be before the start of the series",
+
+	// Installment purchases
+	"installment_purchase_not_found": "installment purchase {id} not found",
+	"installment_purchase_settled":   "the purchase has no remaining installments",
+	"installments_invalid":           "installments must be between 2 and {max}",
+	"installment_amount_too_small":   "amount must leave at least one cent for each remaining installment",
+	"installment_remainder_invalid":  "remainder must be first or last",
+	"first_due_at_required":          "first due date is required",
+
+	// Server
+	"internal_error": "an unexpected error occurred",
+}
@@ -0,0 +1,134 @@
+package i18n
+
+var messagesPtBR = map[string]string{
+	// Transaction
+	"category_id_required":    "o id da categoria é obrigatório",
+	"user_id_required":        "o id do usuário é obrigatório",
+	"amount_must_be_positive": "o valor deve ser maior que 0",
+	"datetime_required":       "a data e hora são obrigatórias",
+	"category_not_found":      "a categoria não existe",
+
+	// Category
+	"name_required":         "o nome é obrigatório",
+	"category_type_invalid": "o tipo da categoria é inválido",
+
+	// User
+	"keycloak_id_required": "o id do keycloak é obrigatório",
+	"email_required":       "o e-mail é obrigatório",
+	"username_required":    "o nome de usuário é obrigatório",
+	"user_status_invalid":  "o status do usuário é inválido",
+
+	// Request binding
+	"invalid_request_body": "o corpo da requisição é inválido",
+	"invalid_query":        "os parâmetros da URL são inválidos",
+	"malformed_json":       "o corpo da requisição não é um JSON válido",
+	"invalid_type":         "{field} deve ser do tipo {type}",
+	"required":             "{field} é obrigatório",
+	"too_small":            "{field} deve ser maior que {param}",
+	"too_large":            "{field} deve ser menor que {param}",
+	"below_minimum":        "{field} deve ser no mínimo {param}",
+	"above_maximum":        "{field} deve ser no máximo {param}",
+	"too_short":            "{field} deve ter pelo menos {param} caracteres",
+	"too_long":             "{field} deve ter no máximo {param} caracteres",
+	"invalid":              "{field} é inválido",
+	"unknown_field":        "{field} não é um campo conhecido",
+	"too_far_in_future":    "{field} deve estar no máximo {param} anos no futuro",
+
+	// Resources
+	"route_not_found":            "rota {id} não encontrada",
+	"transaction_not_found":      "transação {id} não encontrada",
+	"transaction_already_exists": "a transação já existe",
+	"category_already_exists":    "a categoria já existe",
+
+	// Access
+	"authentication_required": "é necessário estar autenticado",
+	"invalid_credentials":     "as credenciais informadas são inválidas",
+	"origin_not_allowed":      "a origem {origin} não é permitida",
+	"rate_limit_exceeded":     "muitas requisições, tente novamente em {retryAfter} segundos",
+	"request_body_too_large":  "o corpo da requisição deve ter no máximo {limit} bytes",
+
+	// Idempotency
+	"idempotency_key_invalid": "Idempotency-Key deve ter entre 1 e 255 caracteres imprimíveis",
+	"idempotency_key_reused":  "Idempotency-Key já foi usada em uma requisição diferente",
+	"idempotency_key_in_use":  "uma requisição com esta Idempotency-Key ainda está sendo processada",
+
+	// Concurrency
+	"precondition_required": "esta requisição deve enviar If-Match com a ETag do recurso",
+	"invalid_precondition":  "{header} deve ser uma única entity tag ou *",
+	"version_mismatch":      "o recurso foi alterado desde que foi lido",
+
+	// Batch
+	"too_many_operations": "um lote pode ter no máximo {max} operações",
+	"unknown_operation":   "op deve ser create, update ou delete",
+	"version_required":    "version é obrigatório para alterar uma transação existente",
+
+	// Export
+	"export_sheet_name":         "Transações",
+	"export_column_date":        "Data",
+	"export_column_description": "Descrição",
+	"export_column_category":    "Categoria",
+	"export_column_type":        "Tipo",
+	"export_column_amount":      "Valor",
+	"export_type_expense":       "Despesa",
+	"export_type_income":        "Receita",
+
+	// Import
+	"delimiter_invalid":         "o delimitador deve ser um único caractere, sem ser aspas ou quebra de linha",
+	"date_format_invalid":       "o formato de data deve conter YYYY, MM e DD",
+	"column_required":           "{field} é obrigatório",
+	"column_position_invalid":   "sem linha de cabeçalho, {field} deve ser a posição de uma coluna, começando em 1",
+	"category_type_mismatch":    "a categoria deve ser do tipo {type}",
+	"import_profile_not_found":  "perfil de importação {id} não encontrado",
+	"import_profile_name_taken": "já existe um perfil de importação com este nome",
+	"import_settings_required":  "informe profileId ou settings",
+	"import_settings_conflict":  "informe profileId ou settings, não ambos",
+	"multipart_required":        "o corpo da requisição deve ser multipart/form-data",
+	"file_too_large":            "o arquivo deve ter no máximo {limit} bytes",
+	"csv_malformed":             "o arquivo não é um CSV válido (linha {line})",
+	"csv_empty":                 "o arquivo não tem linhas para importar",
+	"ofx_malformed":             "o arquivo não é um extrato OFX",
+	"ofx_empty":                 "o extrato não tem transações para importar",
+	"too_many_rows":             "um arquivo pode ter no máximo {max} linhas",
+	"column_not_found":          "o arquivo não tem uma coluna chamada {column}",
+	"row_invalid":               "a linha não pode ser importada",
+	"column_missing":            "a linha não tem valor para {field}",
+	"date_invalid":              "{value} não corresponde ao formato de data {format}",
+	"amount_invalid":            "{value} não é um valor válido",
+	"import_category_unknown":   "nenhuma categoria se chama {name}",
+	"import_category_required":  "a linha não tem categoria e não há categoria padrão para o seu sinal",
+
+	// Trash
+	"category_trashed": "a categoria da transação está na lixeira, restaure-a primeiro",
+
+	// Jobs
+	"job_not_found":          "tarefa {id} não encontrada",
+	"job_finished":           "a tarefa já está {status}",
+	"job_result_unavailable": "a tarefa não tem resultado para baixar enquanto {status}",
+	"job_abandoned":          "a tarefa parou de responder na última tentativa",
+	"job_kind_unknown":       "nenhum worker executa tarefas deste tipo",
+	"max_attempts_invalid":   "uma tarefa deve ser tentada ao menos uma vez",
+	"file_not_found":         "arquivo {id} não encontrado",
+
+	// Recurring transactions
+	"recurring_transaction_not_found":      "transação recorrente {id} não encontrada",
+	"occurrence_not_found":                 "a série não tem ocorrência em {id}",
+	"occurrence_recorded":                  "a ocorrência de {date} já foi registrada como transação",
+	"start_at_required":                    "o início é obrigatório",
+	"recurrence_frequency_invalid":         "a frequência deve ser daily, weekly, monthly ou yearly",
+	"recurrence_interval_invalid":          "o intervalo deve ser ao menos 1",
+	"recurrence_day_of_month_invalid":      "o dia do mês deve estar entre 1 e 31 e só se aplica a agendas mensais",
+	"recurrence_last_business_day_invalid": "o último dia útil só se aplica a agendas mensais sem dia do mês",
+	"recurrence_count_invalid":             "a quantidade não pode ser negativa",
+	"recurrence_until_invalid":             "o fim não pode ser anterior ao início da série",
+
+	// Installment purchases
+	"installment_purchase_not_found": "compra parcelada {id} não encontrada",
+	"installment_purchase_settled":   "a compra não tem parcelas restantes",
+	"installments_invalid":           "o número de parcelas deve estar entre 2 e {max}",
+	"installment_amount_too_small":   "o valor deve deixar ao menos um centavo para cada parcela restante",
+	"installment_remainder_invalid":  "o resto deve ficar na parcela first ou last",
+	"first_due_at_required":          "o vencimento da primeira parcela é obrigatório",
+
+	// Server
+	"internal_error": "ocorreu um erro inesperado",
+}
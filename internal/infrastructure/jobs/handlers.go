@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/export"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/report"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// exportPayload holds the parameters of an export_transactions job
+type exportPayload struct {
+	Format   export.Format `json:"format"`
+	Language string        `json:"language"`
+	TimeZone string        `json:"timeZone"`
+}
+
+// importPayload holds the parameters of the import_csv and import_ofx jobs, whose statement is
+// the input of the job
+type importPayload struct {
+	Language          string                 `json:"language"`
+	ProfileID         uuid.UUID              `json:"profileId"`
+	Settings          *entity.ImportSettings `json:"settings,omitempty"`
+	ExpenseCategoryID uuid.UUID              `json:"expenseCategoryId"`
+	IncomeCategoryID  uuid.UUID              `json:"incomeCategoryId"`
+	Commit            bool                   `json:"commit"`
+}
+
+// ExportTransactionsJob exports every transaction of the user to a file in format, written
+// for lang with dates in location as GET /v1/transactions/export would
+func ExportTransactionsJob(userID uuid.UUID, format export.Format, lang language.Tag, location *time.Location) *dto.EnqueueJobDTO {
+	return &dto.EnqueueJobDTO{
+		UserID:  userID,
+		Kind:    enum.JobKindExportTransactions,
+		Payload: exportPayload{Format: format, Language: lang.String(), TimeZone: location.String()},
+	}
+}
+
+// ImportCSVJob imports a CSV statement, its result being the report POST /v1/imports/csv
+// answers with, written for lang
+func ImportCSVJob(importCSVDTO *dto.ImportCSVDTO, lang language.Tag) *dto.EnqueueJobDTO {
+	return &dto.EnqueueJobDTO{
+		UserID: importCSVDTO.UserID,
+		Kind:   enum.JobKindImportCSV,
+		Payload: importPayload{
+			Language:  lang.String(),
+			ProfileID: importCSVDTO.ProfileID,
+			Settings:  importCSVDTO.Settings,
+			Commit:    importCSVDTO.Commit,
+		},
+		Input: importCSVDTO.Content,
+	}
+}
+
+// ImportOFXJob imports an OFX statement, its result being the report POST /v1/imports/ofx
+// answers with, written for lang
+func ImportOFXJob(importOFXDTO *dto.ImportOFXDTO, lang language.Tag) *dto.EnqueueJobDTO {
+	return &dto.EnqueueJobDTO{
+		UserID: importOFXDTO.UserID,
+		Kind:   enum.JobKindImportOFX,
+		Payload: importPayload{
+			Language:          lang.String(),
+			ExpenseCategoryID: importOFXDTO.ExpenseCategoryID,
+			IncomeCategoryID:  importOFXDTO.IncomeCategoryID,
+			Commit:            importOFXDTO.Commit,
+		},
+		Input: importOFXDTO.Content,
+	}
+}
+
+// Handlers returns the handler of every job kind
+func Handlers(transactionService interfaces.TransactionServiceInterface, importService interfaces.ImportServiceInterface) map[enum.JobKind]Handler {
+	return map[enum.JobKind]Handler{
+		enum.JobKindExportTransactions: exportTransactions(transactionService),
+		enum.JobKindImportCSV: importStatement(func(ctx context.Context, job *entity.Job, payload importPayload, content []byte) (*dto.ImportResultDTO, error) {
+			return importService.ImportCSV(ctx, &dto.ImportCSVDTO{
+				UserID:    job.UserID(),
+				ProfileID: payload.ProfileID,
+				Settings:  payload.Settings,
+				Content:   content,
+				Commit:    payload.Commit,
+			})
+		}),
+		enum.JobKindImportOFX: importStatement(func(ctx context.Context, job *entity.Job, payload importPayload, content []byte) (*dto.ImportResultDTO, error) {
+			return importService.ImportOFX(ctx, &dto.ImportOFXDTO{
+				UserID:            job.UserID(),
+				ExpenseCategoryID: payload.ExpenseCategoryID,
+				IncomeCategoryID:  payload.IncomeCategoryID,
+				Content:           content,
+				Commit:            payload.Commit,
+			})
+		}),
+	}
+}
+
+// exportTransactions reports progress as the share of the transactions counted at the start
+// that has been written
+func exportTransactions(transactionService interfaces.TransactionServiceInterface) Handler {
+	return func(ctx context.Context, job *entity.Job, run *Run) error {
+		var payload exportPayload
+		if err := json.Unmarshal(job.Payload(), &payload); err != nil {
+			return err
+		}
+
+		location, err := time.LoadLocation(payload.TimeZone)
+		if err != nil {
+			return err
+		}
+
+		_, page, err := transactionService.FindAllPaginated(ctx, job.UserID(), 1, 1)
+		if err != nil {
+			return err
+		}
+
+		output, err := run.Output(ctx, payload.Format.FileName(job.CreatedAt().In(location)), payload.Format.ContentType())
+		if err != nil {
+			return err
+		}
+		writer, err := export.NewWriter(payload.Format, output, language.Make(payload.Language), location, job.CreatedAt())
+		if err != nil {
+			return err
+		}
+
+		var written int64
+		err = transactionService.Export(ctx, job.UserID(), func(exported dto.ExportedTransactionDTO) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			written++
+			if page.TotalItems > 0 {
+				run.Progress(int(min(written*100/page.TotalItems, 99)))
+			}
+			return writer.Write(exported)
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+// importStatement reads the statement uploaded with the job and writes the report of the import
+func importStatement(importFn func(ctx context.Context, job *entity.Job, payload importPayload, content []byte) (*dto.ImportResultDTO, error)) Handler {
+	return func(ctx context.Context, job *entity.Job, run *Run) error {
+		var payload importPayload
+		if err := json.Unmarshal(job.Payload(), &payload); err != nil {
+			return err
+		}
+		ctx = i18n.WithLanguage(ctx, language.Make(payload.Language))
+
+		input, err := run.Input(ctx)
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(input)
+		input.Close()
+		if err != nil {
+			return err
+		}
+
+		result, err := importFn(ctx, job, payload, content)
+		if err != nil {
+			return err
+		}
+
+		importReport := report.BuildImportResponse(result, func(err error) *problem.Problem {
+			return report.DescribeError(ctx, err)
+		})
+		output, err := run.Output(ctx, "import-"+job.CreatedAt().UTC().Format(time.DateOnly)+".json", "application/json")
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(output).Encode(importReport)
+	}
+}
@@ -0,0 +1,320 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+)
+
+// errJobLost stops a handler whose job was cancelled, or claimed by another worker after the
+// lease expired, while it ran
+var errJobLost = errors.New("job is no longer held by this worker")
+
+var (
+	internalFailure  = entity.JobFailure{Code: "internal_error", Message: "an unexpected error occurred"}
+	abandonedFailure = entity.JobFailure{Code: "job_abandoned", Message: "the job stopped responding on its last attempt"}
+	unknownFailure   = entity.JobFailure{Code: "job_kind_unknown", Message: "no worker can run jobs of this kind"}
+)
+
+// Handler runs one attempt of a job of the kind it is registered for, reading its parameters
+// from job.Payload(). A domain error fails the job for good, as trying the same request again
+// cannot fix it; any other error is retried.
+type Handler func(ctx context.Context, job *entity.Job, run *Run) error
+
+// Run is the attempt of a job being run by a handler
+type Run struct {
+	job      *entity.Job
+	storage  interfaces.FileStorageInterface
+	progress atomic.Int64
+	result   entity.JobResult
+	output   io.WriteCloser
+}
+
+// Progress reports how much of the job is done, as a percentage, saved with the next heartbeat
+func (r *Run) Progress(percent int) {
+	r.progress.Store(int64(percent))
+}
+
+// Input opens the file uploaded along with the job
+func (r *Run) Input(ctx context.Context) (io.ReadCloser, error) {
+	return r.storage.Open(ctx, r.job.InputKey())
+}
+
+// Output starts the file the job produces, offered for download as name. It is closed by the
+// worker once the handler returns.
+func (r *Run) Output(ctx context.Context, name, contentType string) (io.Writer, error) {
+	if r.output != nil {
+		return nil, fmt.Errorf("job %s already has an output", r.job.ID())
+	}
+
+	output, err := r.storage.Create(ctx, r.job.ResultKey())
+	if err != nil {
+		return nil, err
+	}
+	r.output = output
+	r.result = entity.JobResult{Name: name, ContentType: contentType}
+	return output, nil
+}
+
+// Options sizes a Pool and paces its work
+type Options struct {
+	// Workers is how many jobs run at once
+	Workers int
+	// PollInterval is how long an idle pool waits before looking for due jobs again
+	PollInterval time.Duration
+	// HeartbeatInterval is how often running jobs save their progress and renew their lease
+	HeartbeatInterval time.Duration
+	// Lease is how long a job is held without a heartbeat before other workers may claim it
+	Lease time.Duration
+	// RetryBackoff is the delay before the first retry of a failed job, doubled on every retry
+	RetryBackoff time.Duration
+}
+
+// Pool runs due jobs in the background. Any number of pools, in any number of processes, may
+// share the same jobs: a job is claimed by updating the version it was read with, so a single
+// pool wins it, and the job is held under a lease renewed by heartbeats. A heartbeat that finds
+// the job changed, which is how a cancellation shows, stops the handler.
+type Pool struct {
+	jobs     repository.JobRepositoryInterface
+	storage  interfaces.FileStorageInterface
+	clock    clock.Clock
+	handlers map[enum.JobKind]Handler
+	options  Options
+}
+
+func NewPool(jobs repository.JobRepositoryInterface, storage interfaces.FileStorageInterface, clock clock.Clock, handlers map[enum.JobKind]Handler, options Options) *Pool {
+	return &Pool{
+		jobs:     jobs,
+		storage:  storage,
+		clock:    clock,
+		handlers: handlers,
+		options:  options,
+	}
+}
+
+// Run claims and runs due jobs until ctx is cancelled, then waits for the running ones to stop
+// and puts them back in the queue for the next worker
+func (p *Pool) Run(ctx context.Context) {
+	slots := make(chan struct{}, p.options.Workers)
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case slots <- struct{}{}:
+		}
+
+		job := p.claim(ctx)
+		if job == nil {
+			<-slots
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.options.PollInterval):
+			}
+			continue
+		}
+
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			defer func() { <-slots }()
+			p.run(ctx, job)
+		}()
+	}
+}
+
+// claim starts the next due job, or returns nil when there is none. A job abandoned on its
+// last attempt is failed instead of started again.
+func (p *Pool) claim(ctx context.Context) *entity.Job {
+	for {
+		job, err := p.jobs.FindNextDue(ctx, p.clock.Now())
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.FromContext(ctx).ErrorContext(ctx, "failed to look for due jobs", "error", err)
+			}
+			return nil
+		}
+		if job == nil {
+			return nil
+		}
+
+		abandoned := job.Status() == enum.JobStatusRunning && !job.CanRetry()
+		if abandoned {
+			job.Fail(p.clock, abandonedFailure)
+		} else {
+			job.Start(p.clock, p.options.Lease)
+		}
+
+		claimed, err := p.jobs.Update(ctx, job)
+		if domainerror.IsKind(err, domainerror.KindPreconditionFailed) {
+			continue
+		}
+		if err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "failed to claim job", "job_id", job.ID(), "error", err)
+			return nil
+		}
+		if abandoned {
+			logger.FromContext(ctx).WarnContext(ctx, "job abandoned", "job_id", job.ID(), "kind", job.Kind())
+			continue
+		}
+		return claimed
+	}
+}
+
+// run holds job while its handler runs, then records how the attempt ended
+func (p *Pool) run(ctx context.Context, job *entity.Job) {
+	log := logger.FromContext(ctx).With("job_id", job.ID(), "kind", job.Kind(), "attempt", job.Attempts())
+
+	handler, ok := p.handlers[job.Kind()]
+	if !ok {
+		job.Fail(p.clock, unknownFailure)
+		p.save(ctx, job, log)
+		return
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	attempt := *job
+	run := &Run{job: &attempt, storage: p.storage}
+	done := make(chan error, 1)
+	go func() {
+		done <- call(runCtx, handler, &attempt, run)
+	}()
+
+	heartbeat := time.NewTicker(p.options.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	var err error
+	for waiting := true; waiting; {
+		select {
+		case err = <-done:
+			waiting = false
+		case <-heartbeat.C:
+			job.Heartbeat(p.clock, int(run.progress.Load()), p.options.Lease)
+			renewed, heartbeatErr := p.jobs.Update(ctx, job)
+			switch {
+			case heartbeatErr == nil:
+				job = renewed
+			case domainerror.IsKind(heartbeatErr, domainerror.KindPreconditionFailed) || domainerror.IsKind(heartbeatErr, domainerror.KindNotFound):
+				cancel(errJobLost)
+			case ctx.Err() == nil:
+				log.ErrorContext(ctx, "failed to renew job lease", "error", heartbeatErr)
+			}
+		}
+	}
+
+	if run.output != nil {
+		if closeErr := run.output.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	// the outcome is saved even when the pool is stopping
+	stopping := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+
+	switch {
+	case errors.Is(context.Cause(runCtx), errJobLost):
+		p.lost(ctx, job, log)
+	case err != nil && stopping:
+		p.discardOutput(ctx, job, run, log)
+		job.Release(p.clock)
+		if p.save(ctx, job, log) {
+			log.InfoContext(ctx, "job released")
+		}
+	case err != nil:
+		p.discardOutput(ctx, job, run, log)
+		p.failed(ctx, job, err, log)
+	default:
+		job.Succeed(p.clock, run.result)
+		if p.save(ctx, job, log) {
+			log.InfoContext(ctx, "job succeeded")
+		}
+	}
+}
+
+// failed retries job after a backoff that doubles with every attempt, unless err is a domain
+// error or no attempt is left
+func (p *Pool) failed(ctx context.Context, job *entity.Job, err error, log *slog.Logger) {
+	failure := internalFailure
+	retryable := true
+	if domainErr, ok := domainerror.As(err); ok {
+		failure = entity.JobFailure{Code: domainErr.Code, Message: domainErr.Message, Params: domainErr.Params}
+		retryable = false
+	}
+
+	if retryable && job.CanRetry() {
+		job.Retry(p.clock, failure, p.options.RetryBackoff<<(job.Attempts()-1))
+	} else {
+		job.Fail(p.clock, failure)
+	}
+
+	if p.save(ctx, job, log) {
+		log.ErrorContext(ctx, "job attempt failed", "error", err, "status", job.Status())
+	}
+}
+
+// lost deals with a job taken away from the handler: the file a cancelled job left is removed,
+// while a job claimed by another worker is left to it
+func (p *Pool) lost(ctx context.Context, job *entity.Job, log *slog.Logger) {
+	current, err := p.jobs.FindByID(ctx, job.ID())
+	if err != nil {
+		return
+	}
+	if current.Status() == enum.JobStatusCancelled {
+		if err := p.storage.Delete(ctx, job.ResultKey()); err != nil {
+			log.ErrorContext(ctx, "failed to delete the result of a cancelled job", "error", err)
+		}
+	}
+}
+
+// save stores how an attempt ended, reporting whether it was stored. A job that changed since
+// its last heartbeat was taken away, which lost handles.
+func (p *Pool) save(ctx context.Context, job *entity.Job, log *slog.Logger) bool {
+	_, err := p.jobs.Update(ctx, job)
+	if domainerror.IsKind(err, domainerror.KindPreconditionFailed) {
+		p.lost(ctx, job, log)
+		return false
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to save job", "error", err)
+		return false
+	}
+	return true
+}
+
+func (p *Pool) discardOutput(ctx context.Context, job *entity.Job, run *Run, log *slog.Logger) {
+	if run.output == nil {
+		return
+	}
+	if err := p.storage.Delete(ctx, job.ResultKey()); err != nil {
+		log.ErrorContext(ctx, "failed to delete the output of a job", "error", err)
+	}
+}
+
+// call runs handler, turning a panic into an error so a single job cannot take the process down
+func call(ctx context.Context, handler Handler, job *entity.Job, run *Run) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return handler(ctx, job, run)
+}
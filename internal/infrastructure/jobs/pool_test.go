@@ -0,0 +1,208 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/service"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKind enum.JobKind = "test"
+
+type poolFixture struct {
+	jobs    repository.JobRepositoryInterface
+	storage interfaces.FileStorageInterface
+	service interfaces.JobServiceInterface
+	userID  uuid.UUID
+}
+
+func newPoolFixture() *poolFixture {
+	jobs := memory.NewJobRepository(memory.NewStore())
+	storage := memory.NewFileStorage()
+	return &poolFixture{
+		jobs:    jobs,
+		storage: storage,
+		service: service.NewJobService(jobs, storage, clock.System(), identifier.NewV7(), 2, time.Hour),
+		userID:  uuid.New(),
+	}
+}
+
+// start runs a pool with handler until the test ends, returning the function that stops it
+func (f *poolFixture) start(t *testing.T, handler Handler) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewPool(f.jobs, f.storage, clock.System(), map[enum.JobKind]Handler{testKind: handler}, Options{
+		Workers:           2,
+		PollInterval:      5 * time.Millisecond,
+		HeartbeatInterval: 10 * time.Millisecond,
+		Lease:             time.Minute,
+		RetryBackoff:      10 * time.Millisecond,
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		pool.Run(ctx)
+	}()
+
+	stop = func() {
+		cancel()
+		<-stopped
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+func (f *poolFixture) enqueue(t *testing.T) *entity.Job {
+	job, err := f.service.Enqueue(context.Background(), &dto.EnqueueJobDTO{UserID: f.userID, Kind: testKind, Payload: map[string]string{}})
+	assert.Nil(t, err)
+	return job
+}
+
+// waitFor waits until the job reaches status and returns it
+func (f *poolFixture) waitFor(t *testing.T, id uuid.UUID, status enum.JobStatus) *entity.Job {
+	var job *entity.Job
+	assert.Eventually(t, func() bool {
+		job, _ = f.jobs.FindByID(context.Background(), id)
+		return job.Status() == status
+	}, time.Second, 5*time.Millisecond)
+	return job
+}
+
+func TestPool(t *testing.T) {
+	t.Run("should run a job and keep its output", func(t *testing.T) {
+		fixture := newPoolFixture()
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			output, err := run.Output(ctx, "report.txt", "text/plain")
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(output, "done")
+			return err
+		})
+
+		job := fixture.waitFor(t, fixture.enqueue(t).ID(), enum.JobStatusSucceeded)
+
+		assert.Equal(t, 100, job.Progress())
+		assert.Equal(t, entity.JobResult{Name: "report.txt", ContentType: "text/plain"}, job.Result())
+		_, result, err := fixture.service.OpenResult(context.Background(), fixture.userID, job.ID())
+		assert.Nil(t, err)
+		content, _ := io.ReadAll(result)
+		assert.Equal(t, "done", string(content))
+	})
+
+	t.Run("should save the progress of a running job", func(t *testing.T) {
+		fixture := newPoolFixture()
+		release := make(chan struct{})
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			run.Progress(40)
+			<-release
+			return nil
+		})
+
+		id := fixture.enqueue(t).ID()
+		assert.Eventually(t, func() bool {
+			job, _ := fixture.jobs.FindByID(context.Background(), id)
+			return job.Progress() == 40
+		}, time.Second, 5*time.Millisecond)
+		close(release)
+	})
+
+	t.Run("should retry a failed job until the attempts run out", func(t *testing.T) {
+		fixture := newPoolFixture()
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			return errors.New("connection reset")
+		})
+
+		job := fixture.waitFor(t, fixture.enqueue(t).ID(), enum.JobStatusFailed)
+
+		assert.Equal(t, 2, job.Attempts())
+		assert.Equal(t, "internal_error", job.Failure().Code)
+	})
+
+	t.Run("should not retry a job failed by a domain error", func(t *testing.T) {
+		fixture := newPoolFixture()
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			return domainerror.NewInvalidInput("file", "csv_empty", "file has no rows to import")
+		})
+
+		job := fixture.waitFor(t, fixture.enqueue(t).ID(), enum.JobStatusFailed)
+
+		assert.Equal(t, 1, job.Attempts())
+		assert.Equal(t, "csv_empty", job.Failure().Code)
+	})
+
+	t.Run("should stop a running job that is cancelled and drop its output", func(t *testing.T) {
+		fixture := newPoolFixture()
+		stopped := make(chan error, 1)
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			output, _ := run.Output(ctx, "report.txt", "text/plain")
+			io.WriteString(output, "partial")
+			<-ctx.Done()
+			stopped <- ctx.Err()
+			return ctx.Err()
+		})
+
+		id := fixture.enqueue(t).ID()
+		fixture.waitFor(t, id, enum.JobStatusRunning)
+		_, err := fixture.service.Cancel(context.Background(), fixture.userID, id)
+		assert.Nil(t, err)
+
+		select {
+		case err := <-stopped:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("the handler was not stopped")
+		}
+		assert.Eventually(t, func() bool {
+			_, err := fixture.storage.Open(context.Background(), "jobs/"+id.String()+"/result")
+			return domainerror.IsKind(err, domainerror.KindNotFound)
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, enum.JobStatusCancelled, fixture.waitFor(t, id, enum.JobStatusCancelled).Status())
+	})
+
+	t.Run("should put running jobs back in the queue when stopped", func(t *testing.T) {
+		fixture := newPoolFixture()
+		stop := fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		id := fixture.enqueue(t).ID()
+		fixture.waitFor(t, id, enum.JobStatusRunning)
+		stop()
+
+		job, _ := fixture.jobs.FindByID(context.Background(), id)
+		assert.Equal(t, enum.JobStatusQueued, job.Status())
+		assert.Equal(t, 0, job.Attempts())
+	})
+
+	t.Run("should fail a job abandoned on its last attempt", func(t *testing.T) {
+		fixture := newPoolFixture()
+		expired := time.Now().Add(-time.Minute)
+		job, _ := entity.RestoreJob(uuid.New(), fixture.userID, testKind, enum.JobStatusRunning, []byte(`{}`), 50, 2, 2,
+			expired, expired, entity.JobResult{}, entity.JobFailure{}, 3, expired, expired, expired, time.Time{})
+		fixture.jobs.Create(context.Background(), job)
+		fixture.start(t, func(ctx context.Context, job *entity.Job, run *Run) error {
+			t.Error("an abandoned job was started again")
+			return nil
+		})
+
+		failed := fixture.waitFor(t, job.ID(), enum.JobStatusFailed)
+
+		assert.Equal(t, "job_abandoned", failed.Failure().Code)
+	})
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "flux_control"
+
+// Metrics holds every Prometheus collector exposed by the application
+type Metrics struct {
+	registry            *prometheus.Registry
+	httpRequestDuration *prometheus.HistogramVec
+	dbQueryDuration     *prometheus.HistogramVec
+	transactionsCreated *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them, along with the Go runtime and process
+// collectors, in a dedicated registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of database queries by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		transactionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "business",
+			Name:      "transactions_created_total",
+			Help:      "Number of transactions created by category type.",
+		}, []string{"category_type"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequestDuration,
+		m.dbQueryDuration,
+		m.transactionsCreated,
+	)
+
+	return m
+}
+
+// RegisterDBStats exposes the connection pool statistics of a database handle
+func (m *Metrics) RegisterDBStats(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest records the duration of an HTTP request
+func (m *Metrics) ObserveHTTPRequest(method, route, status string, seconds float64) {
+	m.httpRequestDuration.WithLabelValues(method, route, status).Observe(seconds)
+}
+
+// ObserveDBQuery records the duration of a database query
+func (m *Metrics) ObserveDBQuery(operation, table string, seconds float64) {
+	m.dbQueryDuration.WithLabelValues(operation, table).Observe(seconds)
+}
+
+func (m *Metrics) TransactionCreated(categoryType enum.CategoryType) {
+	m.transactionsCreated.WithLabelValues(string(categoryType)).Inc()
+}
+
+// Noop discards business metrics when metrics are disabled
+type Noop struct{}
+
+func (Noop) TransactionCreated(enum.CategoryType) {}
@@ -1,24 +1,62 @@
 package db
 
 import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
-	"github.com/spf13/viper"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-func NewGormDB(config *viper.Viper) (*gorm.DB, error) {
-	return gorm.Open(mysql.Open(config.GetString("db.connection_string")), &gorm.Config{})
+// models lists every table managed by AutoMigrate
+var models = []any{&model.User{}, &model.Category{}, &model.Transaction{}, &model.IdempotencyKey{}, &model.AuditEntry{}, &model.ImportProfile{}, &model.Job{}, &model.RecurringTransaction{}, &model.InstallmentPurchase{}}
+
+func NewGormDB(cfg *config.Config) (*gorm.DB, error) {
+	db, err := openPrimary(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return withReplicas(db, cfg.DB)
 }
 
-func NewGormDBWithAutoMigrate(config *viper.Viper) (*gorm.DB, error) {
-	db, err := NewGormDB(config)
+// NewGormDBWithAutoMigrate migrates before the replicas are registered, so the migrator
+// inspects the primary rather than a replica that may lag behind it
+func NewGormDBWithAutoMigrate(cfg *config.Config) (*gorm.DB, error) {
+	db, err := openPrimary(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(models...)
 	if err != nil {
 		return nil, err
 	}
-	err = db.AutoMigrate(&model.User{}, &model.Category{}, &model.Transaction{})
+	return withReplicas(db, cfg.DB)
+}
+
+func openPrimary(cfg *config.Config) (*gorm.DB, error) {
+	return gorm.Open(mysql.Open(cfg.DB.ConnectionString), &gorm.Config{
+		TranslateError: true,
+		Logger:         NewGormLogger(cfg.Logging.SlowQueryThreshold, cfg.Logging.Redact),
+	})
+}
+
+// withReplicas applies the pool settings and, when read replicas are configured, routes
+// reads to them
+func withReplicas(db *gorm.DB, cfg config.DBConfig) (*gorm.DB, error) {
+	if len(cfg.Replicas) > 0 {
+		if err := registerReplicas(db, cfg); err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+
+	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
 	return db, nil
 }
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/health"
+	"gorm.io/gorm"
+)
+
+// PingCheck verifies that the database accepts connections
+func PingCheck(gormDB *gorm.DB) health.Check {
+	return health.Check{
+		Name: "database",
+		Check: func(ctx context.Context) error {
+			sqlDB, err := gormDB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// MigrationCheck verifies that the table of every model has been migrated
+func MigrationCheck(gormDB *gorm.DB) health.Check {
+	return health.Check{
+		Name: "migrations",
+		Check: func(ctx context.Context) error {
+			migrator := gormDB.WithContext(ctx).Migrator()
+			for _, model := range models {
+				if !migrator.HasTable(model) {
+					return fmt.Errorf("table for %T is missing", model)
+				}
+			}
+			return nil
+		},
+	}
+}
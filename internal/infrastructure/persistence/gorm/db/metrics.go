@@ -0,0 +1,63 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryObserver receives the duration of each database query
+type QueryObserver interface {
+	ObserveDBQuery(operation, table string, seconds float64)
+}
+
+const queryStartKey = "metrics:query_start"
+
+// RegisterQueryMetrics times every create, query, update, delete, row and raw statement
+// through GORM callbacks and reports it to observer
+func RegisterQueryMetrics(gormDB *gorm.DB, observer QueryObserver) error {
+	callbacks := gormDB.Callback()
+	type register func(name string, fn func(*gorm.DB)) error
+
+	processors := []struct {
+		operation string
+		before    register
+		after     register
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+
+	for _, processor := range processors {
+		if err := processor.before("metrics:before_"+processor.operation, startQueryTimer); err != nil {
+			return err
+		}
+		if err := processor.after("metrics:after_"+processor.operation, stopQueryTimer(processor.operation, observer)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startQueryTimer(tx *gorm.DB) {
+	tx.InstanceSet(queryStartKey, time.Now())
+}
+
+func stopQueryTimer(operation string, observer QueryObserver) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		observer.ObserveDBQuery(operation, tx.Statement.Table, time.Since(start).Seconds())
+	}
+}
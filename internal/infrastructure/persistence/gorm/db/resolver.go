@@ -0,0 +1,58 @@
+package db
+
+import (
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/config"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/readafterwrite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// registerReplicas sends reads outside transactions to the replicas and everything else to
+// the primary, and applies the pool settings to every connection pool
+func registerReplicas(gormDB *gorm.DB, cfg config.DBConfig) error {
+	replicas := make([]gorm.Dialector, len(cfg.Replicas))
+	for i, dsn := range cfg.Replicas {
+		replicas[i] = mysql.Open(dsn)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.ConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := gormDB.Use(resolver); err != nil {
+		return err
+	}
+
+	return registerWriteTracking(gormDB)
+}
+
+// registerWriteTracking records every create, update and delete in the statement context,
+// so Conn can keep the rest of the request on the primary
+func registerWriteTracking(gormDB *gorm.DB) error {
+	callbacks := gormDB.Callback()
+	registers := []func(name string, fn func(*gorm.DB)) error{
+		callbacks.Create().After("gorm:create").Register,
+		callbacks.Update().After("gorm:update").Register,
+		callbacks.Delete().After("gorm:delete").Register,
+	}
+
+	for _, register := range registers {
+		if err := register("read_after_write:mark", markWritten); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func markWritten(tx *gorm.DB) {
+	if tx.Error == nil && tx.Statement.Context != nil {
+		readafterwrite.MarkWritten(tx.Statement.Context)
+	}
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/readafterwrite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type txKey struct{}
+
+type UnitOfWork struct {
+	gorm *gorm.DB
+}
+
+func NewUnitOfWork(gorm *gorm.DB) interfaces.UnitOfWorkInterface {
+	return &UnitOfWork{gorm: gorm}
+}
+
+// Do starts a transaction, or a savepoint when ctx already carries one, and runs fn inside it
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Conn(ctx, u.gorm).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction bound to ctx by a UnitOfWork, or fallback when there is none.
+// Once the request has written anything, reads are pinned to the primary
+func Conn(ctx context.Context, fallback *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	if readafterwrite.Written(ctx) {
+		return fallback.WithContext(ctx).Clauses(dbresolver.Write)
+	}
+	return fallback.WithContext(ctx)
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry is a row of the append-only audit log. Changes holds the field-level diff as
+// JSON.
+type AuditEntry struct {
+	ID         uuid.UUID     `gorm:"primaryKey"`
+	UserID     uuid.UUID     `gorm:"not null;index:idx_audit_log_record,priority:1"`
+	Resource   string        `gorm:"not null;size:32;index:idx_audit_log_record,priority:2"`
+	ResourceID uuid.UUID     `gorm:"not null;index:idx_audit_log_record,priority:3"`
+	Action     string        `gorm:"not null;size:16"`
+	RequestID  string        `gorm:"null;size:128"`
+	Changes    []AuditChange `gorm:"not null;type:json;serializer:json"`
+	OccurredAt time.Time     `gorm:"not null;index:idx_audit_log_record,priority:4"`
+}
+
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func (e *AuditEntry) TableName() string {
+	return "audit_log"
+}
@@ -4,17 +4,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Category struct {
-	ID        uuid.UUID `gorm:"primaryKey"`
-	UserID    uuid.UUID `gorm:"not null"`
-	Name      string    `gorm:"not null"`
-	Type      string    `gorm:"not null"`
-	IsDefault bool      `gorm:"not null"`
-	Icon      string    `gorm:"null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID        uuid.UUID      `gorm:"primaryKey"`
+	UserID    uuid.UUID      `gorm:"not null"`
+	Name      string         `gorm:"not null"`
+	Type      string         `gorm:"not null"`
+	IsDefault bool           `gorm:"not null"`
+	Icon      string         `gorm:"null"`
+	Version   int64          `gorm:"not null;default:1"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 func (c *Category) TableName() string {
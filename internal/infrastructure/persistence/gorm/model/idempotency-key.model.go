@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKey struct {
+	UserID       uuid.UUID         `gorm:"primaryKey"`
+	Key          string            `gorm:"primaryKey;size:255"`
+	RequestHash  string            `gorm:"not null;size:64"`
+	StatusCode   int               `gorm:"not null"`
+	ContentType  string            `gorm:"null"`
+	Headers      map[string]string `gorm:"null;type:json;serializer:json"`
+	ResponseBody []byte            `gorm:"type:mediumblob"`
+	CreatedAt    time.Time         `gorm:"not null"`
+	ExpiresAt    time.Time         `gorm:"not null;index"`
+}
+
+func (k *IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportProfile is a saved set of statement import settings, the column mapping flattened
+// into one column per transaction field
+type ImportProfile struct {
+	ID                uuid.UUID `gorm:"primaryKey"`
+	UserID            uuid.UUID `gorm:"not null;uniqueIndex:idx_import_profiles_user_name,priority:1"`
+	Name              string    `gorm:"not null;size:100;uniqueIndex:idx_import_profiles_user_name,priority:2"`
+	Delimiter         string    `gorm:"not null;size:4"`
+	DecimalComma      bool      `gorm:"not null"`
+	DateFormat        string    `gorm:"not null;size:32"`
+	HasHeader         bool      `gorm:"not null"`
+	NegateAmounts     bool      `gorm:"not null"`
+	DateColumn        string    `gorm:"not null;size:100"`
+	AmountColumn      string    `gorm:"not null;size:100"`
+	DescriptionColumn string    `gorm:"not null;size:100"`
+	CategoryColumn    string    `gorm:"not null;size:100"`
+	ExpenseCategoryID uuid.UUID `gorm:"not null"` // uuid.Nil when unset
+	IncomeCategoryID  uuid.UUID `gorm:"not null"` // uuid.Nil when unset
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+func (p *ImportProfile) TableName() string {
+	return "import_profiles"
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstallmentPurchase is a purchase paid in installments, which are kept in a JSON column as they
+// are always read and written along with the purchase
+type InstallmentPurchase struct {
+	ID           uuid.UUID     `gorm:"primaryKey"`
+	UserID       uuid.UUID     `gorm:"not null;index"`
+	CategoryID   uuid.UUID     `gorm:"not null"`
+	Description  string        `gorm:"not null"`
+	TotalAmount  float64       `gorm:"not null"`
+	Remainder    string        `gorm:"not null;size:8"`
+	Installments []Installment `gorm:"not null;type:json;serializer:json"`
+	Version      int64         `gorm:"not null;default:1"`
+	CreatedAt    time.Time     `gorm:"not null"`
+	UpdatedAt    time.Time     `gorm:"not null"`
+	CancelledAt  *time.Time    `gorm:"null"`
+}
+
+type Installment struct {
+	Number        int       `json:"number"`
+	TransactionID uuid.UUID `json:"transactionId"`
+	DueAt         time.Time `json:"dueAt"`
+	Amount        float64   `json:"amount"`
+	Cancelled     bool      `json:"cancelled,omitempty"`
+}
+
+func (p *InstallmentPurchase) TableName() string {
+	return "installment_purchases"
+}
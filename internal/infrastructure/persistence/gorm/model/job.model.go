@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job is a background job. Payload holds the parameters of its kind as JSON, and the failure
+// of its last attempt is flattened into the Failure columns.
+type Job struct {
+	ID             uuid.UUID         `gorm:"primaryKey"`
+	UserID         uuid.UUID         `gorm:"not null;index"`
+	Kind           string            `gorm:"not null;size:32"`
+	Status         string            `gorm:"not null;size:16;index:idx_jobs_status_run_at,priority:1"`
+	Payload        []byte            `gorm:"not null;type:blob"`
+	Progress       int               `gorm:"not null"`
+	Attempts       int               `gorm:"not null"`
+	MaxAttempts    int               `gorm:"not null"`
+	RunAt          time.Time         `gorm:"not null;index:idx_jobs_status_run_at,priority:2"`
+	LeaseExpiresAt *time.Time        `gorm:"null"`
+	ResultName     string            `gorm:"not null;size:255"`
+	ResultType     string            `gorm:"not null;size:255"`
+	FailureCode    string            `gorm:"not null;size:64"`
+	FailureMessage string            `gorm:"not null;size:1000"`
+	FailureParams  map[string]string `gorm:"null;type:json;serializer:json"`
+	Version        int64             `gorm:"not null;default:1"`
+	CreatedAt      time.Time         `gorm:"not null"`
+	UpdatedAt      time.Time         `gorm:"not null"`
+	StartedAt      *time.Time        `gorm:"null"`
+	FinishedAt     *time.Time        `gorm:"null;index"`
+}
+
+func (j *Job) TableName() string {
+	return "jobs"
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringTransaction is a transaction repeated on a schedule, its rule flattened into one column
+// per part. NextAt is null once the series has ended, so due series are found by index, and
+// PausedAt is null unless the series is paused.
+type RecurringTransaction struct {
+	ID              uuid.UUID   `gorm:"primaryKey"`
+	UserID          uuid.UUID   `gorm:"not null;index"`
+	CategoryID      uuid.UUID   `gorm:"not null"`
+	Amount          float64     `gorm:"not null"`
+	Description     string      `gorm:"not null"`
+	StartAt         time.Time   `gorm:"not null"`
+	Frequency       string      `gorm:"not null;size:16"`
+	IntervalCount   int         `gorm:"not null"`
+	DayOfMonth      int         `gorm:"not null"`
+	LastBusinessDay bool        `gorm:"not null"`
+	Until           *time.Time  `gorm:"null;type:date"`
+	MaxOccurrences  int         `gorm:"not null"` // 0 when unbounded
+	Skipped         []time.Time `gorm:"null;type:json;serializer:json"`
+	Materialized    int         `gorm:"not null"`
+	NextAt          *time.Time  `gorm:"null;index"`
+	PausedAt        *time.Time  `gorm:"null"`
+	PauseReason     string      `gorm:"not null;size:100"`
+	Version         int64       `gorm:"not null;default:1"`
+	CreatedAt       time.Time   `gorm:"not null"`
+	UpdatedAt       time.Time   `gorm:"not null"`
+}
+
+func (r *RecurringTransaction) TableName() string {
+	return "recurring_transactions"
+}
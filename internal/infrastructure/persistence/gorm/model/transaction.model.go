@@ -1,20 +1,26 @@
 package model
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Transaction struct {
 	ID          uuid.UUID `gorm:"primaryKey"`
 	CategoryID  uuid.UUID `gorm:"not null"`
-	UserID      uuid.UUID `gorm:"not null"`
+	UserID      uuid.UUID `gorm:"not null;uniqueIndex:idx_transactions_user_external_id,priority:1"`
 	Amount      float64   `gorm:"not null"`
 	Datetime    time.Time `gorm:"not null"`
 	Description string    `gorm:"not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	// ExternalID is NULL for transactions not imported with an id, so they stay out of the unique index
+	ExternalID sql.NullString `gorm:"size:300;uniqueIndex:idx_transactions_user_external_id,priority:2"`
+	Version    int64          `gorm:"not null;default:1"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
 
 func (t *Transaction) TableName() string {
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	gorm *gorm.DB
+}
+
+func NewAuditRepository(gorm *gorm.DB) repository.AuditRepositoryInterface {
+	return &AuditRepository{gorm: gorm}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *entity.AuditEntry) error {
+	changes := make([]model.AuditChange, len(entry.Changes()))
+	for i, change := range entry.Changes() {
+		changes[i] = model.AuditChange{Field: change.Field, Before: change.Before, After: change.After}
+	}
+
+	return db.Conn(ctx, r.gorm).Create(&model.AuditEntry{
+		ID:         entry.ID(),
+		UserID:     entry.UserID(),
+		Resource:   string(entry.Resource()),
+		ResourceID: entry.ResourceID(),
+		Action:     string(entry.Action()),
+		RequestID:  entry.RequestID(),
+		Changes:    changes,
+		OccurredAt: entry.OccurredAt(),
+	}).Error
+}
+
+func (r *AuditRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, paginate *pagination.Pagination) ([]entity.AuditEntry, error) {
+	var entries []model.AuditEntry
+	var totalItems int64
+
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ? AND resource = ? AND resource_id = ?", userID, resource, resourceID)
+
+	if err := conn.Model(&model.AuditEntry{}).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	if err := conn.Order("occurred_at, id").Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	entriesEntity := make([]entity.AuditEntry, len(entries))
+	for i, entry := range entries {
+		changes := make([]entity.AuditChange, len(entry.Changes))
+		for j, change := range entry.Changes {
+			changes[j] = entity.AuditChange{Field: change.Field, Before: change.Before, After: change.After}
+		}
+
+		entriesEntity[i] = *entity.RestoreAuditEntry(
+			entry.ID,
+			entry.UserID,
+			enum.AuditResource(entry.Resource),
+			entry.ResourceID,
+			enum.AuditAction(entry.Action),
+			entry.RequestID,
+			changes,
+			entry.OccurredAt,
+		)
+	}
+
+	return entriesEntity, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type CategoryRepository struct {
+	gorm *gorm.DB
+}
+
+func NewCategoryRepository(gorm *gorm.DB) repository.CategoryRepositoryInterface {
+	return &CategoryRepository{gorm: gorm}
+}
+
+func (r *CategoryRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Category, error) {
+	var categories []model.Category
+	var totalItems int64
+
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ?", userID)
+
+	if err := conn.Model(&model.Category{}).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	if err := conn.Order("name, id").Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	categoriesEntity := make([]entity.Category, len(categories))
+	for i, category := range categories {
+		categoryEntity, err := categoryFromModel(&category)
+		if err != nil {
+			return nil, err
+		}
+		categoriesEntity[i] = *categoryEntity
+	}
+
+	return categoriesEntity, nil
+}
+
+func (r *CategoryRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Category, error) {
+	var categories []model.Category
+	if err := db.Conn(ctx, r.gorm).Where("user_id = ?", userID).Order("name, id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	categoriesEntity := make([]entity.Category, len(categories))
+	for i, category := range categories {
+		categoryEntity, err := categoryFromModel(&category)
+		if err != nil {
+			return nil, err
+		}
+		categoriesEntity[i] = *categoryEntity
+	}
+
+	return categoriesEntity, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	return r.find(db.Conn(ctx, r.gorm), id)
+}
+
+func (r *CategoryRepository) FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	return r.find(db.Conn(ctx, r.gorm).Unscoped().Where("deleted_at IS NOT NULL"), id)
+}
+
+func (r *CategoryRepository) find(conn *gorm.DB, id uuid.UUID) (*entity.Category, error) {
+	var category model.Category
+	if err := conn.First(&category, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("category", id)
+		}
+		return nil, err
+	}
+
+	return categoryFromModel(&category)
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	categoryModel := categoryToModel(category)
+	if err := db.Conn(ctx, r.gorm).Create(&categoryModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainerror.NewConflict("category_already_exists", "category already exists", err)
+		}
+		return nil, err
+	}
+
+	return categoryFromModel(&categoryModel)
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	result := db.Conn(ctx, r.gorm).Unscoped().Model(&model.Category{}).
+		Where("id = ? AND version = ?", category.ID(), category.Version()).
+		Updates(map[string]any{
+			"name":       category.Name(),
+			"type":       string(category.Type()),
+			"icon":       category.Icon(),
+			"updated_at": category.UpdatedAt(),
+			"deleted_at": toDeletedAt(category.DeletedAt()),
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.staleWrite(ctx, category.ID())
+	}
+
+	updated := categoryToModel(category)
+	updated.Version++
+	return categoryFromModel(&updated)
+}
+
+func (r *CategoryRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	result := db.Conn(ctx, r.gorm).Unscoped().
+		Where("deleted_at < ?", before).
+		Delete(&model.Category{})
+	return result.RowsAffected, result.Error
+}
+
+// staleWrite explains why a conditional write matched no row: the category is either
+// gone or was changed by someone else since it was read
+func (r *CategoryRepository) staleWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).Unscoped().Model(&model.Category{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerror.NewNotFound("category", id)
+	}
+	return repository.VersionMismatch("category", id)
+}
+
+func categoryToModel(category *entity.Category) model.Category {
+	return model.Category{
+		ID:        category.ID(),
+		UserID:    category.UserID(),
+		Name:      category.Name(),
+		Type:      string(category.Type()),
+		IsDefault: category.Default(),
+		Icon:      category.Icon(),
+		Version:   category.Version(),
+		CreatedAt: category.CreatedAt(),
+		UpdatedAt: category.UpdatedAt(),
+		DeletedAt: toDeletedAt(category.DeletedAt()),
+	}
+}
+
+func categoryFromModel(category *model.Category) (*entity.Category, error) {
+	return entity.RestoreCategory(
+		category.ID,
+		category.UserID,
+		category.Name,
+		enum.CategoryType(category.Type),
+		category.IsDefault,
+		category.Icon,
+		category.Version,
+		category.CreatedAt,
+		category.UpdatedAt,
+		category.DeletedAt.Time,
+	)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type IdempotencyRepository struct {
+	gorm *gorm.DB
+}
+
+func NewIdempotencyRepository(gorm *gorm.DB) repository.IdempotencyRepositoryInterface {
+	return &IdempotencyRepository{gorm: gorm}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	conn := db.Conn(ctx, r.gorm)
+
+	// An expired record frees its key; the primary key on (user_id, key) settles concurrent reservations
+	err := conn.Where("user_id = ? AND `key` = ? AND expires_at <= ?", record.UserID(), record.Key(), record.CreatedAt()).
+		Delete(&model.IdempotencyKey{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := model.IdempotencyKey{
+		UserID:      record.UserID(),
+		Key:         record.Key(),
+		RequestHash: record.RequestHash(),
+		CreatedAt:   record.CreatedAt(),
+		ExpiresAt:   record.ExpiresAt(),
+	}
+	err = conn.Create(&reservation).Error
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, err
+	}
+
+	var existing model.IdempotencyKey
+	err = conn.Clauses(dbresolver.Write).
+		First(&existing, "user_id = ? AND `key` = ?", record.UserID(), record.Key()).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.RestoreIdempotencyRecord(
+		existing.UserID,
+		existing.Key,
+		existing.RequestHash,
+		existing.StatusCode,
+		existing.ContentType,
+		existing.Headers,
+		existing.ResponseBody,
+		existing.CreatedAt,
+		existing.ExpiresAt,
+	)
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	return db.Conn(ctx, r.gorm).Model(&model.IdempotencyKey{}).
+		Where("user_id = ? AND `key` = ?", record.UserID(), record.Key()).
+		Updates(&model.IdempotencyKey{
+			StatusCode:   record.StatusCode(),
+			ContentType:  record.ContentType(),
+			Headers:      record.Headers(),
+			ResponseBody: record.Body(),
+		}).Error
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	return db.Conn(ctx, r.gorm).
+		Where("user_id = ? AND `key` = ?", userID, key).
+		Delete(&model.IdempotencyKey{}).Error
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := db.Conn(ctx, r.gorm).Where("expires_at <= ?", now).Delete(&model.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImportProfileRepository struct {
+	gorm *gorm.DB
+}
+
+func NewImportProfileRepository(gorm *gorm.DB) repository.ImportProfileRepositoryInterface {
+	return &ImportProfileRepository{gorm: gorm}
+}
+
+func (r *ImportProfileRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.ImportProfile, error) {
+	var profiles []model.ImportProfile
+	var totalItems int64
+
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ?", userID)
+
+	if err := conn.Model(&model.ImportProfile{}).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	if err := conn.Order("name, id").Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+
+	profilesEntity := make([]entity.ImportProfile, len(profiles))
+	for i, profile := range profiles {
+		profileEntity, err := importProfileFromModel(&profile)
+		if err != nil {
+			return nil, err
+		}
+		profilesEntity[i] = *profileEntity
+	}
+
+	return profilesEntity, nil
+}
+
+func (r *ImportProfileRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error) {
+	var profile model.ImportProfile
+	if err := db.Conn(ctx, r.gorm).First(&profile, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("import_profile", id)
+		}
+		return nil, err
+	}
+
+	return importProfileFromModel(&profile)
+}
+
+func (r *ImportProfileRepository) Create(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error) {
+	profileModel := importProfileToModel(profile)
+	if err := db.Conn(ctx, r.gorm).Create(&profileModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, importProfileNameTaken(err)
+		}
+		return nil, err
+	}
+
+	return importProfileFromModel(&profileModel)
+}
+
+func (r *ImportProfileRepository) Update(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error) {
+	profileModel := importProfileToModel(profile)
+	result := db.Conn(ctx, r.gorm).Model(&model.ImportProfile{}).
+		Where("id = ?", profile.ID()).
+		Select("*").Omit("id", "user_id", "created_at").
+		Updates(&profileModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, importProfileNameTaken(result.Error)
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainerror.NewNotFound("import_profile", profile.ID())
+	}
+
+	return importProfileFromModel(&profileModel)
+}
+
+func (r *ImportProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.Conn(ctx, r.gorm).Delete(&model.ImportProfile{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerror.NewNotFound("import_profile", id)
+	}
+	return nil
+}
+
+func importProfileNameTaken(err error) error {
+	return domainerror.NewConflict("import_profile_name_taken", "an import profile with this name already exists", err)
+}
+
+func importProfileToModel(profile *entity.ImportProfile) model.ImportProfile {
+	settings := profile.Settings()
+	return model.ImportProfile{
+		ID:                profile.ID(),
+		UserID:            profile.UserID(),
+		Name:              profile.Name(),
+		Delimiter:         settings.Delimiter,
+		DecimalComma:      settings.DecimalComma,
+		DateFormat:        settings.DateFormat,
+		HasHeader:         settings.HasHeader,
+		NegateAmounts:     settings.NegateAmounts,
+		DateColumn:        settings.Columns.Date,
+		AmountColumn:      settings.Columns.Amount,
+		DescriptionColumn: settings.Columns.Description,
+		CategoryColumn:    settings.Columns.Category,
+		ExpenseCategoryID: settings.ExpenseCategoryID,
+		IncomeCategoryID:  settings.IncomeCategoryID,
+		CreatedAt:         profile.CreatedAt(),
+		UpdatedAt:         profile.UpdatedAt(),
+	}
+}
+
+func importProfileFromModel(profile *model.ImportProfile) (*entity.ImportProfile, error) {
+	return entity.RestoreImportProfile(
+		profile.ID,
+		profile.UserID,
+		profile.Name,
+		entity.ImportSettings{
+			Delimiter:     profile.Delimiter,
+			DecimalComma:  profile.DecimalComma,
+			DateFormat:    profile.DateFormat,
+			HasHeader:     profile.HasHeader,
+			NegateAmounts: profile.NegateAmounts,
+			Columns: entity.ImportColumns{
+				Date:        profile.DateColumn,
+				Amount:      profile.AmountColumn,
+				Description: profile.DescriptionColumn,
+				Category:    profile.CategoryColumn,
+			},
+			ExpenseCategoryID: profile.ExpenseCategoryID,
+			IncomeCategoryID:  profile.IncomeCategoryID,
+		},
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	)
+}
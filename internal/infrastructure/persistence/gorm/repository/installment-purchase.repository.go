@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type InstallmentPurchaseRepository struct {
+	gorm *gorm.DB
+}
+
+func NewInstallmentPurchaseRepository(gorm *gorm.DB) repository.InstallmentPurchaseRepositoryInterface {
+	return &InstallmentPurchaseRepository{gorm: gorm}
+}
+
+func (r *InstallmentPurchaseRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.InstallmentPurchase, error) {
+	var purchases []model.InstallmentPurchase
+	var totalItems int64
+
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ?", userID)
+
+	if err := conn.Model(&model.InstallmentPurchase{}).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	if err := conn.Order("created_at DESC, id DESC").Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&purchases).Error; err != nil {
+		return nil, err
+	}
+
+	purchasesEntity := make([]entity.InstallmentPurchase, len(purchases))
+	for i, purchase := range purchases {
+		purchaseEntity, err := installmentPurchaseFromModel(&purchase)
+		if err != nil {
+			return nil, err
+		}
+		purchasesEntity[i] = *purchaseEntity
+	}
+
+	return purchasesEntity, nil
+}
+
+func (r *InstallmentPurchaseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.InstallmentPurchase, error) {
+	var purchase model.InstallmentPurchase
+	if err := db.Conn(ctx, r.gorm).First(&purchase, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("installment_purchase", id)
+		}
+		return nil, err
+	}
+
+	return installmentPurchaseFromModel(&purchase)
+}
+
+func (r *InstallmentPurchaseRepository) Create(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error) {
+	purchaseModel := installmentPurchaseToModel(purchase)
+	if err := db.Conn(ctx, r.gorm).Create(&purchaseModel).Error; err != nil {
+		return nil, err
+	}
+
+	return installmentPurchaseFromModel(&purchaseModel)
+}
+
+func (r *InstallmentPurchaseRepository) Update(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error) {
+	purchaseModel := installmentPurchaseToModel(purchase)
+	// a map update skips the serializer of the column
+	installments, err := json.Marshal(purchaseModel.Installments)
+	if err != nil {
+		return nil, err
+	}
+
+	result := db.Conn(ctx, r.gorm).Model(&model.InstallmentPurchase{}).
+		Where("id = ? AND version = ?", purchase.ID(), purchase.Version()).
+		Updates(map[string]any{
+			"category_id":  purchaseModel.CategoryID,
+			"description":  purchaseModel.Description,
+			"total_amount": purchaseModel.TotalAmount,
+			"installments": string(installments),
+			"updated_at":   purchaseModel.UpdatedAt,
+			"cancelled_at": purchaseModel.CancelledAt,
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.staleWrite(ctx, purchase.ID())
+	}
+
+	purchaseModel.Version++
+	return installmentPurchaseFromModel(&purchaseModel)
+}
+
+// staleWrite explains why a conditional write matched no row: the purchase is either gone or was
+// changed by someone else since it was read
+func (r *InstallmentPurchaseRepository) staleWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).Model(&model.InstallmentPurchase{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerror.NewNotFound("installment_purchase", id)
+	}
+	return repository.VersionMismatch("installment_purchase", id)
+}
+
+func installmentPurchaseToModel(purchase *entity.InstallmentPurchase) model.InstallmentPurchase {
+	installments := purchase.Installments()
+	installmentModels := make([]model.Installment, len(installments))
+	for i, installment := range installments {
+		installmentModels[i] = model.Installment{
+			Number:        installment.Number,
+			TransactionID: installment.TransactionID,
+			DueAt:         installment.DueAt,
+			Amount:        installment.Amount,
+			Cancelled:     installment.Cancelled,
+		}
+	}
+
+	return model.InstallmentPurchase{
+		ID:           purchase.ID(),
+		UserID:       purchase.UserID(),
+		CategoryID:   purchase.CategoryID(),
+		Description:  purchase.Description(),
+		TotalAmount:  purchase.TotalAmount(),
+		Remainder:    string(purchase.Remainder()),
+		Installments: installmentModels,
+		Version:      purchase.Version(),
+		CreatedAt:    purchase.CreatedAt(),
+		UpdatedAt:    purchase.UpdatedAt(),
+		CancelledAt:  optionalTime(purchase.CancelledAt()),
+	}
+}
+
+func installmentPurchaseFromModel(purchase *model.InstallmentPurchase) (*entity.InstallmentPurchase, error) {
+	installments := make([]entity.Installment, len(purchase.Installments))
+	for i, installment := range purchase.Installments {
+		installments[i] = entity.Installment{
+			Number:        installment.Number,
+			TransactionID: installment.TransactionID,
+			DueAt:         installment.DueAt,
+			Amount:        installment.Amount,
+			Cancelled:     installment.Cancelled,
+		}
+	}
+
+	return entity.RestoreInstallmentPurchase(
+		purchase.ID,
+		purchase.UserID,
+		purchase.CategoryID,
+		purchase.Description,
+		purchase.TotalAmount,
+		enum.InstallmentRemainder(purchase.Remainder),
+		installments,
+		purchase.Version,
+		purchase.CreatedAt,
+		purchase.UpdatedAt,
+		timeOrZero(purchase.CancelledAt),
+	)
+}
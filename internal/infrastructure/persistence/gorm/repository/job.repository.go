@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type JobRepository struct {
+	gorm *gorm.DB
+}
+
+func NewJobRepository(gorm *gorm.DB) repository.JobRepositoryInterface {
+	return &JobRepository{gorm: gorm}
+}
+
+func (r *JobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	var job model.Job
+	if err := db.Conn(ctx, r.gorm).First(&job, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("job", id)
+		}
+		return nil, err
+	}
+
+	return jobFromModel(&job)
+}
+
+// FindNextDue reads from the primary, as a replica lagging behind would hand out jobs that
+// were already claimed
+func (r *JobRepository) FindNextDue(ctx context.Context, now time.Time) (*entity.Job, error) {
+	var jobs []model.Job
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND lease_expires_at < ?)",
+			enum.JobStatusQueued, now, enum.JobStatusRunning, now).
+		Order("run_at, id").
+		Limit(1).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return jobFromModel(&jobs[0])
+}
+
+func (r *JobRepository) FindFinishedBefore(ctx context.Context, before time.Time, limit int) ([]entity.Job, error) {
+	var jobs []model.Job
+	err := db.Conn(ctx, r.gorm).
+		Where("finished_at < ?", before).
+		Order("finished_at, id").
+		Limit(limit).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	jobsEntity := make([]entity.Job, len(jobs))
+	for i, job := range jobs {
+		jobEntity, err := jobFromModel(&job)
+		if err != nil {
+			return nil, err
+		}
+		jobsEntity[i] = *jobEntity
+	}
+
+	return jobsEntity, nil
+}
+
+func (r *JobRepository) Create(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	jobModel := jobToModel(job)
+	if err := db.Conn(ctx, r.gorm).Create(&jobModel).Error; err != nil {
+		return nil, err
+	}
+
+	return jobFromModel(&jobModel)
+}
+
+func (r *JobRepository) Update(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	jobModel := jobToModel(job)
+	result := db.Conn(ctx, r.gorm).Model(&model.Job{}).
+		Where("id = ? AND version = ?", job.ID(), job.Version()).
+		Updates(map[string]any{
+			"status":           jobModel.Status,
+			"progress":         jobModel.Progress,
+			"attempts":         jobModel.Attempts,
+			"run_at":           jobModel.RunAt,
+			"lease_expires_at": jobModel.LeaseExpiresAt,
+			"result_name":      jobModel.ResultName,
+			"result_type":      jobModel.ResultType,
+			"failure_code":     jobModel.FailureCode,
+			"failure_message":  jobModel.FailureMessage,
+			"failure_params":   failureParams(jobModel.FailureParams),
+			"updated_at":       jobModel.UpdatedAt,
+			"started_at":       jobModel.StartedAt,
+			"finished_at":      jobModel.FinishedAt,
+			"version":          gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.staleWrite(ctx, job.ID())
+	}
+
+	jobModel.Version++
+	return jobFromModel(&jobModel)
+}
+
+func (r *JobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.Conn(ctx, r.gorm).Delete(&model.Job{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerror.NewNotFound("job", id)
+	}
+	return nil
+}
+
+// staleWrite explains why a conditional write matched no row: the job is either gone or was
+// changed by someone else since it was read
+func (r *JobRepository) staleWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).Model(&model.Job{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerror.NewNotFound("job", id)
+	}
+	return repository.VersionMismatch("job", id)
+}
+
+func jobToModel(job *entity.Job) model.Job {
+	result := job.Result()
+	failure := job.Failure()
+	return model.Job{
+		ID:             job.ID(),
+		UserID:         job.UserID(),
+		Kind:           string(job.Kind()),
+		Status:         string(job.Status()),
+		Payload:        job.Payload(),
+		Progress:       job.Progress(),
+		Attempts:       job.Attempts(),
+		MaxAttempts:    job.MaxAttempts(),
+		RunAt:          job.RunAt(),
+		LeaseExpiresAt: optionalTime(job.LeaseExpiresAt()),
+		ResultName:     result.Name,
+		ResultType:     result.ContentType,
+		FailureCode:    failure.Code,
+		FailureMessage: failure.Message,
+		FailureParams:  failure.Params,
+		Version:        job.Version(),
+		CreatedAt:      job.CreatedAt(),
+		UpdatedAt:      job.UpdatedAt(),
+		StartedAt:      optionalTime(job.StartedAt()),
+		FinishedAt:     optionalTime(job.FinishedAt()),
+	}
+}
+
+func jobFromModel(job *model.Job) (*entity.Job, error) {
+	return entity.RestoreJob(
+		job.ID,
+		job.UserID,
+		enum.JobKind(job.Kind),
+		enum.JobStatus(job.Status),
+		job.Payload,
+		job.Progress,
+		job.Attempts,
+		job.MaxAttempts,
+		job.RunAt,
+		timeOrZero(job.LeaseExpiresAt),
+		entity.JobResult{Name: job.ResultName, ContentType: job.ResultType},
+		entity.JobFailure{Code: job.FailureCode, Message: job.FailureMessage, Params: job.FailureParams},
+		job.Version,
+		job.CreatedAt,
+		job.UpdatedAt,
+		timeOrZero(job.StartedAt),
+		timeOrZero(job.FinishedAt),
+	)
+}
+
+// failureParams encodes params for a map update, which skips the serializer of the column
+func failureParams(params map[string]string) any {
+	if len(params) == 0 {
+		return nil
+	}
+	encoded, _ := json.Marshal(params)
+	return string(encoded)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
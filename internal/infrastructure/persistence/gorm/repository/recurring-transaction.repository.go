@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type RecurringTransactionRepository struct {
+	gorm *gorm.DB
+}
+
+func NewRecurringTransactionRepository(gorm *gorm.DB) repository.RecurringTransactionRepositoryInterface {
+	return &RecurringTransactionRepository{gorm: gorm}
+}
+
+func (r *RecurringTransactionRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.RecurringTransaction, error) {
+	var recurrings []model.RecurringTransaction
+	var totalItems int64
+
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ?", userID)
+
+	if err := conn.Model(&model.RecurringTransaction{}).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	if err := conn.Order("created_at DESC, id DESC").Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&recurrings).Error; err != nil {
+		return nil, err
+	}
+
+	return recurringTransactionsFromModels(recurrings)
+}
+
+func (r *RecurringTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.RecurringTransaction, error) {
+	var recurring model.RecurringTransaction
+	if err := db.Conn(ctx, r.gorm).First(&recurring, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("recurring_transaction", id)
+		}
+		return nil, err
+	}
+
+	return recurringTransactionFromModel(&recurring)
+}
+
+// FindDue reads from the primary, as a replica lagging behind would hand out series whose
+// occurrences were already recorded. Paused series are left out.
+func (r *RecurringTransactionRepository) FindDue(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]entity.RecurringTransaction, error) {
+	var recurrings []model.RecurringTransaction
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).
+		Where("next_at <= ? AND id > ? AND paused_at IS NULL", now, after).
+		Order("id").
+		Limit(limit).
+		Find(&recurrings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return recurringTransactionsFromModels(recurrings)
+}
+
+func (r *RecurringTransactionRepository) Create(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error) {
+	recurringModel := recurringTransactionToModel(recurring)
+	if err := db.Conn(ctx, r.gorm).Create(&recurringModel).Error; err != nil {
+		return nil, err
+	}
+
+	return recurringTransactionFromModel(&recurringModel)
+}
+
+func (r *RecurringTransactionRepository) Update(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error) {
+	recurringModel := recurringTransactionToModel(recurring)
+	result := db.Conn(ctx, r.gorm).Model(&model.RecurringTransaction{}).
+		Where("id = ? AND version = ?", recurring.ID(), recurring.Version()).
+		Updates(map[string]any{
+			"category_id":       recurringModel.CategoryID,
+			"amount":            recurringModel.Amount,
+			"description":       recurringModel.Description,
+			"frequency":         recurringModel.Frequency,
+			"interval_count":    recurringModel.IntervalCount,
+			"day_of_month":      recurringModel.DayOfMonth,
+			"last_business_day": recurringModel.LastBusinessDay,
+			"until":             recurringModel.Until,
+			"max_occurrences":   recurringModel.MaxOccurrences,
+			"skipped":           skippedDates(recurringModel.Skipped),
+			"materialized":      recurringModel.Materialized,
+			"next_at":           recurringModel.NextAt,
+			"paused_at":         recurringModel.PausedAt,
+			"pause_reason":      recurringModel.PauseReason,
+			"updated_at":        recurringModel.UpdatedAt,
+			"version":           gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.staleWrite(ctx, recurring.ID())
+	}
+
+	recurringModel.Version++
+	return recurringTransactionFromModel(&recurringModel)
+}
+
+func (r *RecurringTransactionRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	result := db.Conn(ctx, r.gorm).Delete(&model.RecurringTransaction{}, "id = ? AND version = ?", id, version)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.staleWrite(ctx, id)
+	}
+	return nil
+}
+
+// staleWrite explains why a conditional write matched no row: the series is either gone or was
+// changed by someone else since it was read
+func (r *RecurringTransactionRepository) staleWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).Model(&model.RecurringTransaction{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerror.NewNotFound("recurring_transaction", id)
+	}
+	return repository.VersionMismatch("recurring_transaction", id)
+}
+
+func recurringTransactionToModel(recurring *entity.RecurringTransaction) model.RecurringTransaction {
+	rule := recurring.Rule()
+	return model.RecurringTransaction{
+		ID:              recurring.ID(),
+		UserID:          recurring.UserID(),
+		CategoryID:      recurring.CategoryID(),
+		Amount:          recurring.Amount(),
+		Description:     recurring.Description(),
+		StartAt:         recurring.StartAt(),
+		Frequency:       string(rule.Frequency),
+		IntervalCount:   rule.Interval,
+		DayOfMonth:      rule.DayOfMonth,
+		LastBusinessDay: rule.LastBusinessDay,
+		Until:           optionalTime(rule.Until),
+		MaxOccurrences:  rule.Count,
+		Skipped:         recurring.Skipped(),
+		Materialized:    recurring.Materialized(),
+		NextAt:          optionalTime(recurring.NextAt()),
+		PausedAt:        optionalTime(recurring.PausedAt()),
+		PauseReason:     recurring.PauseReason(),
+		Version:         recurring.Version(),
+		CreatedAt:       recurring.CreatedAt(),
+		UpdatedAt:       recurring.UpdatedAt(),
+	}
+}
+
+func recurringTransactionFromModel(recurring *model.RecurringTransaction) (*entity.RecurringTransaction, error) {
+	return entity.RestoreRecurringTransaction(
+		recurring.ID,
+		recurring.UserID,
+		recurring.CategoryID,
+		recurring.Amount,
+		recurring.Description,
+		recurring.StartAt,
+		entity.RecurrenceRule{
+			Frequency:       enum.RecurrenceFrequency(recurring.Frequency),
+			Interval:        recurring.IntervalCount,
+			DayOfMonth:      recurring.DayOfMonth,
+			LastBusinessDay: recurring.LastBusinessDay,
+			Until:           timeOrZero(recurring.Until),
+			Count:           recurring.MaxOccurrences,
+		},
+		recurring.Skipped,
+		recurring.Materialized,
+		timeOrZero(recurring.PausedAt),
+		recurring.PauseReason,
+		recurring.Version,
+		recurring.CreatedAt,
+		recurring.UpdatedAt,
+	)
+}
+
+func recurringTransactionsFromModels(recurrings []model.RecurringTransaction) ([]entity.RecurringTransaction, error) {
+	recurringsEntity := make([]entity.RecurringTransaction, len(recurrings))
+	for i, recurring := range recurrings {
+		recurringEntity, err := recurringTransactionFromModel(&recurring)
+		if err != nil {
+			return nil, err
+		}
+		recurringsEntity[i] = *recurringEntity
+	}
+
+	return recurringsEntity, nil
+}
+
+// skippedDates encodes skipped for a map update, which skips the serializer of the column
+func skippedDates(skipped []time.Time) any {
+	if len(skipped) == 0 {
+		return nil
+	}
+	encoded, _ := json.Marshal(skipped)
+	return string(encoded)
+}
@@ -1,13 +1,26 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
 	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/model"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// createBatchSize is how many rows CreateBatch inserts per statement
+const createBatchSize = 100
+
 type TransactionRepository struct {
 	gorm *gorm.DB
 }
@@ -16,33 +29,55 @@ func NewTransactionRepository(gorm *gorm.DB) repository.TransactionRepositoryInt
 	return &TransactionRepository{gorm: gorm}
 }
 
-func (r *TransactionRepository) FindAllPaginated(paginate *pagination.Pagination) ([]entity.Transaction, error) {
+func (r *TransactionRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Transaction, error) {
 	var transactions []model.Transaction
 	var totalItems int64
 
-	if err := r.gorm.Model(&model.Transaction{}).Count(&totalItems).Error; err != nil {
+	conn := db.Conn(ctx, r.gorm).Where("user_id = ?", userID)
+
+	if err := conn.Model(&model.Transaction{}).Count(&totalItems).Error; err != nil {
 		return nil, err
 	}
 
 	paginate.SetTotal(totalItems)
 
-	if err := r.gorm.Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&transactions).Error; err != nil {
+	if err := conn.Offset(paginate.GetOffset()).Limit(paginate.GetLimit()).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	transactionsEntity := make([]entity.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		transactionEntity, err := transactionFromModel(&transaction)
+		if err != nil {
+			return nil, err
+		}
+		transactionsEntity[i] = *transactionEntity
+	}
+
+	return transactionsEntity, nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return r.find(db.Conn(ctx, r.gorm), id)
+}
+
+func (r *TransactionRepository) FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return r.find(db.Conn(ctx, r.gorm).Unscoped().Where("deleted_at IS NOT NULL"), id)
+}
+
+func (r *TransactionRepository) FindAllBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.Transaction, error) {
+	var transactions []model.Transaction
+	err := db.Conn(ctx, r.gorm).
+		Where("user_id = ? AND datetime >= ? AND datetime < ?", userID, from, to).
+		Order("datetime, id").
+		Find(&transactions).Error
+	if err != nil {
 		return nil, err
 	}
 
 	transactionsEntity := make([]entity.Transaction, len(transactions))
 	for i, transaction := range transactions {
-		var err error
-		transactionEntity, err := entity.NewTransaction(
-			transaction.ID,
-			transaction.CategoryID,
-			transaction.UserID,
-			transaction.Amount,
-			transaction.Datetime,
-			transaction.Description,
-			transaction.CreatedAt,
-			transaction.UpdatedAt,
-		)
+		transactionEntity, err := transactionFromModel(&transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -52,35 +87,204 @@ func (r *TransactionRepository) FindAllPaginated(paginate *pagination.Pagination
 	return transactionsEntity, nil
 }
 
-func (r *TransactionRepository) Create(transaction *entity.Transaction) (*entity.Transaction, error) {
-	transactionModel := model.Transaction{
+func (r *TransactionRepository) Stream(ctx context.Context, userID uuid.UUID, fn func(transaction *entity.Transaction) error) error {
+	conn := db.Conn(ctx, r.gorm)
+	rows, err := conn.Model(&model.Transaction{}).
+		Where("user_id = ?", userID).
+		Order("datetime, id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction model.Transaction
+		if err := conn.ScanRows(rows, &transaction); err != nil {
+			return err
+		}
+		transactionEntity, err := transactionFromModel(&transaction)
+		if err != nil {
+			return err
+		}
+		if err := fn(transactionEntity); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *TransactionRepository) FindExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) ([]string, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+
+	var found []string
+	err := db.Conn(ctx, r.gorm).Unscoped().Model(&model.Transaction{}).
+		Where("user_id = ? AND external_id IN ?", userID, externalIDs).
+		Pluck("external_id", &found).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func (r *TransactionRepository) find(conn *gorm.DB, id uuid.UUID) (*entity.Transaction, error) {
+	var transaction model.Transaction
+	if err := conn.First(&transaction, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerror.NewNotFound("transaction", id)
+		}
+		return nil, err
+	}
+
+	return transactionFromModel(&transaction)
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	transactionModel := transactionToModel(transaction)
+	if err := db.Conn(ctx, r.gorm).Create(&transactionModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainerror.NewConflict("transaction_already_exists", "transaction already exists", err)
+		}
+		return nil, err
+	}
+
+	return transactionFromModel(&transactionModel)
+}
+
+func (r *TransactionRepository) CreateBatch(ctx context.Context, transactions []*entity.Transaction) error {
+	transactionModels := make([]model.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		transactionModels[i] = transactionToModel(transaction)
+	}
+
+	if err := db.Conn(ctx, r.gorm).CreateInBatches(transactionModels, createBatchSize).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domainerror.NewConflict("transaction_already_exists", "transaction already exists", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) Update(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	result := db.Conn(ctx, r.gorm).Unscoped().Model(&model.Transaction{}).
+		Where("id = ? AND version = ?", transaction.ID(), transaction.Version()).
+		Updates(map[string]any{
+			"category_id": transaction.CategoryID(),
+			"amount":      transaction.Amount(),
+			"datetime":    transaction.Datetime(),
+			"description": transaction.Description(),
+			"updated_at":  transaction.UpdatedAt(),
+			"deleted_at":  toDeletedAt(transaction.DeletedAt()),
+			"version":     gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.staleWrite(ctx, transaction.ID())
+	}
+
+	updated := transactionToModel(transaction)
+	updated.Version++
+	return transactionFromModel(&updated)
+}
+
+func (r *TransactionRepository) TrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time) ([]uuid.UUID, error) {
+	conn := db.Conn(ctx, r.gorm)
+	return r.updateMatching(conn, conn.Where("category_id = ?", categoryID), map[string]any{
+		"updated_at": deletedAt,
+		"deleted_at": toDeletedAt(deletedAt),
+	})
+}
+
+func (r *TransactionRepository) UntrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time, updatedAt time.Time) ([]uuid.UUID, error) {
+	conn := db.Conn(ctx, r.gorm)
+	return r.updateMatching(conn, conn.Unscoped().Where("category_id = ? AND deleted_at = ?", categoryID, deletedAt), map[string]any{
+		"updated_at": updatedAt,
+		"deleted_at": nil,
+	})
+}
+
+// updateMatching locks the transactions selected by scope, applies changes to them with a
+// version bump and returns their ids
+func (r *TransactionRepository) updateMatching(conn *gorm.DB, scope *gorm.DB, changes map[string]any) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := scope.Clauses(dbresolver.Write, clause.Locking{Strength: "UPDATE"}).
+		Model(&model.Transaction{}).
+		Order("created_at, id").
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	changes["version"] = gorm.Expr("version + 1")
+	err = conn.Unscoped().Model(&model.Transaction{}).
+		Where("id IN ?", ids).
+		Updates(changes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *TransactionRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	result := db.Conn(ctx, r.gorm).Unscoped().
+		Where("deleted_at < ?", before).
+		Delete(&model.Transaction{})
+	return result.RowsAffected, result.Error
+}
+
+// staleWrite explains why a conditional write matched no row: the transaction is either
+// gone or was changed by someone else since it was read
+func (r *TransactionRepository) staleWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := db.Conn(ctx, r.gorm).Clauses(dbresolver.Write).Unscoped().Model(&model.Transaction{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domainerror.NewNotFound("transaction", id)
+	}
+	return repository.VersionMismatch("transaction", id)
+}
+
+func transactionToModel(transaction *entity.Transaction) model.Transaction {
+	return model.Transaction{
 		ID:          transaction.ID(),
 		CategoryID:  transaction.CategoryID(),
 		UserID:      transaction.UserID(),
 		Amount:      transaction.Amount(),
 		Datetime:    transaction.Datetime(),
 		Description: transaction.Description(),
+		ExternalID:  sql.NullString{String: transaction.ExternalID(), Valid: transaction.ExternalID() != ""},
+		Version:     transaction.Version(),
 		CreatedAt:   transaction.CreatedAt(),
 		UpdatedAt:   transaction.UpdatedAt(),
+		DeletedAt:   toDeletedAt(transaction.DeletedAt()),
 	}
-	if err := r.gorm.Create(&transactionModel).Error; err != nil {
-		return nil, err
-	}
+}
 
-	transactionEntity, err := entity.NewTransaction(
-		transactionModel.ID,
-		transactionModel.CategoryID,
-		transactionModel.UserID,
-		transactionModel.Amount,
-		transactionModel.Datetime,
-		transactionModel.Description,
-		transactionModel.CreatedAt,
-		transactionModel.UpdatedAt,
+func transactionFromModel(transaction *model.Transaction) (*entity.Transaction, error) {
+	return entity.RestoreTransaction(
+		transaction.ID,
+		transaction.CategoryID,
+		transaction.UserID,
+		transaction.Amount,
+		transaction.Datetime,
+		transaction.Description,
+		transaction.ExternalID.String,
+		transaction.Version,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+		transaction.DeletedAt.Time,
 	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return transactionEntity, nil
 }
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/persistence/gorm/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// trashedRecords lists the trashed rows of both tables in the shape of a trash item
+const trashedRecords = `
+	SELECT 'transaction' AS kind, id, user_id, description AS name, deleted_at
+	FROM transactions WHERE user_id = @user AND deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'category' AS kind, id, user_id, name, deleted_at
+	FROM categories WHERE user_id = @user AND deleted_at IS NOT NULL`
+
+type trashedRecord struct {
+	Kind      string
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	DeletedAt time.Time
+}
+
+type TrashRepository struct {
+	gorm *gorm.DB
+}
+
+func NewTrashRepository(gorm *gorm.DB) repository.TrashRepositoryInterface {
+	return &TrashRepository{gorm: gorm}
+}
+
+func (r *TrashRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.TrashItem, error) {
+	var totalItems int64
+	var records []trashedRecord
+
+	conn := db.Conn(ctx, r.gorm)
+	user := map[string]any{"user": userID}
+
+	if err := conn.Raw("SELECT COUNT(*) FROM ("+trashedRecords+") AS trash", user).Scan(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	paginate.SetTotal(totalItems)
+
+	err := conn.Raw(trashedRecords+" ORDER BY deleted_at DESC, id LIMIT @limit OFFSET @offset", map[string]any{
+		"user":   userID,
+		"limit":  paginate.GetLimit(),
+		"offset": paginate.GetOffset(),
+	}).Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]entity.TrashItem, len(records))
+	for i, record := range records {
+		items[i] = *entity.RestoreTrashItem(enum.TrashItemKind(record.Kind), record.ID, record.UserID, record.Name, record.DeletedAt)
+	}
+	return items, nil
+}
+
+// toDeletedAt maps the trash state of an entity, where a zero time means not trashed, to the
+// soft delete column
+func toDeletedAt(deletedAt time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: deletedAt, Valid: !deletedAt.IsZero()}
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type AuditRepository struct {
+	store *Store
+}
+
+func NewAuditRepository(store *Store) repository.AuditRepositoryInterface {
+	return &AuditRepository{store: store}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *entity.AuditEntry) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		data.auditEntries = append(data.auditEntries, *entry)
+		return nil
+	})
+}
+
+func (r *AuditRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, resource enum.AuditResource, resourceID uuid.UUID, paginate *pagination.Pagination) ([]entity.AuditEntry, error) {
+	var entries []entity.AuditEntry
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var matching []entity.AuditEntry
+		for _, entry := range data.auditEntries {
+			if entry.UserID() == userID && entry.Resource() == resource && entry.ResourceID() == resourceID {
+				matching = append(matching, entry)
+			}
+		}
+
+		paginate.SetTotal(int64(len(matching)))
+
+		start := min(paginate.GetOffset(), len(matching))
+		end := min(start+paginate.GetLimit(), len(matching))
+
+		entries = append([]entity.AuditEntry(nil), matching[start:end]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
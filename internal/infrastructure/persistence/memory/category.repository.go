@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type CategoryRepository struct {
+	store *Store
+}
+
+func NewCategoryRepository(store *Store) repository.CategoryRepositoryInterface {
+	return &CategoryRepository{store: store}
+}
+
+func (r *CategoryRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Category, error) {
+	var categories []entity.Category
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		owned := data.ownedCategories(userID)
+
+		paginate.SetTotal(int64(len(owned)))
+
+		start := min(paginate.GetOffset(), len(owned))
+		end := min(start+paginate.GetLimit(), len(owned))
+		categories = owned[start:end]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Category, error) {
+	var categories []entity.Category
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		categories = data.ownedCategories(userID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	return r.find(ctx, id, false)
+}
+
+func (r *CategoryRepository) FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	return r.find(ctx, id, true)
+}
+
+// find loads the category with the given id, provided its trash state is the one asked for
+func (r *CategoryRepository) find(ctx context.Context, id uuid.UUID, trashed bool) (*entity.Category, error) {
+	var category entity.Category
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.categories[id]
+		if !ok || stored.Trashed() != trashed {
+			return domainerror.NewNotFound("category", id)
+		}
+		category = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		if _, exists := data.categories[category.ID()]; exists {
+			return domainerror.NewConflict("category_already_exists", "category already exists", nil)
+		}
+		data.categories[category.ID()] = *category
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *category
+	return &created, nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	var updated *entity.Category
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.categories[category.ID()]
+		if !ok {
+			return domainerror.NewNotFound("category", category.ID())
+		}
+		if stored.Version() != category.Version() {
+			return repository.VersionMismatch("category", category.ID())
+		}
+
+		var err error
+		updated, err = entity.RestoreCategory(
+			category.ID(),
+			category.UserID(),
+			category.Name(),
+			category.Type(),
+			category.Default(),
+			category.Icon(),
+			category.Version()+1,
+			category.CreatedAt(),
+			category.UpdatedAt(),
+			category.DeletedAt(),
+		)
+		if err != nil {
+			return err
+		}
+
+		data.categories[updated.ID()] = *updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *CategoryRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		for id, category := range data.categories {
+			if category.Trashed() && category.DeletedAt().Before(before) {
+				delete(data.categories, id)
+				purged++
+			}
+		}
+		return nil
+	})
+
+	return purged, err
+}
+
+// ownedCategories returns the categories of the user that are not trashed, ordered by name
+func (s *snapshot) ownedCategories(userID uuid.UUID) []entity.Category {
+	var owned []entity.Category
+	for _, category := range s.categories {
+		if category.UserID() == userID && !category.Trashed() {
+			owned = append(owned, category)
+		}
+	}
+	slices.SortFunc(owned, func(a, b entity.Category) int {
+		return cmp.Or(cmp.Compare(a.Name(), b.Name()), cmp.Compare(a.ID().String(), b.ID().String()))
+	})
+	return owned
+}
+
+// Save stores category as is, used to seed fixtures
+func (r *CategoryRepository) Save(ctx context.Context, category *entity.Category) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		data.categories[category.ID()] = *category
+		return nil
+	})
+}
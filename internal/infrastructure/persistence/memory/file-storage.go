@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+)
+
+// FileStorage keeps files in process memory, used by tests and local fixtures
+type FileStorage struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+func NewFileStorage() interfaces.FileStorageInterface {
+	return &FileStorage{files: make(map[string][]byte)}
+}
+
+func (s *FileStorage) Create(_ context.Context, key string) (io.WriteCloser, error) {
+	return &memoryFile{storage: s, key: key}, nil
+}
+
+func (s *FileStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, ok := s.files[key]
+	if !ok {
+		return nil, domainerror.NewNotFound("file", key)
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (s *FileStorage) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.files, key)
+	return nil
+}
+
+// memoryFile is a file being written, stored when closed
+type memoryFile struct {
+	bytes.Buffer
+	storage *FileStorage
+	key     string
+}
+
+func (f *memoryFile) Close() error {
+	f.storage.mu.Lock()
+	defer f.storage.mu.Unlock()
+
+	f.storage.files[f.key] = bytes.Clone(f.Bytes())
+	return nil
+}
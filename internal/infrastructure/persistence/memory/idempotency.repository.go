@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type idempotencyID struct {
+	userID uuid.UUID
+	key    string
+}
+
+type IdempotencyRepository struct {
+	store *Store
+}
+
+func NewIdempotencyRepository(store *Store) repository.IdempotencyRepositoryInterface {
+	return &IdempotencyRepository{store: store}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+	var existing *entity.IdempotencyRecord
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		id := idempotencyID{userID: record.UserID(), key: record.Key()}
+		if stored, ok := data.idempotencyRecords[id]; ok && !stored.Expired(record.CreatedAt()) {
+			existing = &stored
+			return nil
+		}
+		data.idempotencyRecords[id] = *record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		data.idempotencyRecords[idempotencyID{userID: record.UserID(), key: record.Key()}] = *record
+		return nil
+	})
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		delete(data.idempotencyRecords, idempotencyID{userID: userID, key: key})
+		return nil
+	})
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		for id, record := range data.idempotencyRecords {
+			if record.Expired(now) {
+				delete(data.idempotencyRecords, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+
+	return deleted, err
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type ImportProfileRepository struct {
+	store *Store
+}
+
+func NewImportProfileRepository(store *Store) repository.ImportProfileRepositoryInterface {
+	return &ImportProfileRepository{store: store}
+}
+
+func (r *ImportProfileRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.ImportProfile, error) {
+	var profiles []entity.ImportProfile
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var owned []entity.ImportProfile
+		for _, profile := range data.importProfiles {
+			if profile.UserID() == userID {
+				owned = append(owned, profile)
+			}
+		}
+		slices.SortFunc(owned, func(a, b entity.ImportProfile) int {
+			return cmp.Or(cmp.Compare(a.Name(), b.Name()), cmp.Compare(a.ID().String(), b.ID().String()))
+		})
+
+		paginate.SetTotal(int64(len(owned)))
+
+		start := min(paginate.GetOffset(), len(owned))
+		end := min(start+paginate.GetLimit(), len(owned))
+		profiles = owned[start:end]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func (r *ImportProfileRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error) {
+	var profile entity.ImportProfile
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.importProfiles[id]
+		if !ok {
+			return domainerror.NewNotFound("import_profile", id)
+		}
+		profile = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *ImportProfileRepository) Create(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		if data.importProfileNameTaken(profile) {
+			return domainerror.NewConflict("import_profile_name_taken", "an import profile with this name already exists", nil)
+		}
+		data.importProfiles[profile.ID()] = *profile
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *profile
+	return &created, nil
+}
+
+func (r *ImportProfileRepository) Update(ctx context.Context, profile *entity.ImportProfile) (*entity.ImportProfile, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		if _, ok := data.importProfiles[profile.ID()]; !ok {
+			return domainerror.NewNotFound("import_profile", profile.ID())
+		}
+		if data.importProfileNameTaken(profile) {
+			return domainerror.NewConflict("import_profile_name_taken", "an import profile with this name already exists", nil)
+		}
+		data.importProfiles[profile.ID()] = *profile
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *profile
+	return &updated, nil
+}
+
+func (r *ImportProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		if _, ok := data.importProfiles[id]; !ok {
+			return domainerror.NewNotFound("import_profile", id)
+		}
+		delete(data.importProfiles, id)
+		return nil
+	})
+}
+
+// importProfileNameTaken reports whether another profile of the same user has the name of profile
+func (s *snapshot) importProfileNameTaken(profile *entity.ImportProfile) bool {
+	for id, stored := range s.importProfiles {
+		if id != profile.ID() && stored.UserID() == profile.UserID() && stored.Name() == profile.Name() {
+			return true
+		}
+	}
+	return false
+}
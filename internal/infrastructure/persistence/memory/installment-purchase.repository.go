@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type InstallmentPurchaseRepository struct {
+	store *Store
+}
+
+func NewInstallmentPurchaseRepository(store *Store) repository.InstallmentPurchaseRepositoryInterface {
+	return &InstallmentPurchaseRepository{store: store}
+}
+
+func (r *InstallmentPurchaseRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.InstallmentPurchase, error) {
+	var purchases []entity.InstallmentPurchase
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var owned []entity.InstallmentPurchase
+		for _, purchase := range data.purchases {
+			if purchase.UserID() == userID {
+				owned = append(owned, purchase)
+			}
+		}
+		slices.SortFunc(owned, func(a, b entity.InstallmentPurchase) int {
+			return cmp.Or(b.CreatedAt().Compare(a.CreatedAt()), cmp.Compare(b.ID().String(), a.ID().String()))
+		})
+
+		paginate.SetTotal(int64(len(owned)))
+
+		start := min(paginate.GetOffset(), len(owned))
+		end := min(start+paginate.GetLimit(), len(owned))
+		purchases = owned[start:end]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purchases, nil
+}
+
+func (r *InstallmentPurchaseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.InstallmentPurchase, error) {
+	var purchase entity.InstallmentPurchase
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.purchases[id]
+		if !ok {
+			return domainerror.NewNotFound("installment_purchase", id)
+		}
+		purchase = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+func (r *InstallmentPurchaseRepository) Create(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		data.purchases[purchase.ID()] = *purchase
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *purchase
+	return &created, nil
+}
+
+func (r *InstallmentPurchaseRepository) Update(ctx context.Context, purchase *entity.InstallmentPurchase) (*entity.InstallmentPurchase, error) {
+	var updated *entity.InstallmentPurchase
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.purchases[purchase.ID()]
+		if !ok {
+			return domainerror.NewNotFound("installment_purchase", purchase.ID())
+		}
+		if stored.Version() != purchase.Version() {
+			return repository.VersionMismatch("installment_purchase", purchase.ID())
+		}
+
+		var err error
+		updated, err = entity.RestoreInstallmentPurchase(purchase.ID(), purchase.UserID(), purchase.CategoryID(), purchase.Description(),
+			purchase.TotalAmount(), purchase.Remainder(), purchase.Installments(), purchase.Version()+1,
+			purchase.CreatedAt(), purchase.UpdatedAt(), purchase.CancelledAt())
+		if err != nil {
+			return err
+		}
+
+		data.purchases[updated.ID()] = *updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
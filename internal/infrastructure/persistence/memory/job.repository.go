@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type JobRepository struct {
+	store *Store
+}
+
+func NewJobRepository(store *Store) repository.JobRepositoryInterface {
+	return &JobRepository{store: store}
+}
+
+func (r *JobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	var job entity.Job
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.jobs[id]
+		if !ok {
+			return domainerror.NewNotFound("job", id)
+		}
+		job = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *JobRepository) FindNextDue(ctx context.Context, now time.Time) (*entity.Job, error) {
+	var due []entity.Job
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, job := range data.jobs {
+			queued := job.Status() == enum.JobStatusQueued && !job.RunAt().After(now)
+			abandoned := job.Status() == enum.JobStatusRunning && job.LeaseExpiresAt().Before(now)
+			if queued || abandoned {
+				due = append(due, job)
+			}
+		}
+		return nil
+	})
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+
+	next := slices.MinFunc(due, func(a, b entity.Job) int {
+		return cmp.Or(a.RunAt().Compare(b.RunAt()), cmp.Compare(a.ID().String(), b.ID().String()))
+	})
+	return &next, nil
+}
+
+func (r *JobRepository) FindFinishedBefore(ctx context.Context, before time.Time, limit int) ([]entity.Job, error) {
+	var finished []entity.Job
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, job := range data.jobs {
+			if !job.FinishedAt().IsZero() && job.FinishedAt().Before(before) {
+				finished = append(finished, job)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(finished, func(a, b entity.Job) int {
+		return cmp.Or(a.FinishedAt().Compare(b.FinishedAt()), cmp.Compare(a.ID().String(), b.ID().String()))
+	})
+	return finished[:min(limit, len(finished))], nil
+}
+
+func (r *JobRepository) Create(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		data.jobs[job.ID()] = *job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *job
+	return &created, nil
+}
+
+func (r *JobRepository) Update(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	var updated *entity.Job
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.jobs[job.ID()]
+		if !ok {
+			return domainerror.NewNotFound("job", job.ID())
+		}
+		if stored.Version() != job.Version() {
+			return repository.VersionMismatch("job", job.ID())
+		}
+
+		var err error
+		updated, err = entity.RestoreJob(job.ID(), job.UserID(), job.Kind(), job.Status(), job.Payload(), job.Progress(),
+			job.Attempts(), job.MaxAttempts(), job.RunAt(), job.LeaseExpiresAt(), job.Result(), job.Failure(),
+			job.Version()+1, job.CreatedAt(), job.UpdatedAt(), job.StartedAt(), job.FinishedAt())
+		if err != nil {
+			return err
+		}
+
+		data.jobs[updated.ID()] = *updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *JobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		if _, ok := data.jobs[id]; !ok {
+			return domainerror.NewNotFound("job", id)
+		}
+		delete(data.jobs, id)
+		return nil
+	})
+}
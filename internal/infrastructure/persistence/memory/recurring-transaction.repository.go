@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionRepository struct {
+	store *Store
+}
+
+func NewRecurringTransactionRepository(store *Store) repository.RecurringTransactionRepositoryInterface {
+	return &RecurringTransactionRepository{store: store}
+}
+
+func (r *RecurringTransactionRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.RecurringTransaction, error) {
+	var recurrings []entity.RecurringTransaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var owned []entity.RecurringTransaction
+		for _, recurring := range data.recurrings {
+			if recurring.UserID() == userID {
+				owned = append(owned, recurring)
+			}
+		}
+		slices.SortFunc(owned, func(a, b entity.RecurringTransaction) int {
+			return cmp.Or(b.CreatedAt().Compare(a.CreatedAt()), cmp.Compare(b.ID().String(), a.ID().String()))
+		})
+
+		paginate.SetTotal(int64(len(owned)))
+
+		start := min(paginate.GetOffset(), len(owned))
+		end := min(start+paginate.GetLimit(), len(owned))
+		recurrings = owned[start:end]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrings, nil
+}
+
+func (r *RecurringTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.RecurringTransaction, error) {
+	var recurring entity.RecurringTransaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.recurrings[id]
+		if !ok {
+			return domainerror.NewNotFound("recurring_transaction", id)
+		}
+		recurring = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurring, nil
+}
+
+func (r *RecurringTransactionRepository) FindDue(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]entity.RecurringTransaction, error) {
+	var due []entity.RecurringTransaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, recurring := range data.recurrings {
+			if !recurring.Ended() && !recurring.Paused() && !recurring.NextAt().After(now) && cmp.Compare(recurring.ID().String(), after.String()) > 0 {
+				due = append(due, recurring)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(due, func(a, b entity.RecurringTransaction) int {
+		return cmp.Compare(a.ID().String(), b.ID().String())
+	})
+	return due[:min(limit, len(due))], nil
+}
+
+func (r *RecurringTransactionRepository) Create(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		data.recurrings[recurring.ID()] = *recurring
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *recurring
+	return &created, nil
+}
+
+func (r *RecurringTransactionRepository) Update(ctx context.Context, recurring *entity.RecurringTransaction) (*entity.RecurringTransaction, error) {
+	var updated *entity.RecurringTransaction
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.recurrings[recurring.ID()]
+		if !ok {
+			return domainerror.NewNotFound("recurring_transaction", recurring.ID())
+		}
+		if stored.Version() != recurring.Version() {
+			return repository.VersionMismatch("recurring_transaction", recurring.ID())
+		}
+
+		var err error
+		updated, err = entity.RestoreRecurringTransaction(recurring.ID(), recurring.UserID(), recurring.CategoryID(), recurring.Amount(),
+			recurring.Description(), recurring.StartAt(), recurring.Rule(), recurring.Skipped(), recurring.Materialized(),
+			recurring.PausedAt(), recurring.PauseReason(), recurring.Version()+1, recurring.CreatedAt(), recurring.UpdatedAt())
+		if err != nil {
+			return err
+		}
+
+		data.recurrings[updated.ID()] = *updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *RecurringTransactionRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.recurrings[id]
+		if !ok {
+			return domainerror.NewNotFound("recurring_transaction", id)
+		}
+		if stored.Version() != version {
+			return repository.VersionMismatch("recurring_transaction", id)
+		}
+		delete(data.recurrings, id)
+		return nil
+	})
+}
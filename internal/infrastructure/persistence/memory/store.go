@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// Store keeps every record in process memory, used by tests and local fixtures
+type Store struct {
+	mu   sync.RWMutex // guards data
+	txMu sync.Mutex   // serializes writers so a committing transaction never discards another write
+	data *snapshot
+}
+
+// snapshot is the full state of the store, copied when a transaction or savepoint starts
+type snapshot struct {
+	transactions     map[uuid.UUID]entity.Transaction
+	transactionOrder []uuid.UUID
+	categories       map[uuid.UUID]entity.Category
+	auditEntries     []entity.AuditEntry
+	importProfiles   map[uuid.UUID]entity.ImportProfile
+	jobs             map[uuid.UUID]entity.Job
+	recurrings       map[uuid.UUID]entity.RecurringTransaction
+	purchases        map[uuid.UUID]entity.InstallmentPurchase
+
+	idempotencyRecords map[idempotencyID]entity.IdempotencyRecord
+}
+
+type snapshotKey struct{}
+
+func NewStore() *Store {
+	return &Store{
+		data: &snapshot{
+			transactions:   make(map[uuid.UUID]entity.Transaction),
+			categories:     make(map[uuid.UUID]entity.Category),
+			importProfiles: make(map[uuid.UUID]entity.ImportProfile),
+			jobs:           make(map[uuid.UUID]entity.Job),
+			recurrings:     make(map[uuid.UUID]entity.RecurringTransaction),
+			purchases:      make(map[uuid.UUID]entity.InstallmentPurchase),
+
+			idempotencyRecords: make(map[idempotencyID]entity.IdempotencyRecord),
+		},
+	}
+}
+
+func (s *snapshot) clone() *snapshot {
+	transactions := make(map[uuid.UUID]entity.Transaction, len(s.transactions))
+	for id, transaction := range s.transactions {
+		transactions[id] = transaction
+	}
+
+	categories := make(map[uuid.UUID]entity.Category, len(s.categories))
+	for id, category := range s.categories {
+		categories[id] = category
+	}
+
+	importProfiles := make(map[uuid.UUID]entity.ImportProfile, len(s.importProfiles))
+	for id, profile := range s.importProfiles {
+		importProfiles[id] = profile
+	}
+
+	jobs := make(map[uuid.UUID]entity.Job, len(s.jobs))
+	for id, job := range s.jobs {
+		jobs[id] = job
+	}
+
+	recurrings := make(map[uuid.UUID]entity.RecurringTransaction, len(s.recurrings))
+	for id, recurring := range s.recurrings {
+		recurrings[id] = recurring
+	}
+
+	purchases := make(map[uuid.UUID]entity.InstallmentPurchase, len(s.purchases))
+	for id, purchase := range s.purchases {
+		purchases[id] = purchase
+	}
+
+	idempotencyRecords := make(map[idempotencyID]entity.IdempotencyRecord, len(s.idempotencyRecords))
+	for id, record := range s.idempotencyRecords {
+		idempotencyRecords[id] = record
+	}
+
+	return &snapshot{
+		transactions:     transactions,
+		transactionOrder: append([]uuid.UUID(nil), s.transactionOrder...),
+		categories:       categories,
+		auditEntries:     append([]entity.AuditEntry(nil), s.auditEntries...),
+		importProfiles:   importProfiles,
+		jobs:             jobs,
+		recurrings:       recurrings,
+		purchases:        purchases,
+
+		idempotencyRecords: idempotencyRecords,
+	}
+}
+
+// read runs fn against the transaction bound to ctx, or against the committed data
+func (s *Store) read(ctx context.Context, fn func(data *snapshot) error) error {
+	if tx, ok := ctx.Value(snapshotKey{}).(*snapshot); ok {
+		return fn(tx)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write runs fn against the transaction bound to ctx, or directly against the committed data
+func (s *Store) write(ctx context.Context, fn func(data *snapshot) error) error {
+	if tx, ok := ctx.Value(snapshotKey{}).(*snapshot); ok {
+		return fn(tx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	working := s.data.clone()
+	if err := fn(working); err != nil {
+		return err
+	}
+	s.data = working
+	return nil
+}
@@ -0,0 +1,293 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type TransactionRepository struct {
+	store *Store
+}
+
+func NewTransactionRepository(store *Store) repository.TransactionRepositoryInterface {
+	return &TransactionRepository{store: store}
+}
+
+func (r *TransactionRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var owned []uuid.UUID
+		for _, id := range data.transactionOrder {
+			if transaction := data.transactions[id]; transaction.UserID() == userID && !transaction.Trashed() {
+				owned = append(owned, id)
+			}
+		}
+
+		paginate.SetTotal(int64(len(owned)))
+
+		start := min(paginate.GetOffset(), len(owned))
+		end := min(start+paginate.GetLimit(), len(owned))
+
+		transactions = make([]entity.Transaction, 0, end-start)
+		for _, id := range owned[start:end] {
+			transactions = append(transactions, data.transactions[id])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return r.find(ctx, id, false)
+}
+
+func (r *TransactionRepository) FindTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return r.find(ctx, id, true)
+}
+
+func (r *TransactionRepository) FindAllBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, id := range data.transactionOrder {
+			transaction := data.transactions[id]
+			if transaction.UserID() == userID && !transaction.Trashed() &&
+				!transaction.Datetime().Before(from) && transaction.Datetime().Before(to) {
+				transactions = append(transactions, transaction)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *TransactionRepository) Stream(ctx context.Context, userID uuid.UUID, fn func(transaction *entity.Transaction) error) error {
+	var transactions []entity.Transaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, id := range data.transactionOrder {
+			transaction := data.transactions[id]
+			if transaction.UserID() == userID && !transaction.Trashed() {
+				transactions = append(transactions, transaction)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Datetime().Before(transactions[j].Datetime())
+	})
+	for i := range transactions {
+		if err := fn(&transactions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) FindExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) ([]string, error) {
+	wanted := make(map[string]bool, len(externalIDs))
+	for _, externalID := range externalIDs {
+		wanted[externalID] = true
+	}
+
+	var found []string
+	err := r.store.read(ctx, func(data *snapshot) error {
+		for _, id := range data.transactionOrder {
+			transaction := data.transactions[id]
+			if transaction.UserID() == userID && transaction.ExternalID() != "" && wanted[transaction.ExternalID()] {
+				found = append(found, transaction.ExternalID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// find loads the transaction with the given id, provided its trash state is the one asked for
+func (r *TransactionRepository) find(ctx context.Context, id uuid.UUID, trashed bool) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		stored, ok := data.transactions[id]
+		if !ok || stored.Trashed() != trashed {
+			return domainerror.NewNotFound("transaction", id)
+		}
+		transaction = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	err := r.store.write(ctx, func(data *snapshot) error {
+		return insertTransaction(data, transaction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *transaction
+	return &created, nil
+}
+
+func (r *TransactionRepository) CreateBatch(ctx context.Context, transactions []*entity.Transaction) error {
+	return r.store.write(ctx, func(data *snapshot) error {
+		for _, transaction := range transactions {
+			if err := insertTransaction(data, transaction); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertTransaction adds a transaction unless its id, or its external id for the same user, is
+// taken already, as the unique indexes of the database do
+func insertTransaction(data *snapshot, transaction *entity.Transaction) error {
+	if _, exists := data.transactions[transaction.ID()]; exists {
+		return domainerror.NewConflict("transaction_already_exists", "transaction already exists", nil)
+	}
+	if transaction.ExternalID() != "" {
+		for _, stored := range data.transactions {
+			if stored.UserID() == transaction.UserID() && stored.ExternalID() == transaction.ExternalID() {
+				return domainerror.NewConflict("transaction_already_exists", "transaction already exists", nil)
+			}
+		}
+	}
+
+	data.transactions[transaction.ID()] = *transaction
+	data.transactionOrder = append(data.transactionOrder, transaction.ID())
+	return nil
+}
+
+func (r *TransactionRepository) Update(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	var updated *entity.Transaction
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		stored, ok := data.transactions[transaction.ID()]
+		if !ok {
+			return domainerror.NewNotFound("transaction", transaction.ID())
+		}
+		if stored.Version() != transaction.Version() {
+			return repository.VersionMismatch("transaction", transaction.ID())
+		}
+
+		var err error
+		updated, err = withTransactionChanges(transaction, transaction.DeletedAt(), transaction.UpdatedAt())
+		if err != nil {
+			return err
+		}
+
+		data.transactions[updated.ID()] = *updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *TransactionRepository) TrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time) ([]uuid.UUID, error) {
+	return r.updateByCategory(ctx, func(transaction entity.Transaction) bool {
+		return transaction.CategoryID() == categoryID && !transaction.Trashed()
+	}, deletedAt, deletedAt)
+}
+
+func (r *TransactionRepository) UntrashByCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time, updatedAt time.Time) ([]uuid.UUID, error) {
+	return r.updateByCategory(ctx, func(transaction entity.Transaction) bool {
+		return transaction.CategoryID() == categoryID && transaction.DeletedAt().Equal(deletedAt)
+	}, time.Time{}, updatedAt)
+}
+
+// updateByCategory sets the trash state of every transaction matched by match, bumping their
+// versions, and returns their ids in creation order
+func (r *TransactionRepository) updateByCategory(ctx context.Context, match func(entity.Transaction) bool, deletedAt, updatedAt time.Time) ([]uuid.UUID, error) {
+	var affected []uuid.UUID
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		for _, id := range data.transactionOrder {
+			transaction := data.transactions[id]
+			if !match(transaction) {
+				continue
+			}
+
+			updated, err := withTransactionChanges(&transaction, deletedAt, updatedAt)
+			if err != nil {
+				return err
+			}
+			data.transactions[id] = *updated
+			affected = append(affected, id)
+		}
+		return nil
+	})
+
+	return affected, err
+}
+
+func (r *TransactionRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := r.store.write(ctx, func(data *snapshot) error {
+		order := data.transactionOrder[:0]
+		for _, id := range data.transactionOrder {
+			if transaction := data.transactions[id]; transaction.Trashed() && transaction.DeletedAt().Before(before) {
+				delete(data.transactions, id)
+				purged++
+				continue
+			}
+			order = append(order, id)
+		}
+		data.transactionOrder = order
+		return nil
+	})
+
+	return purged, err
+}
+
+// withTransactionChanges copies transaction with the given trash state and the next version, as
+// stored by an update
+func withTransactionChanges(transaction *entity.Transaction, deletedAt, updatedAt time.Time) (*entity.Transaction, error) {
+	return entity.RestoreTransaction(
+		transaction.ID(),
+		transaction.CategoryID(),
+		transaction.UserID(),
+		transaction.Amount(),
+		transaction.Datetime(),
+		transaction.Description(),
+		transaction.ExternalID(),
+		transaction.Version()+1,
+		transaction.CreatedAt(),
+		updatedAt,
+		deletedAt,
+	)
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type TrashRepository struct {
+	store *Store
+}
+
+func NewTrashRepository(store *Store) repository.TrashRepositoryInterface {
+	return &TrashRepository{store: store}
+}
+
+func (r *TrashRepository) FindAllPaginated(ctx context.Context, userID uuid.UUID, paginate *pagination.Pagination) ([]entity.TrashItem, error) {
+	var items []entity.TrashItem
+
+	err := r.store.read(ctx, func(data *snapshot) error {
+		var trashed []entity.TrashItem
+		for _, transaction := range data.transactions {
+			if transaction.UserID() == userID && transaction.Trashed() {
+				trashed = append(trashed, *entity.RestoreTrashItem(enum.TrashItemKindTransaction, transaction.ID(), userID, transaction.Description(), transaction.DeletedAt()))
+			}
+		}
+		for _, category := range data.categories {
+			if category.UserID() == userID && category.Trashed() {
+				trashed = append(trashed, *entity.RestoreTrashItem(enum.TrashItemKindCategory, category.ID(), userID, category.Name(), category.DeletedAt()))
+			}
+		}
+		slices.SortFunc(trashed, func(a, b entity.TrashItem) int {
+			return cmp.Or(b.DeletedAt().Compare(a.DeletedAt()), cmp.Compare(a.ID().String(), b.ID().String()))
+		})
+
+		paginate.SetTotal(int64(len(trashed)))
+
+		start := min(paginate.GetOffset(), len(trashed))
+		end := min(start+paginate.GetLimit(), len(trashed))
+		items = trashed[start:end]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
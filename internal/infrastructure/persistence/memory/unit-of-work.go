@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/interfaces"
+)
+
+type UnitOfWork struct {
+	store *Store
+}
+
+func NewUnitOfWork(store *Store) interfaces.UnitOfWorkInterface {
+	return &UnitOfWork{store: store}
+}
+
+// Do works on a private copy of the data and publishes it only when fn succeeds.
+// Nested calls copy the enclosing transaction, which makes them behave like savepoints.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if parent, ok := ctx.Value(snapshotKey{}).(*snapshot); ok {
+		savepoint := parent.clone()
+		if err := fn(context.WithValue(ctx, snapshotKey{}, savepoint)); err != nil {
+			return err
+		}
+		*parent = *savepoint
+		return nil
+	}
+
+	u.store.txMu.Lock()
+	defer u.store.txMu.Unlock()
+
+	u.store.mu.RLock()
+	working := u.store.data.clone()
+	u.store.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, snapshotKey{}, working)); err != nil {
+		return err
+	}
+
+	u.store.mu.Lock()
+	u.store.data = working
+	u.store.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/identifier"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testUserID = uuid.New()
+
+func newTestTransaction(t *testing.T) *entity.Transaction {
+	transaction, err := entity.NewTransaction(clock.System(), identifier.NewV7(), uuid.New(), testUserID, 10.0, time.Now(), "test")
+	assert.Nil(t, err)
+	return transaction
+}
+
+func countTransactions(t *testing.T, ctx context.Context, repository *TransactionRepository) int64 {
+	paginate := pagination.NewPagination(1, 10)
+	_, err := repository.FindAllPaginated(ctx, testUserID, paginate)
+	assert.Nil(t, err)
+	return paginate.TotalItems
+}
+
+func TestUnitOfWork(t *testing.T) {
+	errRollback := errors.New("rollback")
+
+	t.Run("should commit every write when fn succeeds", func(t *testing.T) {
+		store := NewStore()
+		uow := NewUnitOfWork(store)
+		repository := &TransactionRepository{store: store}
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			if _, err := repository.Create(ctx, newTestTransaction(t)); err != nil {
+				return err
+			}
+			_, err := repository.Create(ctx, newTestTransaction(t))
+			return err
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), countTransactions(t, context.Background(), repository))
+	})
+
+	t.Run("should hide uncommitted writes from readers outside the transaction", func(t *testing.T) {
+		store := NewStore()
+		uow := NewUnitOfWork(store)
+		repository := &TransactionRepository{store: store}
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			_, err := repository.Create(ctx, newTestTransaction(t))
+			assert.Equal(t, int64(1), countTransactions(t, ctx, repository))
+			assert.Equal(t, int64(0), countTransactions(t, context.Background(), repository))
+			return err
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should discard every write when fn fails", func(t *testing.T) {
+		store := NewStore()
+		uow := NewUnitOfWork(store)
+		repository := &TransactionRepository{store: store}
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			if _, err := repository.Create(ctx, newTestTransaction(t)); err != nil {
+				return err
+			}
+			return errRollback
+		})
+
+		assert.ErrorIs(t, err, errRollback)
+		assert.Equal(t, int64(0), countTransactions(t, context.Background(), repository))
+	})
+
+	t.Run("should roll back only the failed savepoint", func(t *testing.T) {
+		store := NewStore()
+		uow := NewUnitOfWork(store)
+		repository := &TransactionRepository{store: store}
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			if _, err := repository.Create(ctx, newTestTransaction(t)); err != nil {
+				return err
+			}
+
+			nestedErr := uow.Do(ctx, func(ctx context.Context) error {
+				if _, err := repository.Create(ctx, newTestTransaction(t)); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			assert.ErrorIs(t, nestedErr, errRollback)
+
+			return uow.Do(ctx, func(ctx context.Context) error {
+				_, err := repository.Create(ctx, newTestTransaction(t))
+				return err
+			})
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), countTransactions(t, context.Background(), repository))
+	})
+
+	t.Run("should discard committed savepoints when the outer transaction fails", func(t *testing.T) {
+		store := NewStore()
+		uow := NewUnitOfWork(store)
+		repository := &TransactionRepository{store: store}
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				_, err := repository.Create(ctx, newTestTransaction(t))
+				return err
+			})
+			assert.Nil(t, err)
+			return errRollback
+		})
+
+		assert.ErrorIs(t, err, errRollback)
+		assert.Equal(t, int64(0), countTransactions(t, context.Background(), repository))
+	})
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+)
+
+// Limit is a token bucket refilled at Rate tokens per second and holding at most Burst tokens
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Decision is the outcome of taking one token from a bucket
+type Decision struct {
+	Allowed    bool
+	Limit      int           // Bucket capacity
+	Remaining  int           // Tokens left after this request
+	ResetAfter time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until the next token, when not allowed
+}
+
+// Store keeps the buckets. Implementations backed by shared storage let several instances
+// enforce a single limit
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore keeps the buckets in process memory and forgets the ones idle for longer than idleTTL
+type MemoryStore struct {
+	mu        sync.Mutex
+	clock     clock.Clock
+	idleTTL   time.Duration
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an in-process Store
+func NewMemoryStore(clock clock.Clock, idleTTL time.Duration) *MemoryStore {
+	return &MemoryStore{
+		clock:     clock,
+		idleTTL:   idleTTL,
+		buckets:   make(map[string]*bucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.sweep(now)
+
+	capacity := float64(limit.Burst)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	decision := Decision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.ResetAfter = seconds((capacity - b.tokens) / limit.Rate)
+
+	return decision, nil
+}
+
+// sweep drops buckets idle for longer than idleTTL; an idle bucket is full anyway
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.idleTTL {
+		return
+	}
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	limit := Limit{Rate: 1, Burst: 2}
+
+	t.Run("should allow a burst and then refuse until a token is refilled", func(t *testing.T) {
+		now := clock.NewFixed(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+		store := NewMemoryStore(now, time.Minute)
+
+		first, _ := store.Take(context.Background(), "user:1", limit)
+		second, _ := store.Take(context.Background(), "user:1", limit)
+		third, _ := store.Take(context.Background(), "user:1", limit)
+
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, time.Second, third.RetryAfter)
+		assert.Equal(t, 2*time.Second, third.ResetAfter)
+
+		now.Advance(time.Second)
+		fourth, _ := store.Take(context.Background(), "user:1", limit)
+
+		assert.True(t, fourth.Allowed)
+	})
+
+	t.Run("should keep a separate bucket per key", func(t *testing.T) {
+		store := NewMemoryStore(clock.NewFixed(time.Now()), time.Minute)
+
+		store.Take(context.Background(), "user:1", limit)
+		store.Take(context.Background(), "user:1", limit)
+		other, _ := store.Take(context.Background(), "ip:10.0.0.1", limit)
+
+		assert.True(t, other.Allowed)
+		assert.Equal(t, 1, other.Remaining)
+	})
+
+	t.Run("should forget idle buckets", func(t *testing.T) {
+		now := clock.NewFixed(time.Now())
+		store := NewMemoryStore(now, time.Minute)
+
+		store.Take(context.Background(), "user:1", limit)
+		now.Advance(2 * time.Minute)
+		store.Take(context.Background(), "user:2", limit)
+
+		assert.Len(t, store.buckets, 1)
+	})
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/application/dto"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/entity/enum"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/hateoas"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+	"github.com/google/uuid"
+)
+
+// ImportRowResponse is a data row of a statement with the transaction read from it, the id of
+// the transaction created from it once imported, or the problem that keeps it from being imported
+type ImportRowResponse struct {
+	Line          int                  `json:"line"`
+	Status        enum.ImportRowStatus `json:"status"`
+	Raw           []string             `json:"raw"`
+	Data          *ImportedRowResponse `json:"data,omitempty"`
+	TransactionID *uuid.UUID           `json:"transactionId,omitempty"`
+	Error         *problem.Problem     `json:"error,omitempty"`
+}
+
+type ImportedRowResponse struct {
+	CategoryID  uuid.UUID `json:"categoryId"`
+	Amount      float64   `json:"amount"`
+	Datetime    time.Time `json:"datetime"`
+	Description string    `json:"description"`
+	ExternalID  string    `json:"externalId,omitempty"`
+}
+
+// ImportSummaryResponse counts the rows of a statement by status
+type ImportSummaryResponse struct {
+	Rows      int `json:"rows"`
+	Ready     int `json:"ready"`
+	Duplicate int `json:"duplicate"`
+	Invalid   int `json:"invalid"`
+	Imported  int `json:"imported"`
+}
+
+type ImportResponse struct {
+	Committed bool                  `json:"committed"`
+	Summary   ImportSummaryResponse `json:"summary"`
+	Rows      []ImportRowResponse   `json:"rows"`
+}
+
+// BuildImportResponse reports every row of an import, using describe to turn errors into
+// problems, such as DescribeError. A committed import that created transactions answers 201,
+// anything else 200.
+func BuildImportResponse(result *dto.ImportResultDTO, describe func(error) *problem.Problem) *hateoas.Response {
+	importResponse := ImportResponse{
+		Committed: result.Committed,
+		Summary:   ImportSummaryResponse{Rows: len(result.Rows)},
+		Rows:      make([]ImportRowResponse, len(result.Rows)),
+	}
+
+	for i, row := range result.Rows {
+		rowResponse := ImportRowResponse{Line: row.Line, Status: row.Status, Raw: row.Raw}
+
+		if row.Transaction != nil {
+			rowResponse.Data = &ImportedRowResponse{
+				CategoryID:  row.Transaction.CategoryID,
+				Amount:      row.Transaction.Amount,
+				Datetime:    row.Transaction.Datetime,
+				Description: row.Transaction.Description,
+				ExternalID:  row.Transaction.ExternalID,
+			}
+		}
+		if row.Imported != nil {
+			id := row.Imported.ID()
+			rowResponse.TransactionID = &id
+		}
+		if row.Err != nil {
+			rowResponse.Error = describe(row.Err)
+		}
+
+		switch row.Status {
+		case enum.ImportRowStatusReady:
+			importResponse.Summary.Ready++
+		case enum.ImportRowStatusDuplicate:
+			importResponse.Summary.Duplicate++
+		case enum.ImportRowStatusInvalid:
+			importResponse.Summary.Invalid++
+		case enum.ImportRowStatusImported:
+			importResponse.Summary.Imported++
+		}
+
+		importResponse.Rows[i] = rowResponse
+	}
+
+	statusCode := http.StatusOK
+	if importResponse.Summary.Imported > 0 {
+		statusCode = http.StatusCreated
+	}
+
+	return hateoas.NewResponse(importResponse, statusCode)
+}
@@ -0,0 +1,83 @@
+// Package report builds the documents that describe the outcome of an operation, problem
+// documents for errors and import reports, the same whether they are answered to a request or
+// written by a background job
+package report
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/gabrieltorresdev/backend-flux-control/internal/infrastructure/i18n"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/logger"
+	"github.com/gabrieltorresdev/backend-flux-control/pkg/problem"
+)
+
+const problemTypePrefix = "urn:flux-control:problem:"
+
+var statusByKind = map[domainerror.Kind]int{
+	domainerror.KindInvalidInput: http.StatusBadRequest,
+	domainerror.KindValidation:   http.StatusUnprocessableEntity,
+	domainerror.KindNotFound:     http.StatusNotFound,
+	domainerror.KindConflict:     http.StatusConflict,
+	domainerror.KindForbidden:    http.StatusForbidden,
+
+	domainerror.KindUnauthenticated: http.StatusUnauthorized,
+	domainerror.KindTooLarge:        http.StatusRequestEntityTooLarge,
+	domainerror.KindRateLimited:     http.StatusTooManyRequests,
+
+	domainerror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	domainerror.KindPreconditionRequired: http.StatusPreconditionRequired,
+}
+
+// DescribeError describes err as a problem document in the language ctx carries. Domain errors
+// are mapped to their status; anything else is logged and described as a generic 500.
+func DescribeError(ctx context.Context, err error) *problem.Problem {
+	if p, ok := domainProblem(ctx, err); ok {
+		return p
+	}
+
+	logger.FromContext(ctx).ErrorContext(ctx, "internal error", "error", err)
+	return internalProblem(ctx)
+}
+
+// domainProblem describes a domain error as a problem document in the language ctx carries,
+// reporting false for any other error
+func domainProblem(ctx context.Context, err error) (*problem.Problem, bool) {
+	domainErr, ok := domainerror.As(err)
+	if !ok {
+		return nil, false
+	}
+	status, known := statusByKind[domainErr.Kind]
+	if !known {
+		return nil, false
+	}
+
+	lang := i18n.LanguageFromContext(ctx)
+	message := i18n.Translate(lang, domainErr.Code, domainErr.Params, domainErr.Message)
+
+	p := problem.New(status, problemTypePrefix+string(domainErr.Kind), message).
+		WithCode(domainErr.Code)
+
+	if domainErr.Field != "" {
+		p.WithErrors(problem.FieldError{Field: domainErr.Field, Code: domainErr.Code, Message: message})
+	}
+	for _, detail := range domainErr.Details {
+		p.WithErrors(problem.FieldError{
+			Field:   detail.Field,
+			Code:    detail.Code,
+			Message: i18n.Translate(lang, detail.Code, detail.Params, detail.Message),
+		})
+	}
+
+	return p, true
+}
+
+// internalProblem is the generic 500 problem, which says nothing about what went wrong
+func internalProblem(ctx context.Context) *problem.Problem {
+	lang := i18n.LanguageFromContext(ctx)
+	message := i18n.Translate(lang, "internal_error", nil, "an unexpected error occurred")
+
+	return problem.New(http.StatusInternalServerError, problemTypePrefix+"internal", message).
+		WithCode("internal_error")
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabrieltorresdev/backend-flux-control/internal/domain/domainerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should read a file once it is closed", func(t *testing.T) {
+		storage, err := NewLocalStorage(t.TempDir())
+		assert.Nil(t, err)
+
+		file, err := storage.Create(ctx, "jobs/1/result")
+		assert.Nil(t, err)
+		_, err = io.WriteString(file, "content")
+		assert.Nil(t, err)
+
+		_, err = storage.Open(ctx, "jobs/1/result")
+		assert.True(t, domainerror.IsKind(err, domainerror.KindNotFound))
+
+		assert.Nil(t, file.Close())
+		stored, err := storage.Open(ctx, "jobs/1/result")
+		assert.Nil(t, err)
+		content, err := io.ReadAll(stored)
+		assert.Nil(t, err)
+		assert.Nil(t, stored.Close())
+		assert.Equal(t, "content", string(content))
+	})
+
+	t.Run("should delete the file and its directory once empty", func(t *testing.T) {
+		dir := t.TempDir()
+		storage, _ := NewLocalStorage(dir)
+		file, _ := storage.Create(ctx, "jobs/1/result")
+		assert.Nil(t, file.Close())
+
+		assert.Nil(t, storage.Delete(ctx, "jobs/1/result"))
+		assert.Nil(t, storage.Delete(ctx, "jobs/1/result"))
+
+		_, err := os.Stat(filepath.Join(dir, "jobs", "1"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("should refuse keys outside of the directory", func(t *testing.T) {
+		storage, _ := NewLocalStorage(t.TempDir())
+
+		_, err := storage.Create(ctx, "../escape")
+		assert.NotNil(t, err)
+		_, err = storage.Open(ctx, "/etc/passwd")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Options configures a structured logger
+type Options struct {
+	Level  string // debug, info, warn or error
+	Format string // json or text
+	Redact bool   // Mask personal data such as emails and descriptions
+}
+
+// New creates a slog logger writing to out according to opts
+func New(out io.Writer, opts Options) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(defaultString(opts.Level, "info"))); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", opts.Level, err)
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: level}
+	if opts.Redact {
+		handlerOptions.ReplaceAttr = RedactAttr
+	}
+
+	switch strings.ToLower(defaultString(opts.Format, "json")) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(out, handlerOptions)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(out, handlerOptions)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: expected json or text", opts.Format)
+	}
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
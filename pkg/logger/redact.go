@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values that must not reach the logs
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are always masked
+var sensitiveKeys = map[string]bool{
+	"email":       true,
+	"description": true,
+	"password":    true,
+	"token":       true,
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// RedactAttr is a slog ReplaceAttr function that masks sensitive attributes by key, and email
+// addresses and bearer tokens embedded in any other value logged as text: strings, errors,
+// fmt.Stringers and whatever a slog.LogValuer resolves to
+func RedactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	value, ok := textOf(attr.Value.Resolve())
+	if !ok {
+		return attr
+	}
+	if redacted := redactText(value); redacted != value {
+		return slog.String(attr.Key, redacted)
+	}
+
+	return attr
+}
+
+// textOf is the text a value is logged as, if it is logged as text at all
+func textOf(value slog.Value) (string, bool) {
+	switch value.Kind() {
+	case slog.KindString:
+		return value.String(), true
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return v.Error(), true
+		case fmt.Stringer:
+			return v.String(), true
+		}
+	}
+	return "", false
+}
+
+func redactText(value string) string {
+	if strings.Contains(value, "@") {
+		value = emailPattern.ReplaceAllString(value, Redacted)
+	}
+	return bearerPattern.ReplaceAllString(value, "Bearer "+Redacted)
+}
@@ -0,0 +1,73 @@
+package problem
+
+import (
+	"net/http"
+)
+
+// ContentType is the media type of problem details documents (RFC 7807)
+const ContentType = "application/problem+json"
+
+// FieldError describes a problem with a single input field
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details document, extended with a machine readable code,
+// the request correlation and trace IDs, and field-level errors
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+	TraceID   string       `json:"traceId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// New creates a problem for the given status, titled after the standard status text
+func New(status int, problemType string, detail string) *Problem {
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+
+	return &Problem{
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WithCode sets the machine readable error code
+func (p *Problem) WithCode(code string) *Problem {
+	p.Code = code
+	return p
+}
+
+// WithInstance sets the URI reference of the failing request
+func (p *Problem) WithInstance(instance string) *Problem {
+	p.Instance = instance
+	return p
+}
+
+// WithRequestID sets the correlation ID of the failing request
+func (p *Problem) WithRequestID(requestID string) *Problem {
+	p.RequestID = requestID
+	return p
+}
+
+// WithTraceID sets the distributed trace ID of the failing request
+func (p *Problem) WithTraceID(traceID string) *Problem {
+	p.TraceID = traceID
+	return p
+}
+
+// WithErrors appends field-level errors
+func (p *Problem) WithErrors(errors ...FieldError) *Problem {
+	p.Errors = append(p.Errors, errors...)
+	return p
+}
@@ -0,0 +1,27 @@
+package readafterwrite
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type contextKey struct{}
+
+// WithTracking returns a copy of ctx that remembers whether a write happened in it, so later
+// reads in the same request can avoid replicas that may not have caught up yet
+func WithTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, new(atomic.Bool))
+}
+
+// MarkWritten records a write in ctx. It is a no-op when ctx is not tracking
+func MarkWritten(ctx context.Context) {
+	if written, ok := ctx.Value(contextKey{}).(*atomic.Bool); ok {
+		written.Store(true)
+	}
+}
+
+// Written reports whether a write has been recorded in ctx
+func Written(ctx context.Context) bool {
+	written, ok := ctx.Value(contextKey{}).(*atomic.Bool)
+	return ok && written.Load()
+}
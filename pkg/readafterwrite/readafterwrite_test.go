@@ -0,0 +1,30 @@
+package readafterwrite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAfterWrite(t *testing.T) {
+	t.Run("should report writes recorded in a derived context", func(t *testing.T) {
+		ctx := WithTracking(context.Background())
+		child, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		assert.False(t, Written(ctx))
+
+		MarkWritten(child)
+
+		assert.True(t, Written(ctx))
+	})
+
+	t.Run("should ignore writes when the context is not tracking", func(t *testing.T) {
+		ctx := context.Background()
+
+		MarkWritten(ctx)
+
+		assert.False(t, Written(ctx))
+	})
+}
@@ -0,0 +1,29 @@
+package requestid
+
+import (
+	"context"
+	"regexp"
+)
+
+// Header is the HTTP header used to receive and propagate request IDs
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// IsValid reports whether an incoming request ID is safe to reuse in headers and logs
+func IsValid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or an empty string
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}